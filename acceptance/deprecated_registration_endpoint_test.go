@@ -86,8 +86,8 @@ var _ = Describe("notifications can be registered, using the deprecated /registr
 			Expect(data).To(ContainElement("X-CF-Client-ID: notifications-sender"))
 			Expect(data).To(ContainElement("X-CF-Notification-ID: " + response.NotificationID))
 			Expect(data).To(ContainElement("Subject: CF Notification: my-special-subject"))
-			Expect(data).To(ContainElement("\t\t<p>This message was sent directly to you.</p><p>this is an acceptance%40te="))
-			Expect(data).To(ContainElement("st</p>"))
+			Expect(data).To(ContainElement("\t\t<p>This message was sent directly to you.</p><p>this is an acceptance%40t="))
+			Expect(data).To(ContainElement("est</p>"))
 			Expect(data).To(ContainElement("hello from the acceptance test"))
 		})
 
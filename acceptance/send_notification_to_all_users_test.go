@@ -15,7 +15,6 @@ import (
 var _ = Describe("Send a notification to all users of UAA", func() {
 	It("sends an email notification to all users of UAA", func() {
 		var templateID string
-		indexedResponses := map[string]support.NotifyResponse{}
 		clientID := "notifications-sender"
 		clientToken := GetClientTokenFor(clientID)
 		client := support.NewClient(Servers.Notifications.URL())
@@ -64,21 +63,12 @@ var _ = Describe("Send a notification to all users of UAA", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(status).To(Equal(http.StatusOK))
 
-			Expect(responses).To(HaveLen(2))
+			Expect(responses).To(HaveLen(1))
 
-			for _, resp := range responses {
-				indexedResponses[resp.Recipient] = resp
-			}
-
-			responseItem := indexedResponses["091b6583-0933-4d17-a5b6-66e54666c88e"]
-			Expect(responseItem.Recipient).To(Equal("091b6583-0933-4d17-a5b6-66e54666c88e"))
-			Expect(responseItem.Status).To(Equal("queued"))
-			Expect(GUIDRegex.MatchString(responseItem.NotificationID)).To(BeTrue())
-
-			responseItem = indexedResponses["943e6076-b1a5-4404-811b-a1ee9253bf56"]
-			Expect(responseItem.Recipient).To(Equal("943e6076-b1a5-4404-811b-a1ee9253bf56"))
-			Expect(responseItem.Status).To(Equal("queued"))
-			Expect(GUIDRegex.MatchString(responseItem.NotificationID)).To(BeTrue())
+			response := responses[0]
+			Expect(response.Recipient).To(BeEmpty())
+			Expect(response.Status).To(Equal("queued"))
+			Expect(GUIDRegex.MatchString(response.CampaignID)).To(BeTrue())
 		})
 
 		By("confirming the messages were sent", func() {
@@ -102,10 +92,10 @@ var _ = Describe("Send a notification to all users of UAA", func() {
 
 			data := strings.Split(string(delivery.Data), "\n")
 			Expect(data).To(ContainElement("X-CF-Client-ID: notifications-sender"))
-			Expect(data).To(ContainElement("X-CF-Notification-ID: " + indexedResponses["091b6583-0933-4d17-a5b6-66e54666c88e"].NotificationID))
+			Expect(data).To(ContainElement(MatchRegexp("^X-CF-Notification-ID: ")))
 			Expect(data).To(ContainElement("Subject: Genetics gone awry"))
-			Expect(data).To(ContainElement("\t\t<h1>T-Rex</h1><p>this is an acceptance-test</p><b>This message was sent to="))
-			Expect(data).To(ContainElement(" everyone.</b>"))
+			Expect(data).To(ContainElement("\t\t<h1>T-Rex</h1><p>this is an acceptance-test</p><b>This message was sent t="))
+			Expect(data).To(ContainElement("o everyone.</b>"))
 			Expect(data).To(ContainElement("T-Rex"))
 			Expect(data).To(ContainElement("oh no!"))
 			Expect(data).To(ContainElement("This message was sent to everyone."))
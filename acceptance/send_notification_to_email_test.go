@@ -84,8 +84,8 @@ var _ = Describe("Send a notification to an email", func() {
 			Expect(data).To(ContainElement("X-CF-Client-ID: notifications-sender"))
 			Expect(data).To(ContainElement("X-CF-Notification-ID: " + response.NotificationID))
 			Expect(data).To(ContainElement("Subject: Boldness my-special-subject"))
-			Expect(data).To(ContainElement("\t\t<p>Enterprise</p><header>this is an acceptance test</header><h1>This messa="))
-			Expect(data).To(ContainElement("ge was sent directly to your email address.</h1>"))
+			Expect(data).To(ContainElement("\t\t<p>Enterprise</p><header>this is an acceptance test</header><h1>This mess="))
+			Expect(data).To(ContainElement("age was sent directly to your email address.</h1>"))
 			Expect(data).To(ContainElement("Enterprise"))
 			Expect(data).To(ContainElement("some text for the email"))
 			Expect(data).To(ContainElement("This message was sent directly to your email address."))
@@ -75,9 +75,9 @@ var _ = Describe("Sending notifications to users with certain roles in an organi
 			Expect(responses).To(HaveLen(1))
 
 			response = responses[0]
-			Expect(response.Recipient).To(Equal("user-456"))
+			Expect(response.Recipient).To(Equal("org-123"))
 			Expect(response.Status).To(Equal("queued"))
-			Expect(GUIDRegex.MatchString(response.NotificationID)).To(BeTrue())
+			Expect(GUIDRegex.MatchString(response.CampaignID)).To(BeTrue())
 		})
 
 		By("confirming the messages were sent", func() {
@@ -92,15 +92,15 @@ var _ = Describe("Sending notifications to users with certain roles in an organi
 
 			data := strings.Split(string(delivery.Data), "\n")
 			Expect(data).To(ContainElement("X-CF-Client-ID: notifications-sender"))
-			Expect(data).To(ContainElement("X-CF-Notification-ID: " + response.NotificationID))
+			Expect(data).To(ContainElement(MatchRegexp("^X-CF-Notification-ID: ")))
 			Expect(data).To(ContainElement("Subject: Phone home organization-role-subject"))
 			Expect(data).To(ContainElement("Cat"))
 			Expect(data).To(ContainElement("this is an organization role test"))
-			Expect(data).To(ContainElement("You received this message because you are an OrgManager in the notifications="))
-			Expect(data).To(ContainElement("-service organization."))
-			Expect(data).To(ContainElement("\t\t<h1>Cat</h1>this is another organization role test<header>You received thi="))
-			Expect(data).To(ContainElement("s message because you are an OrgManager in the notifications-service organiz="))
-			Expect(data).To(ContainElement("ation.</header>"))
+			Expect(data).To(ContainElement("You received this message because you are an OrgManager in the notification="))
+			Expect(data).To(ContainElement("s-service organization."))
+			Expect(data).To(ContainElement("\t\t<h1>Cat</h1>this is another organization role test<header>You received th="))
+			Expect(data).To(ContainElement("is message because you are an OrgManager in the notifications-service organ="))
+			Expect(data).To(ContainElement("ization.</header>"))
 		})
 	})
 
@@ -119,10 +119,9 @@ var _ = Describe("Sending notifications to users with certain roles in an organi
 			Expect(responses).To(HaveLen(1))
 
 			response = responses[0]
-
-			Expect(response.Recipient).To(Equal("user-123"))
+			Expect(response.Recipient).To(Equal("org-123"))
 			Expect(response.Status).To(Equal("queued"))
-			Expect(GUIDRegex.MatchString(response.NotificationID)).To(BeTrue())
+			Expect(GUIDRegex.MatchString(response.CampaignID)).To(BeTrue())
 		})
 
 		By("confirming that the messages were sent", func() {
@@ -137,15 +136,15 @@ var _ = Describe("Sending notifications to users with certain roles in an organi
 
 			data := strings.Split(string(delivery.Data), "\n")
 			Expect(data).To(ContainElement("X-CF-Client-ID: notifications-sender"))
-			Expect(data).To(ContainElement("X-CF-Notification-ID: " + response.NotificationID))
+			Expect(data).To(ContainElement(MatchRegexp("^X-CF-Notification-ID: ")))
 			Expect(data).To(ContainElement("Subject: Phone home organization-role-subject"))
 			Expect(data).To(ContainElement("Cat"))
 			Expect(data).To(ContainElement("this is an organization role test"))
-			Expect(data).To(ContainElement("You received this message because you are an OrgAuditor in the notifications="))
-			Expect(data).To(ContainElement("-service organization."))
-			Expect(data).To(ContainElement("\t\t<h1>Cat</h1>this is another organization role test<header>You received thi="))
-			Expect(data).To(ContainElement("s message because you are an OrgAuditor in the notifications-service organiz="))
-			Expect(data).To(ContainElement("ation.</header>"))
+			Expect(data).To(ContainElement("You received this message because you are an OrgAuditor in the notification="))
+			Expect(data).To(ContainElement("s-service organization."))
+			Expect(data).To(ContainElement("\t\t<h1>Cat</h1>this is another organization role test<header>You received th="))
+			Expect(data).To(ContainElement("is message because you are an OrgAuditor in the notifications-service organ="))
+			Expect(data).To(ContainElement("ization.</header>"))
 		})
 	})
 
@@ -164,10 +163,9 @@ var _ = Describe("Sending notifications to users with certain roles in an organi
 			Expect(responses).To(HaveLen(1))
 
 			response = responses[0]
-
-			Expect(response.Recipient).To(Equal("user-111"))
+			Expect(response.Recipient).To(Equal("org-123"))
 			Expect(response.Status).To(Equal("queued"))
-			Expect(GUIDRegex.MatchString(response.NotificationID)).To(BeTrue())
+			Expect(GUIDRegex.MatchString(response.CampaignID)).To(BeTrue())
 		})
 
 		By("confirming that the messages were sent", func() {
@@ -182,15 +180,15 @@ var _ = Describe("Sending notifications to users with certain roles in an organi
 
 			data := strings.Split(string(delivery.Data), "\n")
 			Expect(data).To(ContainElement("X-CF-Client-ID: notifications-sender"))
-			Expect(data).To(ContainElement("X-CF-Notification-ID: " + response.NotificationID))
+			Expect(data).To(ContainElement(MatchRegexp("^X-CF-Notification-ID: ")))
 			Expect(data).To(ContainElement("Subject: Phone home organization-role-subject"))
 			Expect(data).To(ContainElement("Cat"))
 			Expect(data).To(ContainElement("this is an organization role test"))
-			Expect(data).To(ContainElement("You received this message because you are an BillingManager in the notificat="))
-			Expect(data).To(ContainElement("ions-service organization."))
-			Expect(data).To(ContainElement("\t\t<h1>Cat</h1>this is another organization role test<header>You received thi="))
-			Expect(data).To(ContainElement("s message because you are an BillingManager in the notifications-service org="))
-			Expect(data).To(ContainElement("anization.</header>"))
+			Expect(data).To(ContainElement("You received this message because you are an BillingManager in the notifica="))
+			Expect(data).To(ContainElement("tions-service organization."))
+			Expect(data).To(ContainElement("\t\t<h1>Cat</h1>this is another organization role test<header>You received th="))
+			Expect(data).To(ContainElement("is message because you are an BillingManager in the notifications-service o="))
+			Expect(data).To(ContainElement("rganization.</header>"))
 		})
 	})
 
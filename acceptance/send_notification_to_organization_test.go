@@ -15,7 +15,7 @@ import (
 var _ = Describe("Sending notifications to all users in an organization", func() {
 	It("sends a notification to each user in an organization", func() {
 		var templateID string
-		indexedResponses := map[string]support.NotifyResponse{}
+		var response support.NotifyResponse
 		clientID := "notifications-sender"
 		clientToken := GetClientTokenFor(clientID)
 		client := support.NewClient(Servers.Notifications.URL())
@@ -62,26 +62,12 @@ var _ = Describe("Sending notifications to all users in an organization", func()
 			})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(status).To(Equal(http.StatusOK))
-			Expect(responses).To(HaveLen(3))
+			Expect(responses).To(HaveLen(1))
 
-			for _, response := range responses {
-				indexedResponses[response.Recipient] = response
-			}
-
-			response := indexedResponses["user-456"]
-			Expect(response.Recipient).To(Equal("user-456"))
-			Expect(response.Status).To(Equal("queued"))
-			Expect(GUIDRegex.MatchString(response.NotificationID)).To(BeTrue())
-
-			response = indexedResponses["user-789"]
-			Expect(response.Recipient).To(Equal("user-789"))
-			Expect(response.Status).To(Equal("queued"))
-			Expect(GUIDRegex.MatchString(response.NotificationID)).To(BeTrue())
-
-			response = indexedResponses["user-000"]
-			Expect(response.Recipient).To(Equal("user-000"))
+			response = responses[0]
+			Expect(response.Recipient).To(Equal("org-123"))
 			Expect(response.Status).To(Equal("queued"))
-			Expect(GUIDRegex.MatchString(response.NotificationID)).To(BeTrue())
+			Expect(GUIDRegex.MatchString(response.CampaignID)).To(BeTrue())
 		})
 
 		By("confirming the messages were sent", func() {
@@ -96,14 +82,14 @@ var _ = Describe("Sending notifications to all users in an organization", func()
 
 			data := strings.Split(string(delivery.Data), "\n")
 			Expect(data).To(ContainElement("X-CF-Client-ID: notifications-sender"))
-			Expect(data).To(ContainElement("X-CF-Notification-ID: " + indexedResponses["user-456"].NotificationID))
+			Expect(data).To(ContainElement(MatchRegexp("^X-CF-Notification-ID: ")))
 			Expect(data).To(ContainElement("Subject: Coca cola organization-subject"))
-			Expect(data).To(ContainElement("\t\t<h1>Rat</h1>this is an organization test<section>You received this message="))
-			Expect(data).To(ContainElement(" because you belong to the notifications-service organization.</section>"))
+			Expect(data).To(ContainElement("\t\t<h1>Rat</h1>this is an organization test<section>You received this messag="))
+			Expect(data).To(ContainElement("e because you belong to the notifications-service organization.</section>"))
 			Expect(data).To(ContainElement("Rat"))
 			Expect(data).To(ContainElement("this is an organization test"))
-			Expect(data).To(ContainElement("You received this message because you belong to the notifications-service or="))
-			Expect(data).To(ContainElement("ganization."))
+			Expect(data).To(ContainElement("You received this message because you belong to the notifications-service o="))
+			Expect(data).To(ContainElement("rganization."))
 		})
 	})
 })
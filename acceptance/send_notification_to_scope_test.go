@@ -15,7 +15,7 @@ import (
 var _ = Describe("Sending notifications to users with certain scopes", func() {
 	It("sends a notification to each user with the scope", func() {
 		var templateID string
-		indexedResponses := map[string]support.NotifyResponse{}
+		var response support.NotifyResponse
 
 		client := support.NewClient(Servers.Notifications.URL())
 		clientID := "notifications-sender"
@@ -68,17 +68,13 @@ var _ = Describe("Sending notifications to users with certain scopes", func() {
 			Expect(status).To(Equal(http.StatusOK))
 			Expect(responses).To(HaveLen(1))
 
-			for _, response := range responses {
-				indexedResponses[response.Recipient] = response
-			}
+			response = responses[0]
+			Expect(response.Recipient).To(Equal(scope))
+			Expect(response.Status).To(Equal("queued"))
+			Expect(GUIDRegex.MatchString(response.CampaignID)).To(BeTrue())
 		})
 
 		By("confirming that the messages were delivered", func() {
-			response := indexedResponses["user-369"]
-			Expect(response.Recipient).To(Equal("user-369"))
-			Expect(response.Status).To(Equal("queued"))
-			Expect(GUIDRegex.MatchString(response.NotificationID)).To(BeTrue())
-
 			Eventually(func() int {
 				return len(Servers.SMTP.Deliveries)
 			}, 1*time.Second).Should(Equal(1))
@@ -90,10 +86,10 @@ var _ = Describe("Sending notifications to users with certain scopes", func() {
 
 			data := strings.Split(string(delivery.Data), "\n")
 			Expect(data).To(ContainElement("X-CF-Client-ID: notifications-sender"))
-			Expect(data).To(ContainElement("X-CF-Notification-ID: " + indexedResponses["user-369"].NotificationID))
+			Expect(data).To(ContainElement(MatchRegexp("^X-CF-Notification-ID: ")))
 			Expect(data).To(ContainElement("Subject: Food scope-subject"))
-			Expect(data).To(ContainElement("\t\t<h1>Fish</h1>this is a scope test<b>You received this message because you ="))
-			Expect(data).To(ContainElement("have the this.scope scope.</b>"))
+			Expect(data).To(ContainElement("\t\t<h1>Fish</h1>this is a scope test<b>You received this message because you="))
+			Expect(data).To(ContainElement(" have the this.scope scope.</b>"))
 			Expect(data).To(ContainElement("this is a scope test"))
 			Expect(data).To(ContainElement("Fish"))
 			Expect(data).To(ContainElement("You received this message because you have the this.scope scope."))
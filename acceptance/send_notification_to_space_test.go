@@ -19,7 +19,7 @@ var _ = Describe("Sending notifications to all users in a space", func() {
 		clientID := "notifications-sender"
 		clientToken := GetClientTokenFor(clientID)
 		spaceID := "space-123"
-		indexedResponses := map[string]support.NotifyResponse{}
+		var response support.NotifyResponse
 
 		By("registering a client with a notification", func() {
 			status, err := client.Notifications.Register(clientToken.Access, support.RegisterClient{
@@ -65,26 +65,12 @@ var _ = Describe("Sending notifications to all users in a space", func() {
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(status).To(Equal(http.StatusOK))
-			Expect(responses).To(HaveLen(3))
+			Expect(responses).To(HaveLen(1))
 
-			for _, response := range responses {
-				indexedResponses[response.Recipient] = response
-			}
-
-			response := indexedResponses["user-456"]
-			Expect(response.Recipient).To(Equal("user-456"))
-			Expect(response.Status).To(Equal("queued"))
-			Expect(GUIDRegex.MatchString(response.NotificationID)).To(BeTrue())
-
-			response = indexedResponses["user-789"]
-			Expect(response.Recipient).To(Equal("user-789"))
+			response = responses[0]
+			Expect(response.Recipient).To(Equal(spaceID))
 			Expect(response.Status).To(Equal("queued"))
-			Expect(GUIDRegex.MatchString(response.NotificationID)).To(BeTrue())
-
-			response = indexedResponses["user-000"]
-			Expect(response.Recipient).To(Equal("user-000"))
-			Expect(response.Status).To(Equal("queued"))
-			Expect(GUIDRegex.MatchString(response.NotificationID)).To(BeTrue())
+			Expect(GUIDRegex.MatchString(response.CampaignID)).To(BeTrue())
 		})
 
 		By("confirming the messages were sent", func() {
@@ -99,15 +85,31 @@ var _ = Describe("Sending notifications to all users in a space", func() {
 
 			data := strings.Split(string(delivery.Data), "\n")
 			Expect(data).To(ContainElement("X-CF-Client-ID: notifications-sender"))
-			Expect(data).To(ContainElement("X-CF-Notification-ID: " + indexedResponses["user-456"].NotificationID))
+			Expect(data).To(ContainElement(MatchRegexp("^X-CF-Notification-ID: ")))
 			Expect(data).To(ContainElement("Subject: Aliens space-subject"))
-			Expect(data).To(ContainElement("\t\t<h1>Dogs</h1>this is a space test<h2>You received this message because you="))
-			Expect(data).To(ContainElement(" belong to the notifications-service space in the notifications-service orga="))
-			Expect(data).To(ContainElement("nization.</h2>"))
+			Expect(data).To(ContainElement("\t\t<h1>Dogs</h1>this is a space test<h2>You received this message because yo="))
+			Expect(data).To(ContainElement("u belong to the notifications-service space in the notifications-service or="))
+			Expect(data).To(ContainElement("ganization.</h2>"))
 			Expect(data).To(ContainElement("Dogs"))
 			Expect(data).To(ContainElement("this is a space test"))
-			Expect(data).To(ContainElement("You received this message because you belong to the notifications-service sp="))
-			Expect(data).To(ContainElement("ace in the notifications-service organization."))
+			Expect(data).To(ContainElement("You received this message because you belong to the notifications-service s="))
+			Expect(data).To(ContainElement("pace in the notifications-service organization."))
+		})
+
+		By("checking the progress of the campaign", func() {
+			Eventually(func() support.Campaign {
+				status, campaign, err := client.Campaigns.Get(clientToken.Access, response.CampaignID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status).To(Equal(http.StatusOK))
+
+				return campaign
+			}, 1*time.Second).Should(Equal(support.Campaign{
+				ID:        response.CampaignID,
+				Status:    "expanded",
+				Expanded:  3,
+				Queued:    3,
+				Delivered: 1,
+			}))
 		})
 	})
 })
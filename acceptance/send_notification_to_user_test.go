@@ -88,8 +88,8 @@ var _ = Describe("Send a notification to a user", func() {
 			Expect(data).To(ContainElement("X-CF-Notification-ID: " + response.NotificationID))
 			Expect(data).To(ContainElement("Reply-To: males@example.com"))
 			Expect(data).To(ContainElement("Subject: Awesomeness my-special-subject"))
-			Expect(data).To(ContainElement("\t\t<p>Millenium Falcon</p><p>this is an acceptance%40test</p><b>This message ="))
-			Expect(data).To(ContainElement("was sent directly to you.</b>"))
+			Expect(data).To(ContainElement("\t\t<p>Millenium Falcon</p><p>this is an acceptance%40test</p><b>This message="))
+			Expect(data).To(ContainElement(" was sent directly to you.</b>"))
 			Expect(data).To(ContainElement("hello from the acceptance test"))
 			Expect(data).To(ContainElement("This message was sent directly to you."))
 		})
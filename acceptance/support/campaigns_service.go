@@ -0,0 +1,19 @@
+package support
+
+import "encoding/json"
+
+type CampaignsService struct {
+	client *Client
+}
+
+func (s CampaignsService) Get(token, campaignID string) (int, Campaign, error) {
+	var campaign Campaign
+
+	status, body, err := s.client.makeRequest("GET", s.client.CampaignPath(campaignID), nil, token)
+	if err != nil {
+		return status, campaign, err
+	}
+
+	err = json.NewDecoder(body).Decode(&campaign)
+	return status, campaign, err
+}
@@ -17,6 +17,7 @@ type Client struct {
 	Notify        *NotifyService
 	Preferences   *PreferencesService
 	Messages      *MessagesService
+	Campaigns     *CampaignsService
 }
 
 func NewClient(host string) *Client {
@@ -42,6 +43,9 @@ func NewClient(host string) *Client {
 	client.Messages = &MessagesService{
 		client: client,
 	}
+	client.Campaigns = &CampaignsService{
+		client: client,
+	}
 
 	return client
 }
@@ -165,3 +169,7 @@ func (c Client) ClientsNotificationsTemplatePath(clientID, notificationID string
 func (c Client) MessagePath(messageID string) string {
 	return c.host + "/messages/" + messageID
 }
+
+func (c Client) CampaignPath(campaignID string) string {
+	return c.host + "/campaigns/" + campaignID
+}
@@ -48,12 +48,21 @@ type NotifyResponse struct {
 	Status         string `json:"status"`
 	Recipient      string `json:"recipient"`
 	NotificationID string `json:"notification_id"`
+	CampaignID     string `json:"campaign_id"`
 }
 
 type Message struct {
 	Status string `json:"status"`
 }
 
+type Campaign struct {
+	ID        string `json:"id"`
+	Status    string `json:"status"`
+	Expanded  int    `json:"expanded"`
+	Queued    int    `json:"queued"`
+	Delivered int    `json:"delivered"`
+}
+
 type RegisterClient struct {
 	SourceName    string                          `json:"source_name"`
 	Notifications map[string]RegisterNotification `json:"notifications,omitempty"`
@@ -145,8 +145,8 @@ var _ = Describe("Default Template", func() {
 			Expect(data).To(ContainElement("X-CF-Client-ID: notifications-admin"))
 			Expect(data).To(ContainElement("X-CF-Notification-ID: " + response.NotificationID))
 			Expect(data).To(ContainElement("Subject: CF Notification: my-special-subject"))
-			Expect(data).To(ContainElement("\t\t<p>This message was sent directly to your email address.</p><header>this i="))
-			Expect(data).To(ContainElement("s an acceptance test</header>"))
+			Expect(data).To(ContainElement("\t\t<p>This message was sent directly to your email address.</p><header>this ="))
+			Expect(data).To(ContainElement("is an acceptance test</header>"))
 		})
 	})
 })
@@ -1,9 +1,16 @@
 package application
 
 import (
+	"errors"
+	"io"
 	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
+	"github.com/cloudfoundry-incubator/notifications/mail"
 	"github.com/cloudfoundry-incubator/notifications/postal"
 	"github.com/cloudfoundry-incubator/notifications/web"
 	"github.com/pivotal-cf/uaa-sso-golang/uaa"
@@ -35,10 +42,11 @@ func (app Application) Boot() {
 	app.RetrieveUAAPublicKey()
 	app.migrator.Migrate()
 	app.EnableDBLogging()
-	app.UnlockJobs()
-	app.StartWorkers()
+	workers := app.StartWorkers()
 	app.StartMessageGC()
-	app.StartServer()
+	bounceListener := app.StartBounceListener()
+	server := app.StartServer()
+	app.WaitForShutdown(server, bounceListener, workers)
 }
 
 func (app Application) PrintConfiguration() {
@@ -49,33 +57,77 @@ func (app Application) PrintConfiguration() {
 }
 
 func (app Application) ConfigureSMTP() {
-	if app.env.TestMode {
+	if app.env.TestMode || app.env.MailTransport != MailTransportSMTP {
 		return
 	}
 
 	logger := app.mother.Logger()
-	mailClient := app.mother.MailClient()
+	if len(app.env.SMTPRelayConfigs) == 0 {
+		err := app.checkSMTPServer(app.mother.MailClient(), app.env.SMTPTLSMode)
+		if err != nil {
+			logger.Panicln(err)
+		}
+		return
+	}
+
+	// A relay that is down or misconfigured at boot has its circuit breaker
+	// opened, so the failover transport skips it until it has cooled down.
+	// Only fail to boot when none of the relays can be used.
+	transport, _ := app.mother.MailTransport().(*mail.FailoverTransport)
+	reachable := false
+	for i, mailClient := range app.mother.RelayMailClients() {
+		relay := app.env.SMTPRelayConfigs[i]
+		err := app.checkSMTPServer(mailClient, relay.TLSMode)
+		if err != nil {
+			logger.Printf("SMTP relay %s:%s is unhealthy: %s", relay.Host, relay.Port, err.Error())
+			if transport != nil {
+				transport.Trip(net.JoinHostPort(relay.Host, relay.Port))
+			}
+			continue
+		}
+		reachable = true
+	}
+
+	if !reachable {
+		logger.Panicln("None of the relays in SMTP_RELAYS could be reached")
+	}
+}
+
+func (app Application) checkSMTPServer(mailClient *mail.Client, tlsMode string) error {
 	err := mailClient.Connect()
 	if err != nil {
-		logger.Panicln(err)
+		return err
 	}
+	defer mailClient.Close()
 
 	err = mailClient.Hello()
 	if err != nil {
-		logger.Panicln(err)
+		return err
 	}
 
 	startTLSSupported, _ := mailClient.Extension("STARTTLS")
 
-	mailClient.Quit()
+	switch tlsMode {
+	case SMTPTLSModeStartTLS:
+		if !startTLSSupported {
+			return errors.New(`SMTP TLS configuration mismatch: Configured to use STARTTLS, but the mail server does not support the "STARTTLS" extension.`)
+		}
 
-	if !startTLSSupported && app.env.SMTPTLS {
-		logger.Panicln(`SMTP TLS configuration mismatch: Configured to use TLS over SMTP, but the mail server does not support the "STARTTLS" extension.`)
+		// Complete the handshake, so that a certificate that cannot be
+		// verified is found at boot rather than on the first delivery.
+		err = mailClient.StartTLS()
+		if err != nil {
+			return err
+		}
+	case SMTPTLSModeNone:
+		if startTLSSupported {
+			return errors.New(`SMTP TLS configuration mismatch: Not configured to use TLS over SMTP, but the mail server does support the "STARTTLS" extension.`)
+		}
+	case SMTPTLSModeImplicit:
+		// The TLS handshake has already succeeded if the server said hello.
 	}
 
-	if startTLSSupported && !app.env.SMTPTLS {
-		logger.Panicln(`SMTP TLS configuration mismatch: Not configured to use TLS over SMTP, but the mail server does support the "STARTTLS" extension.`)
-	}
+	return nil
 }
 
 func (app Application) RetrieveUAAPublicKey() {
@@ -90,23 +142,24 @@ func (app Application) RetrieveUAAPublicKey() {
 	log.Printf("UAA Public Key: %s", UAAPublicKey)
 }
 
-func (app Application) UnlockJobs() {
-	app.mother.Queue().Unlock()
-}
-
 func (app Application) EnableDBLogging() {
 	if app.env.DBLoggingEnabled {
 		app.mother.Database().TraceOn("[DB]", app.mother.Logger())
 	}
 }
 
-func (app Application) StartWorkers() {
+func (app Application) StartWorkers() []postal.DeliveryWorker {
+	workers := []postal.DeliveryWorker{}
 	for i := 0; i < WorkerCount; i++ {
-		worker := postal.NewDeliveryWorker(i+1, app.mother.Logger(), app.mother.MailClient(), app.mother.Queue(),
-			app.mother.GlobalUnsubscribesRepo(), app.mother.UnsubscribesRepo(), app.mother.KindsRepo(), app.mother.MessagesRepo(),
-			app.mother.Database(), app.env.Sender, app.env.EncryptionKey, app.mother.UserLoader(), app.mother.TemplatesLoader(), app.mother.ReceiptsRepo(), app.mother.TokenLoader())
+		worker := postal.NewDeliveryWorker(i+1, app.mother.Logger(), app.mother.MailTransport(), app.mother.Queue(),
+			app.mother.GlobalUnsubscribesRepo(), app.mother.UnsubscribesRepo(), app.mother.SuppressionsRepo(), app.mother.KindsRepo(), app.mother.MessagesRepo(),
+			app.mother.Database(), app.env.Sender, app.env.EncryptionKey, app.mother.UserLoader(), app.mother.TemplatesLoader(), app.mother.ReceiptsRepo(), app.mother.TokenLoader(),
+			app.mother.CampaignExpander(), app.mother.CampaignsRepo())
 		worker.Work()
+		workers = append(workers, worker)
 	}
+
+	return workers
 }
 
 func (app Application) StartMessageGC() {
@@ -119,8 +172,82 @@ func (app Application) StartMessageGC() {
 	messageGC.Run()
 }
 
-func (app Application) StartServer() {
-	web.NewServer().Run(app.env.Port, app.mother)
+func (app Application) StartServer() *web.Server {
+	server := web.NewServer()
+	go server.Run(app.env.Port, app.mother)
+
+	return server
+}
+
+// StartBounceListener accepts bounce messages over SMTP or LMTP when a
+// bounce listener port is configured, and returns nil otherwise. The listener
+// does not authenticate its peers, so it only binds to the loopback interface
+// unless BOUNCE_LISTENER_HOST says otherwise.
+func (app Application) StartBounceListener() *mail.Listener {
+	if app.env.BounceListenerPort == "" {
+		return nil
+	}
+
+	logger := app.mother.Logger()
+	bounceProcessor := app.mother.BounceProcessor()
+	listener := mail.NewListener(func(data io.Reader) error {
+		dsn, err := mail.ParseDSN(data)
+		if err != nil {
+			return err
+		}
+
+		return bounceProcessor.Process(dsn)
+	}, logger)
+
+	go func() {
+		err := listener.Run(net.JoinHostPort(app.env.BounceListenerHost, app.env.BounceListenerPort))
+		if err != nil {
+			logger.Panicln(err)
+		}
+	}()
+
+	return listener
+}
+
+func (app Application) WaitForShutdown(server *web.Server, bounceListener *mail.Listener, workers []postal.DeliveryWorker) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+
+	sig := <-signals
+	app.mother.Logger().Printf("Received %s, shutting down\n", sig)
+
+	app.Shutdown(server, bounceListener, workers)
+}
+
+// Shutdown stops accepting HTTP requests and bounces, closes the queue so that no more
+// jobs are reserved, and waits for in-flight deliveries to finish. Workers
+// that are still delivering once the drain timeout expires are abandoned.
+// The mail transport is closed last.
+func (app Application) Shutdown(server *web.Server, bounceListener *mail.Listener, workers []postal.DeliveryWorker) {
+	logger := app.mother.Logger()
+
+	server.Shutdown()
+	if bounceListener != nil {
+		bounceListener.Close()
+	}
+	app.mother.Queue().Close()
+
+	drained := make(chan bool)
+	go func() {
+		for _, worker := range workers {
+			worker.Halt()
+		}
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		logger.Println("All workers drained")
+	case <-time.After(time.Duration(app.env.DrainTimeout) * time.Millisecond):
+		logger.Printf("Timed out after %dms waiting for workers to drain\n", app.env.DrainTimeout)
+	}
+
+	app.mother.MailTransport().Close()
 }
 
 // This is a hack to get the logs output to the loggregator before the process exits
@@ -1,11 +1,13 @@
 package application
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"path"
 	"strings"
 
+	"github.com/cloudfoundry-incubator/notifications/mail"
 	"github.com/ryanmoran/viron"
 )
 
@@ -13,31 +15,93 @@ const (
 	SMTPAuthNone    = "none"
 	SMTPAuthPlain   = "plain"
 	SMTPAuthCRAMMD5 = "cram-md5"
+	SMTPAuthLogin   = "login"
+	SMTPAuthXOAUTH2 = "xoauth2"
 )
 
-var SMTPAuthMechanisms = []string{SMTPAuthNone, SMTPAuthPlain, SMTPAuthCRAMMD5}
+var SMTPAuthMechanisms = []string{SMTPAuthNone, SMTPAuthPlain, SMTPAuthCRAMMD5, SMTPAuthLogin, SMTPAuthXOAUTH2}
+
+const (
+	SMTPTLSModeNone     = "none"
+	SMTPTLSModeStartTLS = "starttls"
+	SMTPTLSModeImplicit = "implicit"
+)
+
+var SMTPTLSModes = []string{SMTPTLSModeNone, SMTPTLSModeStartTLS, SMTPTLSModeImplicit}
+
+const (
+	MailTransportSMTP     = "smtp"
+	MailTransportHTTP     = "http"
+	MailTransportSendmail = "sendmail"
+	MailTransportFile     = "file"
+)
 
 var UAAPublicKey string
 
+type DKIMKeyConfig struct {
+	Domain     string `json:"domain"`
+	Selector   string `json:"selector"`
+	PrivateKey string `json:"private_key"`
+}
+
+// SMTPRelayConfig describes one entry of SMTP_RELAYS. The auth_mechanism and
+// tls_mode of a relay default to SMTP_AUTH_MECHANISM and SMTP_TLS_MODE.
+type SMTPRelayConfig struct {
+	Host          string `json:"host"`
+	Port          string `json:"port"`
+	User          string `json:"user"`
+	Pass          string `json:"pass"`
+	CRAMMD5Secret string `json:"crammd5_secret"`
+	AuthMechanism string `json:"auth_mechanism"`
+	TLSMode       string `json:"tls_mode"`
+}
+
 type Environment struct {
+	BounceListenerHost    string `env:"BOUNCE_LISTENER_HOST"        env-default:"127.0.0.1"`
+	BounceListenerPort    string `env:"BOUNCE_LISTENER_PORT"`
 	CCHost                string `env:"CC_HOST"                     env-required:"true"`
 	CORSOrigin            string `env:"CORS_ORIGIN"                 env-default:"*"`
 	DBLoggingEnabled      bool   `env:"DB_LOGGING_ENABLED"`
+	DKIMKeys              string `env:"DKIM_KEYS"`
+	DKIMKeyConfigs        []DKIMKeyConfig
 	DatabaseURL           string `env:"DATABASE_URL"                env-required:"true"`
+	DrainTimeout          int    `env:"DRAIN_TIMEOUT"               env-default:"9000"`
 	EncryptionKey         []byte `env:"ENCRYPTION_KEY"              env-required:"true"`
+	GobbleLeaseDuration   int    `env:"GOBBLE_LEASE_DURATION"       env-default:"60000"`
 	GobbleWaitMaxDuration int    `env:"GOBBLE_WAIT_MAX_DURATION"    env-default:"5000"`
+	MailFileDirectory     string `env:"MAIL_FILE_DIRECTORY"`
+	MailFileFormat        string `env:"MAIL_FILE_FORMAT"            env-default:"maildir"`
+	MailHTTPToken         string `env:"MAIL_HTTP_TOKEN"`
+	MailHTTPURL           string `env:"MAIL_HTTP_URL"`
+	MailTransport         string `env:"MAIL_TRANSPORT"              env-default:"smtp"`
 	ModelMigrationsDir    string
 	Port                  string `env:"PORT"                        env-default:"3000"`
 	RootPath              string `env:"ROOT_PATH"`
-	SMTPAuthMechanism     string `env:"SMTP_AUTH_MECHANISM"         env-required:"true"`
+	SMTPAuthMechanism     string `env:"SMTP_AUTH_MECHANISM"`
 	SMTPCRAMMD5Secret     string `env:"SMTP_CRAMMD5_SECRET"`
-	SMTPHost              string `env:"SMTP_HOST"                   env-required:"true"`
+	SMTPHost              string `env:"SMTP_HOST"`
 	SMTPLoggingEnabled    bool   `env:"SMTP_LOGGING_ENABLED"        env-default:"false"`
+	SMTPOAuthClientID     string `env:"SMTP_OAUTH_CLIENT_ID"`
+	SMTPOAuthClientSecret string `env:"SMTP_OAUTH_CLIENT_SECRET"`
+	SMTPOAuthScope        string `env:"SMTP_OAUTH_SCOPE"`
+	SMTPOAuthTokenURL     string `env:"SMTP_OAUTH_TOKEN_URL"`
 	SMTPPass              string `env:"SMTP_PASS"`
-	SMTPPort              string `env:"SMTP_PORT"                   env-required:"true"`
+	SMTPPoolIdleTimeout   int    `env:"SMTP_POOL_IDLE_TIMEOUT"      env-default:"30000"`
+	SMTPPoolMaxMessages   int    `env:"SMTP_POOL_MAX_MESSAGES"      env-default:"100"`
+	SMTPPoolSize          int    `env:"SMTP_POOL_SIZE"              env-default:"10"`
+	SMTPPort              string `env:"SMTP_PORT"`
+	SMTPRelayCooldown     int    `env:"SMTP_RELAY_COOLDOWN"         env-default:"30000"`
+	SMTPRelayThreshold    int    `env:"SMTP_RELAY_FAILURE_THRESHOLD" env-default:"3"`
+	SMTPRelays            string `env:"SMTP_RELAYS"`
+	SMTPRelayConfigs      []SMTPRelayConfig
 	SMTPTLS               bool   `env:"SMTP_TLS"                    env-default:"true"`
+	SMTPTLSCACert         string `env:"SMTP_TLS_CA_CERT"`
+	SMTPTLSClientCert     string `env:"SMTP_TLS_CLIENT_CERT"`
+	SMTPTLSClientKey      string `env:"SMTP_TLS_CLIENT_KEY"`
+	SMTPTLSMode           string `env:"SMTP_TLS_MODE"`
 	SMTPUser              string `env:"SMTP_USER"`
 	Sender                string `env:"SENDER"                      env-required:"true"`
+	SendmailPath          string `env:"SENDMAIL_PATH"               env-default:"/usr/sbin/sendmail"`
 	TestMode              bool   `env:"TEST_MODE"                   env-default:"false"`
 	UAAClientID           string `env:"UAA_CLIENT_ID"               env-required:"true"`
 	UAAClientSecret       string `env:"UAA_CLIENT_SECRET"           env-required:"true"`
@@ -55,8 +119,18 @@ func NewEnvironment() Environment {
 		panic(err)
 	}
 	env.parseDatabaseURL()
-	env.validateSMTPAuthMechanism()
+	env.validateMailTransport()
+	env.inferSMTPTLSMode()
+	env.validateSMTPTLSMode()
+	if env.MailTransport == MailTransportSMTP {
+		env.parseSMTPRelays()
+		env.validateSMTPServer()
+		env.validateSMTPAuthMechanism()
+		env.validateSMTPOAuth()
+	}
+	env.validateSMTPTLSClientCertificate()
 	env.inferModelMigrationsDir()
+	env.parseDKIMKeys()
 	return env
 }
 
@@ -80,12 +154,163 @@ func (env *Environment) parseDatabaseURL() {
 	env.DatabaseURL = fmt.Sprintf("%s:%s@%s(%s)%s?parseTime=true", parsedURL.User.Username(), password, parsedURL.Scheme, parsedURL.Host, parsedURL.Path)
 }
 
+func (env *Environment) validateMailTransport() {
+	transports := mail.TransportNames()
+	known := false
+	for _, transport := range transports {
+		if transport == env.MailTransport {
+			known = true
+		}
+	}
+
+	if !known {
+		panic(fmt.Sprintf("Could not parse MAIL_TRANSPORT %q, it is not one of the allowed values: %+v", env.MailTransport, transports))
+	}
+
+	switch env.MailTransport {
+	case MailTransportHTTP:
+		if env.MailHTTPURL == "" {
+			panic("MAIL_HTTP_URL is required when MAIL_TRANSPORT is http")
+		}
+	case MailTransportFile:
+		if env.MailFileDirectory == "" {
+			panic("MAIL_FILE_DIRECTORY is required when MAIL_TRANSPORT is file")
+		}
+	}
+}
+
+func (env *Environment) validateSMTPServer() {
+	if len(env.SMTPRelayConfigs) > 0 {
+		return
+	}
+
+	required := []struct {
+		name  string
+		value string
+	}{
+		{"SMTP_HOST", env.SMTPHost},
+		{"SMTP_PORT", env.SMTPPort},
+		{"SMTP_AUTH_MECHANISM", env.SMTPAuthMechanism},
+	}
+
+	for _, variable := range required {
+		if variable.value == "" {
+			panic(viron.NewRequiredFieldError(variable.name))
+		}
+	}
+}
+
 func (env *Environment) validateSMTPAuthMechanism() {
+	if len(env.SMTPRelayConfigs) == 0 {
+		validateSMTPAuthMechanism("SMTP_AUTH_MECHANISM", env.SMTPAuthMechanism)
+		return
+	}
+
+	for _, relay := range env.SMTPRelayConfigs {
+		validateSMTPAuthMechanism(fmt.Sprintf("the auth_mechanism of SMTP relay %s", relay.Host), relay.AuthMechanism)
+	}
+}
+
+func validateSMTPAuthMechanism(name, value string) {
 	for _, mechanism := range SMTPAuthMechanisms {
-		if mechanism == env.SMTPAuthMechanism {
+		if mechanism == value {
 			return
 		}
 	}
 
-	panic(fmt.Sprintf("Could not parse SMTP_AUTH_MECHANISM %q, it is not one of the allowed values: %+v", env.SMTPAuthMechanism, SMTPAuthMechanisms))
+	panic(fmt.Sprintf("Could not parse %s %q, it is not one of the allowed values: %+v", name, value, SMTPAuthMechanisms))
+}
+
+func (env *Environment) validateSMTPOAuth() {
+	usesXOAUTH2 := env.SMTPAuthMechanism == SMTPAuthXOAUTH2
+	for _, relay := range env.SMTPRelayConfigs {
+		usesXOAUTH2 = usesXOAUTH2 || relay.AuthMechanism == SMTPAuthXOAUTH2
+	}
+
+	if !usesXOAUTH2 {
+		return
+	}
+
+	if env.SMTPOAuthTokenURL == "" || env.SMTPOAuthClientID == "" {
+		panic("SMTP_OAUTH_TOKEN_URL and SMTP_OAUTH_CLIENT_ID are required when SMTP_AUTH_MECHANISM is xoauth2")
+	}
+}
+
+// inferSMTPTLSMode falls back on the older SMTP_TLS flag when SMTP_TLS_MODE
+// is not set.
+func (env *Environment) inferSMTPTLSMode() {
+	if env.SMTPTLSMode != "" {
+		return
+	}
+
+	if env.SMTPTLS {
+		env.SMTPTLSMode = SMTPTLSModeStartTLS
+	} else {
+		env.SMTPTLSMode = SMTPTLSModeNone
+	}
+}
+
+func (env *Environment) validateSMTPTLSMode() {
+	validateSMTPTLSMode("SMTP_TLS_MODE", env.SMTPTLSMode)
+}
+
+func validateSMTPTLSMode(name, value string) {
+	for _, mode := range SMTPTLSModes {
+		if mode == value {
+			return
+		}
+	}
+
+	panic(fmt.Sprintf("Could not parse %s %q, it is not one of the allowed values: %+v", name, value, SMTPTLSModes))
+}
+
+func (env *Environment) validateSMTPTLSClientCertificate() {
+	if (env.SMTPTLSClientCert == "") != (env.SMTPTLSClientKey == "") {
+		panic("SMTP_TLS_CLIENT_CERT and SMTP_TLS_CLIENT_KEY must be set together")
+	}
+}
+
+func (env *Environment) parseSMTPRelays() {
+	if env.SMTPRelays == "" {
+		return
+	}
+
+	err := json.Unmarshal([]byte(env.SMTPRelays), &env.SMTPRelayConfigs)
+	if err != nil {
+		panic(fmt.Sprintf("Could not parse SMTP_RELAYS, it is not a JSON list of relays: %s", err.Error()))
+	}
+
+	for i, relay := range env.SMTPRelayConfigs {
+		if relay.Host == "" || relay.Port == "" {
+			panic("Every relay in SMTP_RELAYS requires a host and port")
+		}
+
+		if relay.AuthMechanism == "" {
+			relay.AuthMechanism = env.SMTPAuthMechanism
+		}
+
+		if relay.TLSMode == "" {
+			relay.TLSMode = env.SMTPTLSMode
+		}
+		validateSMTPTLSMode(fmt.Sprintf("the tls_mode of SMTP relay %s", relay.Host), relay.TLSMode)
+
+		env.SMTPRelayConfigs[i] = relay
+	}
+}
+
+func (env *Environment) parseDKIMKeys() {
+	if env.DKIMKeys == "" {
+		return
+	}
+
+	err := json.Unmarshal([]byte(env.DKIMKeys), &env.DKIMKeyConfigs)
+	if err != nil {
+		panic(fmt.Sprintf("Could not parse DKIM_KEYS, it is not a JSON list of keys: %s", err.Error()))
+	}
+
+	for _, key := range env.DKIMKeyConfigs {
+		if key.Domain == "" || key.Selector == "" || key.PrivateKey == "" {
+			panic("Every key in DKIM_KEYS requires a domain, selector and private_key")
+		}
+	}
 }
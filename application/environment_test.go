@@ -12,12 +12,19 @@ import (
 var _ = Describe("Environment", func() {
 	var variables = map[string]string{}
 	var envVars = []string{
+		"BOUNCE_LISTENER_HOST",
 		"CC_HOST",
 		"CORS_ORIGIN",
 		"DATABASE_URL",
 		"DB_LOGGING_ENABLED",
+		"DKIM_KEYS",
+		"DRAIN_TIMEOUT",
 		"ENCRYPTION_KEY",
+		"GOBBLE_LEASE_DURATION",
 		"GOBBLE_WAIT_MAX_DURATION",
+		"MAIL_FILE_DIRECTORY",
+		"MAIL_HTTP_URL",
+		"MAIL_TRANSPORT",
 		"PORT",
 		"ROOT_PATH",
 		"SENDER",
@@ -25,8 +32,20 @@ var _ = Describe("Environment", func() {
 		"SMTP_CRAMMD5_SECRET",
 		"SMTP_HOST",
 		"SMTP_LOGGING_ENABLED",
+		"SMTP_OAUTH_CLIENT_ID",
+		"SMTP_OAUTH_TOKEN_URL",
 		"SMTP_PASS",
+		"SMTP_POOL_IDLE_TIMEOUT",
+		"SMTP_POOL_MAX_MESSAGES",
+		"SMTP_POOL_SIZE",
 		"SMTP_PORT",
+		"SMTP_RELAYS",
+		"SMTP_RELAY_COOLDOWN",
+		"SMTP_RELAY_FAILURE_THRESHOLD",
+		"SMTP_TLS",
+		"SMTP_TLS_CLIENT_CERT",
+		"SMTP_TLS_CLIENT_KEY",
+		"SMTP_TLS_MODE",
 		"SMTP_USER",
 		"TEST_MODE",
 		"UAA_CLIENT_ID",
@@ -88,6 +107,90 @@ var _ = Describe("Environment", func() {
 		})
 	})
 
+	Describe("DKIM configuration", func() {
+		It("parses the keys when they are set", func() {
+			os.Setenv("DKIM_KEYS", `[{"domain":"example.com","selector":"notifications","private_key":"the-key"}]`)
+
+			env := application.NewEnvironment()
+
+			Expect(env.DKIMKeyConfigs).To(Equal([]application.DKIMKeyConfig{
+				{Domain: "example.com", Selector: "notifications", PrivateKey: "the-key"},
+			}))
+		})
+
+		It("does not sign messages when the keys are not set", func() {
+			os.Setenv("DKIM_KEYS", "")
+
+			env := application.NewEnvironment()
+
+			Expect(env.DKIMKeyConfigs).To(BeEmpty())
+		})
+
+		It("panics when the keys are not valid JSON", func() {
+			os.Setenv("DKIM_KEYS", "example.com")
+
+			Expect(func() {
+				application.NewEnvironment()
+			}).To(Panic())
+		})
+
+		It("panics when a key is missing its selector", func() {
+			os.Setenv("DKIM_KEYS", `[{"domain":"example.com","private_key":"the-key"}]`)
+
+			Expect(func() {
+				application.NewEnvironment()
+			}).To(Panic())
+		})
+	})
+
+	Describe("Mail transport configuration", func() {
+		It("defaults to smtp", func() {
+			os.Setenv("MAIL_TRANSPORT", "")
+
+			env := application.NewEnvironment()
+
+			Expect(env.MailTransport).To(Equal(application.MailTransportSMTP))
+		})
+
+		It("panics when the transport is unknown", func() {
+			os.Setenv("MAIL_TRANSPORT", "pigeon")
+
+			Expect(func() {
+				application.NewEnvironment()
+			}).To(Panic())
+		})
+
+		It("does not require the SMTP settings for other transports", func() {
+			os.Setenv("MAIL_TRANSPORT", "file")
+			os.Setenv("MAIL_FILE_DIRECTORY", "/tmp/mail")
+			os.Setenv("SMTP_HOST", "")
+			os.Setenv("SMTP_PORT", "")
+			os.Setenv("SMTP_AUTH_MECHANISM", "")
+
+			Expect(func() {
+				application.NewEnvironment()
+			}).NotTo(Panic())
+		})
+
+		It("requires a URL for the http transport", func() {
+			os.Setenv("MAIL_TRANSPORT", "http")
+			os.Setenv("MAIL_HTTP_URL", "")
+
+			Expect(func() {
+				application.NewEnvironment()
+			}).To(Panic())
+		})
+
+		It("requires a directory for the file transport", func() {
+			os.Setenv("MAIL_TRANSPORT", "file")
+			os.Setenv("MAIL_FILE_DIRECTORY", "")
+
+			Expect(func() {
+				application.NewEnvironment()
+			}).To(Panic())
+		})
+	})
+
 	Describe("Port configuration", func() {
 		It("loads the value when it is set", func() {
 			os.Setenv("PORT", "5001")
@@ -163,6 +266,69 @@ var _ = Describe("Environment", func() {
 			Expect(env.SMTPTLS).To(BeTrue())
 		})
 
+		It("loads the connection pool settings, falling back to defaults", func() {
+			os.Setenv("SMTP_POOL_SIZE", "4")
+			os.Setenv("SMTP_POOL_IDLE_TIMEOUT", "")
+			os.Setenv("SMTP_POOL_MAX_MESSAGES", "")
+
+			env := application.NewEnvironment()
+
+			Expect(env.SMTPPoolSize).To(Equal(4))
+			Expect(env.SMTPPoolIdleTimeout).To(Equal(30000))
+			Expect(env.SMTPPoolMaxMessages).To(Equal(100))
+		})
+
+		Describe("SMTP_TLS_MODE", func() {
+			It("loads the value when it is set", func() {
+				os.Setenv("SMTP_TLS_MODE", "implicit")
+
+				env := application.NewEnvironment()
+
+				Expect(env.SMTPTLSMode).To(Equal(application.SMTPTLSModeImplicit))
+			})
+
+			It("falls back on SMTP_TLS when it is not set", func() {
+				os.Setenv("SMTP_TLS_MODE", "")
+
+				os.Setenv("SMTP_TLS", "true")
+				Expect(application.NewEnvironment().SMTPTLSMode).To(Equal(application.SMTPTLSModeStartTLS))
+
+				os.Setenv("SMTP_TLS", "false")
+				Expect(application.NewEnvironment().SMTPTLSMode).To(Equal(application.SMTPTLSModeNone))
+			})
+
+			It("panics if it is not one of the supported modes", func() {
+				os.Setenv("SMTP_TLS_MODE", "ssl")
+
+				Expect(func() {
+					application.NewEnvironment()
+				}).To(Panic())
+			})
+
+			It("panics if only one of SMTP_TLS_CLIENT_CERT and SMTP_TLS_CLIENT_KEY is set", func() {
+				os.Setenv("SMTP_TLS_CLIENT_CERT", "certificate")
+				os.Setenv("SMTP_TLS_CLIENT_KEY", "")
+
+				Expect(func() {
+					application.NewEnvironment()
+				}).To(Panic())
+			})
+		})
+
+		It("requires a token endpoint when SMTP_AUTH_MECHANISM is xoauth2", func() {
+			os.Setenv("SMTP_AUTH_MECHANISM", "xoauth2")
+			os.Setenv("SMTP_OAUTH_TOKEN_URL", "")
+			os.Setenv("SMTP_OAUTH_CLIENT_ID", "smtp-client")
+			Expect(func() {
+				application.NewEnvironment()
+			}).To(Panic())
+
+			os.Setenv("SMTP_OAUTH_TOKEN_URL", "https://login.example.com/oauth/token")
+			Expect(func() {
+				application.NewEnvironment()
+			}).NotTo(Panic())
+		})
+
 		It("defaults to true when SMTP_TLS is not a boolean", func() {
 			os.Setenv("SMTP_TLS", "")
 
@@ -180,7 +346,7 @@ var _ = Describe("Environment", func() {
 			}).NotTo(Panic())
 		})
 
-		It("it panics if SMTP_AUTH_MECHANISM is not one of the three supported types", func() {
+		It("it panics if SMTP_AUTH_MECHANISM is not one of the supported types", func() {
 			os.Setenv("SMTP_AUTH_MECHANISM", "cram-md5")
 			Expect(func() {
 				application.NewEnvironment()
@@ -196,6 +362,11 @@ var _ = Describe("Environment", func() {
 				application.NewEnvironment()
 			}).NotTo(Panic())
 
+			os.Setenv("SMTP_AUTH_MECHANISM", "login")
+			Expect(func() {
+				application.NewEnvironment()
+			}).NotTo(Panic())
+
 			os.Setenv("SMTP_AUTH_MECHANISM", "banana")
 			Expect(func() {
 				application.NewEnvironment()
@@ -231,6 +402,74 @@ var _ = Describe("Environment", func() {
 				application.NewEnvironment()
 			}).To(Panic())
 		})
+
+		Describe("SMTP_RELAYS", func() {
+			It("parses the relays, defaulting their auth mechanism and TLS mode", func() {
+				os.Setenv("SMTP_AUTH_MECHANISM", "plain")
+				os.Setenv("SMTP_TLS_MODE", "starttls")
+				os.Setenv("SMTP_RELAYS", `[
+					{"host":"primary.example.com","port":"587","user":"me","pass":"secret"},
+					{"host":"backup.example.com","port":"465","auth_mechanism":"none","tls_mode":"implicit"}
+				]`)
+
+				env := application.NewEnvironment()
+
+				Expect(env.SMTPRelayConfigs).To(Equal([]application.SMTPRelayConfig{
+					{Host: "primary.example.com", Port: "587", User: "me", Pass: "secret", AuthMechanism: "plain", TLSMode: "starttls"},
+					{Host: "backup.example.com", Port: "465", AuthMechanism: "none", TLSMode: "implicit"},
+				}))
+			})
+
+			It("does not require SMTP_HOST and SMTP_PORT", func() {
+				os.Setenv("SMTP_HOST", "")
+				os.Setenv("SMTP_PORT", "")
+				os.Setenv("SMTP_RELAYS", `[{"host":"primary.example.com","port":"587","auth_mechanism":"none"}]`)
+
+				Expect(func() {
+					application.NewEnvironment()
+				}).NotTo(Panic())
+			})
+
+			It("panics when the relays are not valid JSON", func() {
+				os.Setenv("SMTP_RELAYS", "primary.example.com")
+
+				Expect(func() {
+					application.NewEnvironment()
+				}).To(Panic())
+			})
+
+			It("panics when a relay is missing its port", func() {
+				os.Setenv("SMTP_RELAYS", `[{"host":"primary.example.com"}]`)
+
+				Expect(func() {
+					application.NewEnvironment()
+				}).To(Panic())
+			})
+
+			It("panics when a relay has an unknown auth mechanism or TLS mode", func() {
+				os.Setenv("SMTP_RELAYS", `[{"host":"primary.example.com","port":"587","auth_mechanism":"banana"}]`)
+				Expect(func() {
+					application.NewEnvironment()
+				}).To(Panic())
+
+				os.Setenv("SMTP_RELAYS", `[{"host":"primary.example.com","port":"587","tls_mode":"banana"}]`)
+				Expect(func() {
+					application.NewEnvironment()
+				}).To(Panic())
+			})
+
+			It("loads the circuit breaker settings, defaulting to 3 failures and 30000ms", func() {
+				env := application.NewEnvironment()
+				Expect(env.SMTPRelayThreshold).To(Equal(3))
+				Expect(env.SMTPRelayCooldown).To(Equal(30000))
+
+				os.Setenv("SMTP_RELAY_FAILURE_THRESHOLD", "5")
+				os.Setenv("SMTP_RELAY_COOLDOWN", "1000")
+				env = application.NewEnvironment()
+				Expect(env.SMTPRelayThreshold).To(Equal(5))
+				Expect(env.SMTPRelayCooldown).To(Equal(1000))
+			})
+		})
 	})
 
 	Describe("SMTP logging", func() {
@@ -424,4 +663,52 @@ var _ = Describe("Environment", func() {
 			Expect(env.GobbleWaitMaxDuration).To(Equal(5000))
 		})
 	})
+
+	Describe("Gobble LeaseDuration", func() {
+		It("sets the value if present", func() {
+			os.Setenv("GOBBLE_LEASE_DURATION", "30000")
+			env := application.NewEnvironment()
+
+			Expect(env.GobbleLeaseDuration).To(Equal(30000))
+		})
+
+		It("defaults to 60000", func() {
+			os.Setenv("GOBBLE_LEASE_DURATION", "")
+			env := application.NewEnvironment()
+
+			Expect(env.GobbleLeaseDuration).To(Equal(60000))
+		})
+	})
+
+	Describe("DrainTimeout", func() {
+		It("sets the value if present", func() {
+			os.Setenv("DRAIN_TIMEOUT", "2500")
+			env := application.NewEnvironment()
+
+			Expect(env.DrainTimeout).To(Equal(2500))
+		})
+
+		It("defaults to 9000", func() {
+			os.Setenv("DRAIN_TIMEOUT", "")
+			env := application.NewEnvironment()
+
+			Expect(env.DrainTimeout).To(Equal(9000))
+		})
+	})
+
+	Describe("BounceListenerHost", func() {
+		It("sets the value if present", func() {
+			os.Setenv("BOUNCE_LISTENER_HOST", "10.0.0.5")
+			env := application.NewEnvironment()
+
+			Expect(env.BounceListenerHost).To(Equal("10.0.0.5"))
+		})
+
+		It("defaults to the loopback interface", func() {
+			os.Setenv("BOUNCE_LISTENER_HOST", "")
+			env := application.NewEnvironment()
+
+			Expect(env.BounceListenerHost).To(Equal("127.0.0.1"))
+		})
+	})
 })
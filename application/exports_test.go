@@ -0,0 +1,7 @@
+package application
+
+import "github.com/cloudfoundry-incubator/notifications/mail"
+
+func (m *Mother) MailConfig() mail.Config {
+	return m.mailConfig()
+}
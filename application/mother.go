@@ -1,6 +1,8 @@
 package application
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"log"
 	"os"
 	"path"
@@ -26,6 +28,8 @@ type Mother struct {
 	logger    *log.Logger
 	queue     *gobble.Queue
 	uaaClient *uaa.UAA
+	transport mail.ClientInterface
+	smtpToken *mail.OAuthTokenLoader
 	mutex     sync.Mutex
 }
 
@@ -44,6 +48,8 @@ func (m *Mother) Logger() *log.Logger {
 }
 
 func (m *Mother) Queue() gobble.QueueInterface {
+	logger := m.Logger()
+
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
@@ -51,6 +57,9 @@ func (m *Mother) Queue() gobble.QueueInterface {
 		env := NewEnvironment()
 		m.queue = gobble.NewQueue(gobble.Config{
 			WaitMaxDuration: time.Duration(env.GobbleWaitMaxDuration) * time.Millisecond,
+			LeaseDuration:   time.Duration(env.GobbleLeaseDuration) * time.Millisecond,
+			BatchSize:       WorkerCount,
+			Logger:          logger,
 		})
 	}
 
@@ -83,10 +92,9 @@ func (m Mother) SpaceStrategy() strategies.SpaceStrategy {
 	tokenLoader := postal.NewTokenLoader(uaaClient)
 	spaceLoader := utilities.NewSpaceLoader(cloudController)
 	organizationLoader := utilities.NewOrganizationLoader(cloudController)
-	mailer := m.Mailer()
 	findsUserGUIDs := utilities.NewFindsUserGUIDs(cloudController, uaaClient)
 
-	return strategies.NewSpaceStrategy(tokenLoader, spaceLoader, organizationLoader, findsUserGUIDs, mailer)
+	return strategies.NewSpaceStrategy(tokenLoader, spaceLoader, organizationLoader, findsUserGUIDs, m.CampaignStarter())
 }
 
 func (m Mother) OrganizationStrategy() strategies.OrganizationStrategy {
@@ -97,18 +105,17 @@ func (m Mother) OrganizationStrategy() strategies.OrganizationStrategy {
 	tokenLoader := postal.NewTokenLoader(uaaClient)
 	organizationLoader := utilities.NewOrganizationLoader(cloudController)
 	findsUserGUIDs := utilities.NewFindsUserGUIDs(cloudController, uaaClient)
-	mailer := m.Mailer()
 
-	return strategies.NewOrganizationStrategy(tokenLoader, organizationLoader, findsUserGUIDs, mailer)
+	return strategies.NewOrganizationStrategy(tokenLoader, organizationLoader, findsUserGUIDs, m.CampaignStarter())
 }
 
 func (m Mother) EveryoneStrategy() strategies.EveryoneStrategy {
+	env := NewEnvironment()
 	uaaClient := m.UAAClient()
 	tokenLoader := postal.NewTokenLoader(uaaClient)
-	allUsers := utilities.NewAllUsers(uaaClient)
-	mailer := m.Mailer()
+	allUsers := utilities.NewAllUsers(utilities.NewUAAUsersPager(uaaClient, env.UAAHost))
 
-	return strategies.NewEveryoneStrategy(tokenLoader, allUsers, mailer)
+	return strategies.NewEveryoneStrategy(tokenLoader, allUsers, m.CampaignStarter())
 }
 
 func (m Mother) UAAScopeStrategy() strategies.UAAScopeStrategy {
@@ -118,9 +125,8 @@ func (m Mother) UAAScopeStrategy() strategies.UAAScopeStrategy {
 
 	tokenLoader := postal.NewTokenLoader(uaaClient)
 	findsUserGUIDs := utilities.NewFindsUserGUIDs(cloudController, uaaClient)
-	mailer := m.Mailer()
 
-	return strategies.NewUAAScopeStrategy(tokenLoader, findsUserGUIDs, mailer)
+	return strategies.NewUAAScopeStrategy(tokenLoader, findsUserGUIDs, m.CampaignStarter())
 }
 
 func (m Mother) EmailStrategy() strategies.EmailStrategy {
@@ -140,6 +146,21 @@ func (m Mother) Mailer() strategies.Mailer {
 	return strategies.NewMailer(m.Queue(), uuid.NewV4, m.MessagesRepo())
 }
 
+func (m Mother) CampaignStarter() strategies.CampaignStarter {
+	return strategies.NewCampaignStarter(m.Queue(), uuid.NewV4, m.CampaignsRepo())
+}
+
+func (m Mother) CampaignExpander() strategies.CampaignExpander {
+	audiences := map[string]strategies.AudienceLoaderInterface{
+		strategies.SpaceAudience:        m.SpaceStrategy(),
+		strategies.OrganizationAudience: m.OrganizationStrategy(),
+		strategies.ScopeAudience:        m.UAAScopeStrategy(),
+		strategies.EveryoneAudience:     m.EveryoneStrategy(),
+	}
+
+	return strategies.NewCampaignExpander(audiences, m.Mailer(), m.CampaignsRepo(), m.Database())
+}
+
 func (m Mother) TemplatesLoader() postal.TemplatesLoader {
 	finder := m.TemplateFinder()
 	database := m.Database()
@@ -161,7 +182,73 @@ func (m Mother) TokenLoader() postal.TokenLoader {
 	return postal.NewTokenLoader(uaaClient)
 }
 
-func (m Mother) MailClient() *mail.Client {
+func (m *Mother) MailClient() *mail.Client {
+	client, err := mail.NewClient(m.mailConfig(), m.Logger())
+	if err != nil {
+		m.Logger().Panicln(err)
+	}
+
+	return client
+}
+
+// RelayMailClients returns a client for every relay in SMTP_RELAYS, in the
+// order they are configured.
+func (m *Mother) RelayMailClients() []*mail.Client {
+	config := m.mailConfig()
+	clients := []*mail.Client{}
+	for _, relay := range config.Relays {
+		client, err := mail.NewClient(config.ForRelay(relay), m.Logger())
+		if err != nil {
+			m.Logger().Panicln(err)
+		}
+		clients = append(clients, client)
+	}
+
+	return clients
+}
+
+// SMTPTokenLoader is shared by every SMTP session so that the OAuth token is
+// only fetched again once it expires.
+func (m *Mother) SMTPTokenLoader() *mail.OAuthTokenLoader {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.smtpToken == nil {
+		env := NewEnvironment()
+		m.smtpToken = mail.NewOAuthTokenLoader(mail.OAuthConfig{
+			TokenURL:      env.SMTPOAuthTokenURL,
+			ClientID:      env.SMTPOAuthClientID,
+			ClientSecret:  env.SMTPOAuthClientSecret,
+			Scope:         env.SMTPOAuthScope,
+			SkipVerifySSL: !env.VerifySSL,
+		})
+	}
+
+	return m.smtpToken
+}
+
+// MailTransport is shared by every delivery worker, and sends mail with the
+// transport named by MAIL_TRANSPORT.
+func (m *Mother) MailTransport() mail.ClientInterface {
+	config := m.mailConfig()
+	logger := m.Logger()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.transport == nil {
+		env := NewEnvironment()
+		transport, err := mail.NewTransport(env.MailTransport, config, logger)
+		if err != nil {
+			logger.Panicln(err)
+		}
+		m.transport = transport
+	}
+
+	return m.transport
+}
+
+func (m *Mother) mailConfig() mail.Config {
 	env := NewEnvironment()
 	mailConfig := mail.Config{
 		User:           env.SMTPUser,
@@ -170,26 +257,107 @@ func (m Mother) MailClient() *mail.Client {
 		Port:           env.SMTPPort,
 		Secret:         env.SMTPCRAMMD5Secret,
 		TestMode:       env.TestMode,
-		SkipVerifySSL:  env.VerifySSL,
-		DisableTLS:     !env.SMTPTLS,
+		SkipVerifySSL:  !env.VerifySSL,
 		LoggingEnabled: env.SMTPLoggingEnabled,
+		Pool: mail.PoolConfig{
+			MaxConnections:           env.SMTPPoolSize,
+			MaxIdleTime:              time.Duration(env.SMTPPoolIdleTimeout) * time.Millisecond,
+			MaxMessagesPerConnection: env.SMTPPoolMaxMessages,
+		},
+		HTTP: mail.HTTPTransportConfig{
+			URL:   env.MailHTTPURL,
+			Token: env.MailHTTPToken,
+		},
+		SendmailPath: env.SendmailPath,
+		File: mail.FileTransportConfig{
+			Directory: env.MailFileDirectory,
+			Format:    env.MailFileFormat,
+		},
+		CircuitBreaker: mail.CircuitBreakerConfig{
+			Threshold: env.SMTPRelayThreshold,
+			Cooldown:  time.Duration(env.SMTPRelayCooldown) * time.Millisecond,
+		},
+		AuthMechanism: mailAuthMechanism(env.SMTPAuthMechanism),
+		TLSMode:       mailTLSMode(env.SMTPTLSMode),
+	}
+
+	usesXOAUTH2 := mailConfig.AuthMechanism == mail.AuthXOAUTH2
+	for _, relay := range env.SMTPRelayConfigs {
+		mailConfig.Relays = append(mailConfig.Relays, mail.Relay{
+			Host:          relay.Host,
+			Port:          relay.Port,
+			User:          relay.User,
+			Pass:          relay.Pass,
+			Secret:        relay.CRAMMD5Secret,
+			AuthMechanism: mailAuthMechanism(relay.AuthMechanism),
+			TLSMode:       mailTLSMode(relay.TLSMode),
+		})
+		usesXOAUTH2 = usesXOAUTH2 || relay.AuthMechanism == SMTPAuthXOAUTH2
+	}
+
+	if usesXOAUTH2 {
+		mailConfig.TokenSource = m.SMTPTokenLoader()
+	}
+
+	if env.SMTPTLSClientCert != "" {
+		certificate, err := tls.X509KeyPair([]byte(env.SMTPTLSClientCert), []byte(env.SMTPTLSClientKey))
+		if err != nil {
+			m.Logger().Panicln(err)
+		}
+		mailConfig.Certificates = []tls.Certificate{certificate}
 	}
 
-	switch env.SMTPAuthMechanism {
-	case SMTPAuthNone:
-		mailConfig.AuthMechanism = mail.AuthNone
+	if len(env.DKIMKeyConfigs) > 0 {
+		keys := []mail.DKIMKey{}
+		for _, config := range env.DKIMKeyConfigs {
+			signer, err := mail.ParseDKIMPrivateKey(config.PrivateKey)
+			if err != nil {
+				m.Logger().Panicln(err)
+			}
+
+			keys = append(keys, mail.DKIMKey{
+				Domain:   config.Domain,
+				Selector: config.Selector,
+				Signer:   signer,
+			})
+		}
+		mailConfig.DKIM = mail.NewDKIMSigner(keys)
+	}
+
+	if env.SMTPTLSCACert != "" {
+		mailConfig.RootCAs = x509.NewCertPool()
+		if !mailConfig.RootCAs.AppendCertsFromPEM([]byte(env.SMTPTLSCACert)) {
+			m.Logger().Panicln("SMTP_TLS_CA_CERT does not contain any PEM encoded certificates")
+		}
+	}
+
+	return mailConfig
+}
+
+func mailAuthMechanism(mechanism string) mail.AuthMechanism {
+	switch mechanism {
 	case SMTPAuthPlain:
-		mailConfig.AuthMechanism = mail.AuthPlain
+		return mail.AuthPlain
 	case SMTPAuthCRAMMD5:
-		mailConfig.AuthMechanism = mail.AuthCRAMMD5
+		return mail.AuthCRAMMD5
+	case SMTPAuthLogin:
+		return mail.AuthLogin
+	case SMTPAuthXOAUTH2:
+		return mail.AuthXOAUTH2
+	default:
+		return mail.AuthNone
 	}
+}
 
-	client, err := mail.NewClient(mailConfig, m.Logger())
-	if err != nil {
-		m.Logger().Panicln(err)
+func mailTLSMode(mode string) mail.TLSMode {
+	switch mode {
+	case SMTPTLSModeNone:
+		return mail.TLSModeNone
+	case SMTPTLSModeImplicit:
+		return mail.TLSModeImplicit
+	default:
+		return mail.TLSModeStartTLS
 	}
-
-	return client
 }
 
 func (m Mother) Repos() (models.ClientsRepo, models.KindsRepo) {
@@ -244,6 +412,43 @@ func (m Mother) MessageFinder() services.MessageFinder {
 	return services.NewMessageFinder(messagesRepo, database)
 }
 
+func (m Mother) MessageCanceler() services.MessageCanceler {
+	database := m.Database()
+	messagesRepo := m.MessagesRepo()
+
+	return services.NewMessageCanceler(messagesRepo, m.Queue(), database)
+}
+
+func (m Mother) CampaignFinder() services.CampaignFinder {
+	return services.NewCampaignFinder(m.CampaignsRepo(), m.Database())
+}
+
+func (m Mother) DeadJobServiceObjects() (services.DeadJobsFinder, services.DeadJobReplayer, services.DeadJobPurger) {
+	queue := m.Queue()
+
+	return services.NewDeadJobsFinder(queue),
+		services.NewDeadJobReplayer(queue),
+		services.NewDeadJobPurger(queue)
+}
+
+func (m Mother) SuppressionServiceObjects() (services.SuppressionsFinder, services.SuppressionsUpdater) {
+	suppressionsRepo := m.SuppressionsRepo()
+	database := m.Database()
+
+	return services.NewSuppressionsFinder(suppressionsRepo, database),
+		services.NewSuppressionsUpdater(suppressionsRepo, database)
+}
+
+func (m Mother) BounceProcessor() services.BounceProcessor {
+	return services.NewBounceProcessor(m.MessagesRepo(), m.SuppressionsRepo(), m.Database())
+}
+
+func (m Mother) TemplatePreviewer() postal.TemplatePreviewer {
+	env := NewEnvironment()
+
+	return postal.NewTemplatePreviewer(env.Sender, env.EncryptionKey)
+}
+
 func (m Mother) TemplateServiceObjects() (services.TemplateCreator, services.TemplateFinder, services.TemplateUpdater,
 	services.TemplateDeleter, services.TemplateLister, services.TemplateAssigner, services.TemplateAssociationLister) {
 
@@ -251,15 +456,28 @@ func (m Mother) TemplateServiceObjects() (services.TemplateCreator, services.Tem
 	clientsRepo, kindsRepo := m.Repos()
 	templatesRepo := m.TemplatesRepo()
 
-	return services.NewTemplateCreator(templatesRepo, database),
+	templateVersionsRepo := m.TemplateVersionsRepo()
+
+	return services.NewTemplateCreator(templatesRepo, templateVersionsRepo, database),
 		services.NewTemplateFinder(templatesRepo, database),
-		services.NewTemplateUpdater(templatesRepo, database),
+		services.NewTemplateUpdater(templatesRepo, templateVersionsRepo, database),
 		services.NewTemplateDeleter(templatesRepo, database),
 		services.NewTemplateLister(templatesRepo, database),
 		services.NewTemplateAssigner(clientsRepo, kindsRepo, templatesRepo, database),
 		services.NewTemplateAssociationLister(clientsRepo, kindsRepo, templatesRepo, database)
 }
 
+func (m Mother) TemplateVersionServiceObjects() (services.TemplateVersionLister, services.TemplateVersionDiffer, services.TemplateRollbacker) {
+	database := m.Database()
+	templatesRepo := m.TemplatesRepo()
+	templateVersionsRepo := m.TemplateVersionsRepo()
+	updater := services.NewTemplateUpdater(templatesRepo, templateVersionsRepo, database)
+
+	return services.NewTemplateVersionLister(templatesRepo, templateVersionsRepo, database),
+		services.NewTemplateVersionDiffer(templateVersionsRepo, database),
+		services.NewTemplateRollbacker(templateVersionsRepo, updater, database)
+}
+
 func (m Mother) KindsRepo() models.KindsRepo {
 	return models.NewKindsRepo()
 }
@@ -272,14 +490,26 @@ func (m Mother) GlobalUnsubscribesRepo() models.GlobalUnsubscribesRepo {
 	return models.NewGlobalUnsubscribesRepo()
 }
 
+func (m Mother) SuppressionsRepo() models.SuppressionsRepo {
+	return models.NewSuppressionsRepo()
+}
+
 func (m Mother) TemplatesRepo() models.TemplatesRepo {
 	return models.NewTemplatesRepo()
 }
 
+func (m Mother) TemplateVersionsRepo() models.TemplateVersionsRepo {
+	return models.NewTemplateVersionsRepo()
+}
+
 func (m Mother) MessagesRepo() models.MessagesRepo {
 	return models.NewMessagesRepo()
 }
 
+func (m Mother) CampaignsRepo() models.CampaignsRepo {
+	return models.NewCampaignsRepo()
+}
+
 func (m Mother) ReceiptsRepo() models.ReceiptsRepo {
 	return models.NewReceiptsRepo()
 }
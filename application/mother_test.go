@@ -0,0 +1,92 @@
+package application_test
+
+import (
+	"os"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/application"
+	"github.com/cloudfoundry-incubator/notifications/mail"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Mother", func() {
+	var mother *application.Mother
+	var variables = map[string]string{}
+	var envVars = []string{
+		"MAIL_TRANSPORT",
+		"SMTP_AUTH_MECHANISM",
+		"SMTP_OAUTH_CLIENT_ID",
+		"SMTP_OAUTH_TOKEN_URL",
+		"SMTP_RELAYS",
+		"VERIFY_SSL",
+	}
+
+	// build fails the test, instead of hanging the suite, when building takes
+	// a lock that is already held.
+	build := func(builder func() interface{}) interface{} {
+		built := make(chan interface{}, 1)
+		go func() {
+			built <- builder()
+		}()
+
+		var result interface{}
+		Eventually(built, 2*time.Second).Should(Receive(&result))
+		return result
+	}
+
+	BeforeEach(func() {
+		for _, envVar := range envVars {
+			variables[envVar] = os.Getenv(envVar)
+		}
+
+		mother = application.NewMother()
+	})
+
+	AfterEach(func() {
+		for key, value := range variables {
+			os.Setenv(key, value)
+		}
+	})
+
+	Describe("mail configuration", func() {
+		It("verifies the certificate of the mail server unless VERIFY_SSL is false", func() {
+			os.Setenv("VERIFY_SSL", "true")
+			Expect(mother.MailConfig().SkipVerifySSL).To(BeFalse())
+
+			os.Setenv("VERIFY_SSL", "false")
+			Expect(mother.MailConfig().SkipVerifySSL).To(BeTrue())
+		})
+	})
+
+	Describe("MailTransport", func() {
+		It("builds a shared SMTP connection pool", func() {
+			os.Setenv("MAIL_TRANSPORT", "smtp")
+			os.Setenv("SMTP_RELAYS", "")
+
+			transport := build(func() interface{} {
+				return mother.MailTransport()
+			})
+			Expect(transport).To(BeAssignableToTypeOf(&mail.Pool{}))
+
+			again := build(func() interface{} {
+				return mother.MailTransport()
+			})
+			Expect(again == transport).To(BeTrue())
+		})
+
+		It("builds the relay failover transport, along with its OAuth token loader", func() {
+			os.Setenv("MAIL_TRANSPORT", "smtp")
+			os.Setenv("SMTP_AUTH_MECHANISM", "xoauth2")
+			os.Setenv("SMTP_OAUTH_TOKEN_URL", "https://uaa.example.com/oauth/token")
+			os.Setenv("SMTP_OAUTH_CLIENT_ID", "notifications")
+			os.Setenv("SMTP_RELAYS", `[{"host":"primary.example.com","port":"587"}]`)
+
+			transport := build(func() interface{} {
+				return mother.MailTransport()
+			})
+			Expect(transport).To(BeAssignableToTypeOf(&mail.FailoverTransport{}))
+		})
+	})
+})
@@ -12,6 +12,8 @@ type CloudControllerInterface interface {
 	GetManagersByOrgGuid(string, string) ([]CloudControllerUser, error)
 	GetAuditorsByOrgGuid(string, string) ([]CloudControllerUser, error)
 	GetBillingManagersByOrgGuid(string, string) ([]CloudControllerUser, error)
+	GetUsersPageBySpaceGuid(string, string, string) (CloudControllerUsersPage, error)
+	GetUsersPageByOrgGuid(string, string, string, string) (CloudControllerUsersPage, error)
 	LoadSpace(string, string) (CloudControllerSpace, error)
 	LoadOrganization(string, string) (CloudControllerOrganization, error)
 }
@@ -0,0 +1,98 @@
+package cf
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/metrics"
+	"github.com/pivotal-cf-experimental/rainmaker"
+)
+
+// CloudControllerUsersPage is a single page of a Cloud Controller user
+// listing. Next is the cursor for the following page, and is empty on the
+// last page.
+type CloudControllerUsersPage struct {
+	Users []CloudControllerUser
+	Next  string
+}
+
+func (cc CloudController) GetUsersPageBySpaceGuid(guid, token, cursor string) (CloudControllerUsersPage, error) {
+	then := time.Now()
+
+	var list rainmaker.UsersList
+	var err error
+	if cursor != "" {
+		list, err = cc.followCursor(cursor, token)
+	} else {
+		list, err = cc.client.Spaces.ListUsers(guid, token)
+	}
+
+	if err != nil {
+		return CloudControllerUsersPage{}, NewFailure(0, err.Error())
+	}
+
+	duration := time.Now().Sub(then)
+
+	metrics.NewMetric("histogram", map[string]interface{}{
+		"name":  "notifications.external-requests.cc.users-page-by-space-guid",
+		"value": duration.Seconds(),
+	}).Log()
+
+	return usersPage(list), nil
+}
+
+func (cc CloudController) GetUsersPageByOrgGuid(guid, role, token, cursor string) (CloudControllerUsersPage, error) {
+	var list rainmaker.UsersList
+	var err error
+	then := time.Now()
+
+	switch {
+	case cursor != "":
+		list, err = cc.followCursor(cursor, token)
+	case role == "OrgManager":
+		list, err = cc.client.Organizations.ListManagers(guid, token)
+	case role == "OrgAuditor":
+		list, err = cc.client.Organizations.ListAuditors(guid, token)
+	case role == "BillingManager":
+		list, err = cc.client.Organizations.ListBillingManagers(guid, token)
+	default:
+		list, err = cc.client.Organizations.ListUsers(guid, token)
+	}
+
+	if err != nil {
+		return CloudControllerUsersPage{}, NewFailure(0, err.Error())
+	}
+
+	duration := time.Now().Sub(then)
+
+	metrics.NewMetric("histogram", map[string]interface{}{
+		"name":  "notifications.external-requests.cc.users-page-by-org-guid",
+		"value": duration.Seconds(),
+	}).Log()
+
+	return usersPage(list), nil
+}
+
+// followCursor fetches the page a cursor points at. A cursor is the next_url
+// of an earlier page, which is followed from an unfetched list so that the
+// pages before it are not requested again.
+func (cc CloudController) followCursor(cursor, token string) (rainmaker.UsersList, error) {
+	list := rainmaker.NewOrganization(cc.client.Config, "").Users
+	list.NextURL = cursor
+
+	return list.Next(token)
+}
+
+func usersPage(list rainmaker.UsersList) CloudControllerUsersPage {
+	page := CloudControllerUsersPage{
+		Users: []CloudControllerUser{},
+		Next:  list.NextURL,
+	}
+
+	for _, user := range list.Users {
+		page.Users = append(page.Users, CloudControllerUser{
+			GUID: user.GUID,
+		})
+	}
+
+	return page
+}
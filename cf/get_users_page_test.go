@@ -0,0 +1,134 @@
+package cf_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/cf"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Paging through users", func() {
+	var CCServer *httptest.Server
+	var cloudController cf.CloudController
+	var requests []string
+
+	userResource := func(guid string) string {
+		return fmt.Sprintf(`{
+          "metadata": {
+            "guid": %q,
+            "url": "/v2/users/%s",
+            "created_at": "2013-04-30T21:00:49+00:00",
+            "updated_at": null
+          },
+          "entity": {
+            "admin": false,
+            "active": true,
+            "default_space_guid": null
+          }
+        }`, guid, guid)
+	}
+
+	BeforeEach(func() {
+		requests = []string{}
+
+		CCServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+			if token != testUAAToken {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"code":10002,"description":"Authentication error","error_code":"CF-NotAuthenticated"}`))
+				return
+			}
+
+			requests = append(requests, req.URL.Path+"?page="+req.URL.Query().Get("page"))
+
+			w.WriteHeader(http.StatusOK)
+			if req.URL.Query().Get("page") == "2" {
+				w.Write([]byte(fmt.Sprintf(`{
+                  "total_results": 2,
+                  "total_pages": 2,
+                  "prev_url": "%s?page=1",
+                  "next_url": null,
+                  "resources": [%s]
+                }`, req.URL.Path, userResource("user-456"))))
+				return
+			}
+
+			w.Write([]byte(fmt.Sprintf(`{
+              "total_results": 2,
+              "total_pages": 2,
+              "prev_url": null,
+              "next_url": "%s?page=2",
+              "resources": [%s]
+            }`, req.URL.Path, userResource("user-123"))))
+		}))
+
+		cloudController = cf.NewCloudController(CCServer.URL, false)
+	})
+
+	AfterEach(func() {
+		CCServer.Close()
+	})
+
+	Describe("GetUsersPageBySpaceGuid", func() {
+		It("returns the first page along with a cursor for the next one", func() {
+			page, err := cloudController.GetUsersPageBySpaceGuid(testSpaceGuid, testUAAToken, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(page.Users).To(Equal([]cf.CloudControllerUser{{GUID: "user-123"}}))
+			Expect(page.Next).To(Equal("/v2/users?page=2"))
+		})
+
+		It("returns the page the cursor points at", func() {
+			page, err := cloudController.GetUsersPageBySpaceGuid(testSpaceGuid, testUAAToken, "/v2/users?page=2")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(page.Users).To(Equal([]cf.CloudControllerUser{{GUID: "user-456"}}))
+			Expect(page.Next).To(BeEmpty())
+			Expect(requests).To(Equal([]string{"/v2/users?page=2"}))
+		})
+
+		It("returns an error when the Cloud Controller returns a 400, or 500 status code", func() {
+			_, err := cloudController.GetUsersPageBySpaceGuid(testSpaceGuid, "bad-token", "")
+
+			Expect(err).To(BeAssignableToTypeOf(cf.Failure{}))
+		})
+	})
+
+	Describe("GetUsersPageByOrgGuid", func() {
+		It("lists the users holding the given role", func() {
+			page, err := cloudController.GetUsersPageByOrgGuid("org-001", "OrgManager", testUAAToken, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(page.Users).To(Equal([]cf.CloudControllerUser{{GUID: "user-123"}}))
+			Expect(page.Next).To(Equal("/v2/organizations/org-001/managers?page=2"))
+		})
+
+		It("lists every member of the organization when no role is given", func() {
+			page, err := cloudController.GetUsersPageByOrgGuid("org-001", "", testUAAToken, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(page.Users).To(Equal([]cf.CloudControllerUser{{GUID: "user-123"}}))
+			Expect(page.Next).To(Equal("/v2/organizations/org-001/users?page=2"))
+		})
+
+		It("returns the page the cursor points at", func() {
+			page, err := cloudController.GetUsersPageByOrgGuid("org-001", "OrgAuditor", testUAAToken, "/v2/organizations/org-001/auditors?page=2")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(page.Users).To(Equal([]cf.CloudControllerUser{{GUID: "user-456"}}))
+			Expect(page.Next).To(BeEmpty())
+			Expect(requests).To(Equal([]string{"/v2/organizations/org-001/auditors?page=2"}))
+		})
+
+		It("returns an error when the Cloud Controller returns a 400, or 500 status code", func() {
+			_, err := cloudController.GetUsersPageByOrgGuid("org-001", "OrgAuditor", "bad-token", "")
+
+			Expect(err).To(BeAssignableToTypeOf(cf.Failure{}))
+		})
+	})
+})
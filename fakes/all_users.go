@@ -1,14 +1,21 @@
 package fakes
 
+import "github.com/cloudfoundry-incubator/notifications/postal/utilities"
+
 type AllUsers struct {
 	LoadError error
-	GUIDs     []string
+	Pages     map[string]utilities.UserGUIDsPage
+	Cursors   []string
 }
 
 func NewAllUsers() *AllUsers {
-	return &AllUsers{}
+	return &AllUsers{
+		Pages: make(map[string]utilities.UserGUIDsPage),
+	}
 }
 
-func (fake *AllUsers) AllUserGUIDs() ([]string, error) {
-	return fake.GUIDs, fake.LoadError
+func (fake *AllUsers) AllUserGUIDs(cursor string) (utilities.UserGUIDsPage, error) {
+	fake.Cursors = append(fake.Cursors, cursor)
+
+	return fake.Pages[cursor], fake.LoadError
 }
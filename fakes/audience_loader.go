@@ -0,0 +1,35 @@
+package fakes
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/postal"
+	"github.com/cloudfoundry-incubator/notifications/postal/strategies"
+)
+
+type AudienceLoader struct {
+	LoadedAudience    strategies.Audience
+	AudienceError     error
+	Campaigns         []postal.Campaign
+	Pages             map[string]strategies.AudiencePage
+	AudiencePageError error
+	Cursors           []string
+}
+
+func NewAudienceLoader() *AudienceLoader {
+	return &AudienceLoader{
+		Campaigns: []postal.Campaign{},
+		Pages:     make(map[string]strategies.AudiencePage),
+		Cursors:   []string{},
+	}
+}
+
+func (fake *AudienceLoader) Audience(campaign postal.Campaign) (strategies.Audience, error) {
+	fake.Campaigns = append(fake.Campaigns, campaign)
+
+	return fake.LoadedAudience, fake.AudienceError
+}
+
+func (fake *AudienceLoader) AudiencePage(campaign postal.Campaign, cursor string) (strategies.AudiencePage, error) {
+	fake.Cursors = append(fake.Cursors, cursor)
+
+	return fake.Pages[cursor], fake.AudiencePageError
+}
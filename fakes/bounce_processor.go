@@ -0,0 +1,17 @@
+package fakes
+
+import "github.com/cloudfoundry-incubator/notifications/mail"
+
+type BounceProcessor struct {
+	ProcessArgument mail.DeliveryStatusNotification
+	ProcessError    error
+}
+
+func NewBounceProcessor() *BounceProcessor {
+	return &BounceProcessor{}
+}
+
+func (fake *BounceProcessor) Process(dsn mail.DeliveryStatusNotification) error {
+	fake.ProcessArgument = dsn
+	return fake.ProcessError
+}
@@ -0,0 +1,20 @@
+package fakes
+
+import "github.com/cloudfoundry-incubator/notifications/postal"
+
+type CampaignExpander struct {
+	ExpandedCampaigns []postal.Campaign
+	ExpandError       error
+}
+
+func NewCampaignExpander() *CampaignExpander {
+	return &CampaignExpander{
+		ExpandedCampaigns: []postal.Campaign{},
+	}
+}
+
+func (fake *CampaignExpander) Expand(campaign postal.Campaign) error {
+	fake.ExpandedCampaigns = append(fake.ExpandedCampaigns, campaign)
+
+	return fake.ExpandError
+}
@@ -0,0 +1,18 @@
+package fakes
+
+import "github.com/cloudfoundry-incubator/notifications/web/services"
+
+type CampaignFinder struct {
+	Campaigns map[string]services.Campaign
+	FindError error
+}
+
+func NewCampaignFinder() *CampaignFinder {
+	return &CampaignFinder{
+		Campaigns: map[string]services.Campaign{},
+	}
+}
+
+func (finder CampaignFinder) Find(campaignID string) (services.Campaign, error) {
+	return finder.Campaigns[campaignID], finder.FindError
+}
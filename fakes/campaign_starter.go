@@ -0,0 +1,26 @@
+package fakes
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/postal"
+	"github.com/cloudfoundry-incubator/notifications/postal/strategies"
+)
+
+type CampaignStarter struct {
+	StartArguments map[string]interface{}
+	Response       strategies.Response
+	StartError     error
+}
+
+func NewCampaignStarter() *CampaignStarter {
+	return &CampaignStarter{}
+}
+
+func (fake *CampaignStarter) Start(conn models.ConnectionInterface, campaign postal.Campaign) (strategies.Response, error) {
+	fake.StartArguments = map[string]interface{}{
+		"connection": conn,
+		"campaign":   campaign,
+	}
+
+	return fake.Response, fake.StartError
+}
@@ -0,0 +1,72 @@
+package fakes
+
+import (
+	"fmt"
+
+	"github.com/cloudfoundry-incubator/notifications/models"
+)
+
+type CampaignsRepo struct {
+	Campaigns      map[string]models.Campaign
+	Updates        []models.Campaign
+	CreateError    error
+	FindError      error
+	UpdateError    error
+	IncrementError error
+}
+
+func NewCampaignsRepo() *CampaignsRepo {
+	return &CampaignsRepo{
+		Campaigns: make(map[string]models.Campaign),
+		Updates:   []models.Campaign{},
+	}
+}
+
+func (fake *CampaignsRepo) Create(conn models.ConnectionInterface, campaign models.Campaign) (models.Campaign, error) {
+	if fake.CreateError != nil {
+		return models.Campaign{}, fake.CreateError
+	}
+
+	if campaign.Status == "" {
+		campaign.Status = models.CampaignStatusExpanding
+	}
+	fake.Campaigns[campaign.ID] = campaign
+
+	return campaign, nil
+}
+
+func (fake *CampaignsRepo) Find(conn models.ConnectionInterface, campaignID string) (models.Campaign, error) {
+	if fake.FindError != nil {
+		return models.Campaign{}, fake.FindError
+	}
+
+	campaign, ok := fake.Campaigns[campaignID]
+	if !ok {
+		return campaign, models.RecordNotFoundError(fmt.Sprintf("We did not find the campaign with ID %s", campaignID))
+	}
+
+	return campaign, nil
+}
+
+func (fake *CampaignsRepo) Update(conn models.ConnectionInterface, campaign models.Campaign) (models.Campaign, error) {
+	if fake.UpdateError != nil {
+		return campaign, fake.UpdateError
+	}
+
+	fake.Campaigns[campaign.ID] = campaign
+	fake.Updates = append(fake.Updates, campaign)
+
+	return campaign, nil
+}
+
+func (fake *CampaignsRepo) IncrementDeliveredCount(conn models.ConnectionInterface, campaignID string) error {
+	if fake.IncrementError != nil {
+		return fake.IncrementError
+	}
+
+	campaign := fake.Campaigns[campaignID]
+	campaign.DeliveredCount++
+	fake.Campaigns[campaignID] = campaign
+
+	return nil
+}
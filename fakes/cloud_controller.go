@@ -14,6 +14,7 @@ type CloudController struct {
 	GetManagersByOrganizationGuidError        error
 	GetAuditorsByOrganizationGuidError        error
 	GetBillingManagersByOrganizationGuidError error
+	GetUsersPageError                         error
 	LoadSpaceError                            error
 	LoadOrganizationError                     error
 	UsersBySpaceGuid                          map[string][]cf.CloudControllerUser
@@ -23,6 +24,8 @@ type CloudController struct {
 	BillingManagersByOrganization             map[string][]cf.CloudControllerUser
 	Spaces                                    map[string]cf.CloudControllerSpace
 	Orgs                                      map[string]cf.CloudControllerOrganization
+	UsersPages                                map[string]cf.CloudControllerUsersPage
+	UsersPageCursors                          []string
 }
 
 func NewCloudController() *CloudController {
@@ -32,6 +35,7 @@ func NewCloudController() *CloudController {
 		ManagersByOrganization:        make(map[string][]cf.CloudControllerUser),
 		AuditorsByOrganization:        make(map[string][]cf.CloudControllerUser),
 		BillingManagersByOrganization: make(map[string][]cf.CloudControllerUser),
+		UsersPages:                    make(map[string]cf.CloudControllerUsersPage),
 	}
 }
 
@@ -85,6 +89,20 @@ func (fake *CloudController) GetBillingManagersByOrgGuid(guid, token string) ([]
 	}
 }
 
+func (fake *CloudController) GetUsersPageBySpaceGuid(guid, token, cursor string) (cf.CloudControllerUsersPage, error) {
+	fake.CurrentToken = token
+	fake.UsersPageCursors = append(fake.UsersPageCursors, cursor)
+
+	return fake.UsersPages[guid+cursor], fake.GetUsersPageError
+}
+
+func (fake *CloudController) GetUsersPageByOrgGuid(guid, role, token, cursor string) (cf.CloudControllerUsersPage, error) {
+	fake.CurrentToken = token
+	fake.UsersPageCursors = append(fake.UsersPageCursors, cursor)
+
+	return fake.UsersPages[guid+role+cursor], fake.GetUsersPageError
+}
+
 func (fake *CloudController) LoadSpace(guid, token string) (cf.CloudControllerSpace, error) {
 	if fake.LoadSpaceError != nil {
 		return cf.CloudControllerSpace{}, fake.LoadSpaceError
@@ -0,0 +1,23 @@
+package fakes
+
+type DeadJobPurger struct {
+	PurgeArgument     int
+	PurgeError        error
+	PurgeAllWasCalled bool
+	PurgeAllCount     int
+	PurgeAllError     error
+}
+
+func NewDeadJobPurger() *DeadJobPurger {
+	return &DeadJobPurger{}
+}
+
+func (fake *DeadJobPurger) Purge(id int) error {
+	fake.PurgeArgument = id
+	return fake.PurgeError
+}
+
+func (fake *DeadJobPurger) PurgeAll() (int, error) {
+	fake.PurgeAllWasCalled = true
+	return fake.PurgeAllCount, fake.PurgeAllError
+}
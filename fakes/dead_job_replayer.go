@@ -0,0 +1,15 @@
+package fakes
+
+type DeadJobReplayer struct {
+	ReplayArgument int
+	ReplayError    error
+}
+
+func NewDeadJobReplayer() *DeadJobReplayer {
+	return &DeadJobReplayer{}
+}
+
+func (fake *DeadJobReplayer) Replay(id int) error {
+	fake.ReplayArgument = id
+	return fake.ReplayError
+}
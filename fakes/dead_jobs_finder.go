@@ -0,0 +1,40 @@
+package fakes
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+)
+
+type DeadJobsFinder struct {
+	DeadJobs      []services.DeadJob
+	ListWasCalled bool
+	ListError     error
+	FindArgument  int
+	FindError     error
+}
+
+func NewDeadJobsFinder() *DeadJobsFinder {
+	return &DeadJobsFinder{
+		DeadJobs: []services.DeadJob{},
+	}
+}
+
+func (fake *DeadJobsFinder) List() ([]services.DeadJob, error) {
+	fake.ListWasCalled = true
+	return fake.DeadJobs, fake.ListError
+}
+
+func (fake *DeadJobsFinder) Find(id int) (services.DeadJob, error) {
+	fake.FindArgument = id
+	if fake.FindError != nil {
+		return services.DeadJob{}, fake.FindError
+	}
+
+	for _, deadJob := range fake.DeadJobs {
+		if deadJob.ID == id {
+			return deadJob, nil
+		}
+	}
+
+	return services.DeadJob{}, models.NewRecordNotFoundError("Dead job with ID %d could not be found", id)
+}
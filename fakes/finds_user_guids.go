@@ -1,5 +1,7 @@
 package fakes
 
+import "github.com/cloudfoundry-incubator/notifications/postal/utilities"
+
 type FindsUserGUIDs struct {
 	SpaceGuids                            map[string][]string
 	UserGUIDsBelongingToSpaceError        error
@@ -7,6 +9,8 @@ type FindsUserGUIDs struct {
 	UserGUIDsBelongingToOrganizationError error
 	GUIDsWithScopes                       map[string][]string
 	UserGUIDsBelongingToScopeError        error
+	Cursors                               []string
+	NextCursor                            string
 }
 
 func NewFindsUserGUIDs() *FindsUserGUIDs {
@@ -17,14 +21,18 @@ func NewFindsUserGUIDs() *FindsUserGUIDs {
 	}
 }
 
-func (finder FindsUserGUIDs) UserGUIDsBelongingToSpace(spaceGUID, token string) ([]string, error) {
-	return finder.SpaceGuids[spaceGUID], finder.UserGUIDsBelongingToSpaceError
+func (finder *FindsUserGUIDs) UserGUIDsBelongingToSpace(spaceGUID, token, cursor string) (utilities.UserGUIDsPage, error) {
+	finder.Cursors = append(finder.Cursors, cursor)
+
+	return utilities.UserGUIDsPage{GUIDs: finder.SpaceGuids[spaceGUID+cursor], Next: finder.NextCursor}, finder.UserGUIDsBelongingToSpaceError
 }
 
-func (finder FindsUserGUIDs) UserGUIDsBelongingToOrganization(orgGUID, role, token string) ([]string, error) {
-	return finder.OrganizationGuids[orgGUID], finder.UserGUIDsBelongingToOrganizationError
+func (finder *FindsUserGUIDs) UserGUIDsBelongingToOrganization(orgGUID, role, token, cursor string) (utilities.UserGUIDsPage, error) {
+	finder.Cursors = append(finder.Cursors, cursor)
+
+	return utilities.UserGUIDsPage{GUIDs: finder.OrganizationGuids[orgGUID+cursor], Next: finder.NextCursor}, finder.UserGUIDsBelongingToOrganizationError
 }
 
-func (finder FindsUserGUIDs) UserGUIDsBelongingToScope(scope string) ([]string, error) {
+func (finder *FindsUserGUIDs) UserGUIDsBelongingToScope(scope string) ([]string, error) {
 	return finder.GUIDsWithScopes[scope], finder.UserGUIDsBelongingToScopeError
 }
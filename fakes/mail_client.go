@@ -6,6 +6,7 @@ type MailClient struct {
 	Messages     []mail.Message
 	SendError    error
 	ConnectError error
+	Relay        string
 }
 
 func NewMailClient() MailClient {
@@ -16,6 +17,8 @@ func (fake *MailClient) Connect() error {
 	return fake.ConnectError
 }
 
+func (fake *MailClient) Close() {}
+
 func (fake *MailClient) Send(msg mail.Message) error {
 	err := fake.Connect()
 	if err != nil {
@@ -29,3 +32,12 @@ func (fake *MailClient) Send(msg mail.Message) error {
 	fake.Messages = append(fake.Messages, msg)
 	return nil
 }
+
+func (fake *MailClient) SendVia(msg mail.Message) (string, error) {
+	err := fake.Send(msg)
+	if err != nil {
+		return "", err
+	}
+
+	return fake.Relay, nil
+}
@@ -9,7 +9,12 @@ import (
 
 type Mailer struct {
 	DeliverArguments map[string]interface{}
+	DeliveredUsers   [][]strategies.User
 	Responses        []strategies.Response
+
+	EnqueueTransaction models.TransactionInterface
+	EnqueuedUsers      [][]strategies.User
+	EnqueueError       error
 }
 
 func NewMailer() *Mailer {
@@ -26,6 +31,26 @@ func (fake *Mailer) Deliver(conn models.ConnectionInterface, users []strategies.
 		"client":     client,
 		"scope":      scope,
 	}
+	fake.DeliveredUsers = append(fake.DeliveredUsers, users)
 
 	return fake.Responses
 }
+
+func (fake *Mailer) Enqueue(transaction models.TransactionInterface, users []strategies.User, options postal.Options, space cf.CloudControllerSpace, org cf.CloudControllerOrganization, client, scope string) ([]strategies.Response, error) {
+	fake.EnqueueTransaction = transaction
+	fake.DeliverArguments = map[string]interface{}{
+		"connection": transaction,
+		"users":      users,
+		"options":    options,
+		"space":      space,
+		"org":        org,
+		"client":     client,
+		"scope":      scope,
+	}
+	if fake.EnqueueError != nil {
+		return []strategies.Response{}, fake.EnqueueError
+	}
+	fake.EnqueuedUsers = append(fake.EnqueuedUsers, users)
+
+	return fake.Responses, nil
+}
@@ -0,0 +1,15 @@
+package fakes
+
+type MessageCanceler struct {
+	CancelArgument string
+	CancelError    error
+}
+
+func NewMessageCanceler() *MessageCanceler {
+	return &MessageCanceler{}
+}
+
+func (fake *MessageCanceler) Cancel(messageID string) error {
+	fake.CancelArgument = messageID
+	return fake.CancelError
+}
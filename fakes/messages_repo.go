@@ -43,7 +43,7 @@ func (fake MessagesRepo) Upsert(conn models.ConnectionInterface, message models.
 func (fake *MessagesRepo) DeleteBefore(conn models.ConnectionInterface, thresholdTime time.Time) (int, error) {
 	count := 0
 	for key, message := range fake.Messages {
-		if message.UpdatedAt.Before(thresholdTime) {
+		if message.UpdatedAt.Before(thresholdTime) && message.Status != models.StatusScheduled {
 			delete(fake.Messages, key)
 			count += 1
 		}
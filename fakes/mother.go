@@ -2,6 +2,7 @@ package fakes
 
 import (
 	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/postal"
 	"github.com/cloudfoundry-incubator/notifications/postal/strategies"
 	"github.com/cloudfoundry-incubator/notifications/web/handlers"
 	"github.com/cloudfoundry-incubator/notifications/web/middleware"
@@ -63,12 +64,40 @@ func (mother Mother) MessageFinder() services.MessageFinder {
 	return services.MessageFinder{}
 }
 
+func (mother Mother) MessageCanceler() services.MessageCanceler {
+	return services.MessageCanceler{}
+}
+
+func (mother Mother) CampaignFinder() services.CampaignFinder {
+	return services.CampaignFinder{}
+}
+
+func (mother Mother) DeadJobServiceObjects() (services.DeadJobsFinder, services.DeadJobReplayer, services.DeadJobPurger) {
+	return services.DeadJobsFinder{}, services.DeadJobReplayer{}, services.DeadJobPurger{}
+}
+
+func (mother Mother) SuppressionServiceObjects() (services.SuppressionsFinder, services.SuppressionsUpdater) {
+	return services.SuppressionsFinder{}, services.SuppressionsUpdater{}
+}
+
+func (mother Mother) BounceProcessor() services.BounceProcessor {
+	return services.BounceProcessor{}
+}
+
+func (mother Mother) TemplatePreviewer() postal.TemplatePreviewer {
+	return postal.TemplatePreviewer{}
+}
+
 func (mother Mother) TemplateServiceObjects() (services.TemplateCreator, services.TemplateFinder,
 	services.TemplateUpdater, services.TemplateDeleter, services.TemplateLister,
 	services.TemplateAssigner, services.TemplateAssociationLister) {
 	return services.TemplateCreator{}, services.TemplateFinder{}, services.TemplateUpdater{}, services.TemplateDeleter{}, services.TemplateLister{}, services.TemplateAssigner{}, services.TemplateAssociationLister{}
 }
 
+func (mother Mother) TemplateVersionServiceObjects() (services.TemplateVersionLister, services.TemplateVersionDiffer, services.TemplateRollbacker) {
+	return services.TemplateVersionLister{}, services.TemplateVersionDiffer{}, services.TemplateRollbacker{}
+}
+
 func (mother Mother) Database() models.DatabaseInterface {
 	return NewDatabase()
 }
@@ -1,16 +1,30 @@
 package fakes
 
-import "github.com/cloudfoundry-incubator/notifications/gobble"
+import (
+	"database/sql"
+
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+)
 
 type Queue struct {
-	jobs         chan gobble.Job
-	pk           int
-	EnqueueError error
+	jobs               chan gobble.Job
+	pk                 int
+	EnqueueError       error
+	EnqueueTransaction gobble.Execer
+	BuriedJobs         []gobble.Job
+	DeadJobsList       []gobble.DeadJob
+	DeadJobsError      error
+	ReplayedDeadJobIDs []int
+	PurgedDeadJobIDs   []int
+	CloseWasCalled     bool
+	CanceledReferences []string
+	CancelError        error
 }
 
 func NewQueue() *Queue {
 	return &Queue{
-		jobs: make(chan gobble.Job),
+		jobs:         make(chan gobble.Job),
+		DeadJobsList: []gobble.DeadJob{},
 	}
 }
 
@@ -27,6 +41,11 @@ func (fake *Queue) Enqueue(job gobble.Job) (gobble.Job, error) {
 	return job, nil
 }
 
+func (fake *Queue) EnqueueWithTransaction(job gobble.Job, transaction gobble.Execer) (gobble.Job, error) {
+	fake.EnqueueTransaction = transaction
+	return fake.Enqueue(job)
+}
+
 func (fake *Queue) Reserve(string) <-chan gobble.Job {
 	return fake.jobs
 }
@@ -40,4 +59,91 @@ func (fake *Queue) Requeue(job gobble.Job) {
 	}(job)
 }
 
-func (fake *Queue) Unlock() {}
+func (fake *Queue) Bury(job gobble.Job) {
+	fake.BuriedJobs = append(fake.BuriedJobs, job)
+}
+
+func (fake *Queue) KeepAlive(job gobble.Job, done <-chan bool) {
+	<-done
+}
+
+func (fake *Queue) Cancel(reference string) error {
+	if fake.CancelError != nil {
+		return fake.CancelError
+	}
+
+	fake.CanceledReferences = append(fake.CanceledReferences, reference)
+	return nil
+}
+
+func (fake *Queue) Close() {
+	fake.CloseWasCalled = true
+}
+
+func (fake *Queue) DeadJobs() ([]gobble.DeadJob, error) {
+	return fake.DeadJobsList, fake.DeadJobsError
+}
+
+func (fake *Queue) FindDeadJob(id int) (gobble.DeadJob, error) {
+	if fake.DeadJobsError != nil {
+		return gobble.DeadJob{}, fake.DeadJobsError
+	}
+
+	for _, deadJob := range fake.DeadJobsList {
+		if deadJob.ID == id {
+			return deadJob, nil
+		}
+	}
+
+	return gobble.DeadJob{}, sql.ErrNoRows
+}
+
+func (fake *Queue) ReplayDeadJob(id int) (gobble.Job, error) {
+	deadJob, err := fake.FindDeadJob(id)
+	if err != nil {
+		return gobble.Job{}, err
+	}
+
+	fake.ReplayedDeadJobIDs = append(fake.ReplayedDeadJobIDs, id)
+	fake.removeDeadJob(id)
+
+	return fake.Enqueue(gobble.Job{
+		Payload: deadJob.Payload,
+	})
+}
+
+func (fake *Queue) PurgeDeadJob(id int) error {
+	_, err := fake.FindDeadJob(id)
+	if err != nil {
+		return err
+	}
+
+	fake.PurgedDeadJobIDs = append(fake.PurgedDeadJobIDs, id)
+	fake.removeDeadJob(id)
+
+	return nil
+}
+
+func (fake *Queue) PurgeDeadJobs() (int, error) {
+	if fake.DeadJobsError != nil {
+		return 0, fake.DeadJobsError
+	}
+
+	count := len(fake.DeadJobsList)
+	for _, deadJob := range fake.DeadJobsList {
+		fake.PurgedDeadJobIDs = append(fake.PurgedDeadJobIDs, deadJob.ID)
+	}
+	fake.DeadJobsList = []gobble.DeadJob{}
+
+	return count, nil
+}
+
+func (fake *Queue) removeDeadJob(id int) {
+	deadJobs := []gobble.DeadJob{}
+	for _, deadJob := range fake.DeadJobsList {
+		if deadJob.ID != id {
+			deadJobs = append(deadJobs, deadJob)
+		}
+	}
+	fake.DeadJobsList = deadJobs
+}
@@ -0,0 +1,18 @@
+package fakes
+
+import "github.com/cloudfoundry-incubator/notifications/web/services"
+
+type SuppressionsFinder struct {
+	Suppressions []services.Suppression
+	ListError    error
+}
+
+func NewSuppressionsFinder() *SuppressionsFinder {
+	return &SuppressionsFinder{
+		Suppressions: []services.Suppression{},
+	}
+}
+
+func (fake *SuppressionsFinder) List() ([]services.Suppression, error) {
+	return fake.Suppressions, fake.ListError
+}
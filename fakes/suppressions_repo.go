@@ -0,0 +1,79 @@
+package fakes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/models"
+)
+
+type SuppressionsRepo struct {
+	Suppressions map[string]models.Suppression
+	UpsertError  error
+	FindError    error
+	FindAllError error
+	DeleteError  error
+}
+
+func NewSuppressionsRepo() *SuppressionsRepo {
+	return &SuppressionsRepo{
+		Suppressions: make(map[string]models.Suppression),
+	}
+}
+
+func (fake *SuppressionsRepo) Upsert(conn models.ConnectionInterface, suppression models.Suppression) (models.Suppression, error) {
+	if fake.UpsertError != nil {
+		return suppression, fake.UpsertError
+	}
+
+	suppression.Email = strings.ToLower(suppression.Email)
+	fake.Suppressions[suppression.Email] = suppression
+
+	return suppression, nil
+}
+
+func (fake *SuppressionsRepo) Find(conn models.ConnectionInterface, email string) (models.Suppression, error) {
+	if fake.FindError != nil {
+		return models.Suppression{}, fake.FindError
+	}
+
+	suppression, ok := fake.Suppressions[strings.ToLower(email)]
+	if !ok {
+		return suppression, models.RecordNotFoundError(fmt.Sprintf("We did not find a suppression for %s", email))
+	}
+
+	return suppression, nil
+}
+
+func (fake *SuppressionsRepo) FindAll(conn models.ConnectionInterface) ([]models.Suppression, error) {
+	if fake.FindAllError != nil {
+		return []models.Suppression{}, fake.FindAllError
+	}
+
+	emails := []string{}
+	for email := range fake.Suppressions {
+		emails = append(emails, email)
+	}
+	sort.Strings(emails)
+
+	suppressions := []models.Suppression{}
+	for _, email := range emails {
+		suppressions = append(suppressions, fake.Suppressions[email])
+	}
+
+	return suppressions, nil
+}
+
+func (fake *SuppressionsRepo) Delete(conn models.ConnectionInterface, email string) error {
+	if fake.DeleteError != nil {
+		return fake.DeleteError
+	}
+
+	if _, ok := fake.Suppressions[strings.ToLower(email)]; !ok {
+		return models.RecordNotFoundError(fmt.Sprintf("We did not find a suppression for %s", email))
+	}
+
+	delete(fake.Suppressions, strings.ToLower(email))
+	return nil
+}
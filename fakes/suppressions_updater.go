@@ -0,0 +1,28 @@
+package fakes
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+)
+
+type SuppressionsUpdater struct {
+	AddArgument    models.Suppression
+	AddResult      services.Suppression
+	AddError       error
+	RemoveArgument string
+	RemoveError    error
+}
+
+func NewSuppressionsUpdater() *SuppressionsUpdater {
+	return &SuppressionsUpdater{}
+}
+
+func (fake *SuppressionsUpdater) Add(suppression models.Suppression) (services.Suppression, error) {
+	fake.AddArgument = suppression
+	return fake.AddResult, fake.AddError
+}
+
+func (fake *SuppressionsUpdater) Remove(email string) error {
+	fake.RemoveArgument = email
+	return fake.RemoveError
+}
@@ -4,6 +4,7 @@ import "github.com/cloudfoundry-incubator/notifications/models"
 
 type TemplateCreator struct {
 	CreateArgument models.Template
+	CreateAuthor   string
 	CreateError    error
 }
 
@@ -11,7 +12,8 @@ func NewTemplateCreator() *TemplateCreator {
 	return &TemplateCreator{}
 }
 
-func (fake *TemplateCreator) Create(template models.Template) (string, error) {
+func (fake *TemplateCreator) Create(template models.Template, author string) (string, error) {
 	fake.CreateArgument = template
+	fake.CreateAuthor = author
 	return "guid", fake.CreateError
 }
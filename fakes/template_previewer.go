@@ -0,0 +1,23 @@
+package fakes
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/cloudfoundry-incubator/notifications/postal"
+)
+
+type TemplatePreviewer struct {
+	Delivery     postal.Delivery
+	Templates    postal.Templates
+	Message      mail.Message
+	PreviewError error
+}
+
+func NewTemplatePreviewer() *TemplatePreviewer {
+	return &TemplatePreviewer{}
+}
+
+func (fake *TemplatePreviewer) Preview(delivery postal.Delivery, templates postal.Templates) (mail.Message, error) {
+	fake.Delivery = delivery
+	fake.Templates = templates
+	return fake.Message, fake.PreviewError
+}
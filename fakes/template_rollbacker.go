@@ -0,0 +1,19 @@
+package fakes
+
+type TemplateRollbacker struct {
+	RollbackArgumentID      string
+	RollbackArgumentVersion int
+	RollbackAuthor          string
+	RollbackError           error
+}
+
+func NewTemplateRollbacker() *TemplateRollbacker {
+	return &TemplateRollbacker{}
+}
+
+func (fake *TemplateRollbacker) Rollback(templateID string, version int, author string) error {
+	fake.RollbackArgumentID = templateID
+	fake.RollbackArgumentVersion = version
+	fake.RollbackAuthor = author
+	return fake.RollbackError
+}
@@ -6,6 +6,7 @@ type TemplateUpdater struct {
 	UpdateArgumentID   string
 	UpdateArgumentBody models.Template
 	UpdateArgument     models.Template
+	UpdateAuthor       string
 	UpdateError        error
 }
 
@@ -13,9 +14,10 @@ func NewTemplateUpdater() *TemplateUpdater {
 	return &TemplateUpdater{}
 }
 
-func (fake *TemplateUpdater) Update(templateID string, template models.Template) error {
+func (fake *TemplateUpdater) Update(templateID string, template models.Template, author string) error {
 	fake.UpdateArgumentID = templateID
 	fake.UpdateArgumentBody = template
+	fake.UpdateAuthor = author
 
 	return fake.UpdateError
 }
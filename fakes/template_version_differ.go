@@ -0,0 +1,22 @@
+package fakes
+
+import "github.com/cloudfoundry-incubator/notifications/web/services"
+
+type TemplateVersionDiffer struct {
+	DiffArgumentID   string
+	DiffArgumentFrom int
+	DiffArgumentTo   int
+	DiffResult       services.TemplateDiff
+	DiffError        error
+}
+
+func NewTemplateVersionDiffer() *TemplateVersionDiffer {
+	return &TemplateVersionDiffer{}
+}
+
+func (fake *TemplateVersionDiffer) Diff(templateID string, from, to int) (services.TemplateDiff, error) {
+	fake.DiffArgumentID = templateID
+	fake.DiffArgumentFrom = from
+	fake.DiffArgumentTo = to
+	return fake.DiffResult, fake.DiffError
+}
@@ -0,0 +1,18 @@
+package fakes
+
+import "github.com/cloudfoundry-incubator/notifications/models"
+
+type TemplateVersionLister struct {
+	ListArgument string
+	Versions     []models.TemplateVersion
+	ListError    error
+}
+
+func NewTemplateVersionLister() *TemplateVersionLister {
+	return &TemplateVersionLister{}
+}
+
+func (fake *TemplateVersionLister) List(templateID string) ([]models.TemplateVersion, error) {
+	fake.ListArgument = templateID
+	return fake.Versions, fake.ListError
+}
@@ -0,0 +1,48 @@
+package fakes
+
+import "github.com/cloudfoundry-incubator/notifications/models"
+
+type TemplateVersionsRepo struct {
+	Versions    []models.TemplateVersion
+	CreateError error
+	FindError   error
+	ListError   error
+}
+
+func NewTemplateVersionsRepo() *TemplateVersionsRepo {
+	return &TemplateVersionsRepo{}
+}
+
+func (fake *TemplateVersionsRepo) Create(conn models.ConnectionInterface, version models.TemplateVersion) (models.TemplateVersion, error) {
+	if fake.CreateError != nil {
+		return models.TemplateVersion{}, fake.CreateError
+	}
+
+	fake.Versions = append(fake.Versions, version)
+	return version, nil
+}
+
+func (fake *TemplateVersionsRepo) Find(conn models.ConnectionInterface, templateID string, number int) (models.TemplateVersion, error) {
+	if fake.FindError != nil {
+		return models.TemplateVersion{}, fake.FindError
+	}
+
+	for _, version := range fake.Versions {
+		if version.TemplateID == templateID && version.Version == number {
+			return version, nil
+		}
+	}
+
+	return models.TemplateVersion{}, models.NewRecordNotFoundError("Version %d of template %q could not be found", number, templateID)
+}
+
+func (fake *TemplateVersionsRepo) List(conn models.ConnectionInterface, templateID string) ([]models.TemplateVersion, error) {
+	versions := []models.TemplateVersion{}
+	for i := len(fake.Versions) - 1; i >= 0; i-- {
+		if fake.Versions[i].TemplateID == templateID {
+			versions = append(versions, fake.Versions[i])
+		}
+	}
+
+	return versions, fake.ListError
+}
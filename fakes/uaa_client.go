@@ -12,6 +12,8 @@ type UAAClient struct {
 	UsersGUIDsByScopeError    error
 	AllUsersError             error
 	AllUsersData              []uaa.User
+	UsersPageSize             int
+	UsersPageStartIndexes     []int
 }
 
 func NewUAAClient() *UAAClient {
@@ -46,3 +48,19 @@ func (fake *UAAClient) UsersGUIDsByScope(scope string) ([]string, error) {
 func (fake *UAAClient) AllUsers() ([]uaa.User, error) {
 	return fake.AllUsersData, fake.AllUsersError
 }
+
+func (fake *UAAClient) UsersPage(startIndex int) ([]uaa.User, int, error) {
+	fake.UsersPageStartIndexes = append(fake.UsersPageStartIndexes, startIndex)
+
+	start := startIndex - 1
+	if start > len(fake.AllUsersData) {
+		start = len(fake.AllUsersData)
+	}
+
+	end := len(fake.AllUsersData)
+	if fake.UsersPageSize > 0 && start+fake.UsersPageSize < end {
+		end = start + fake.UsersPageSize
+	}
+
+	return fake.AllUsersData[start:end], len(fake.AllUsersData), fake.AllUsersError
+}
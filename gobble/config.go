@@ -1,7 +1,13 @@
 package gobble
 
-import "time"
+import (
+	"log"
+	"time"
+)
 
 type Config struct {
 	WaitMaxDuration time.Duration
+	LeaseDuration   time.Duration
+	BatchSize       int
+	Logger          *log.Logger
 }
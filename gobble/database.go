@@ -48,6 +48,7 @@ func Database() *DB {
 	migrationsDir := os.Getenv("GOBBLE_MIGRATIONS_DIR")
 	migrate(databaseURL, migrationsDir)
 	conn.AddTableWithName(Job{}, "jobs").SetKeys(true, "ID")
+	conn.AddTableWithName(DeadJob{}, "dead_jobs").SetKeys(true, "ID")
 
 	_database = &DB{
 		Connection: conn,
@@ -75,6 +75,67 @@ var _ = Describe("Database", func() {
 			Field: "active_at",
 			Type:  "timestamp",
 		}))
+		Expect(columns).To(ContainElement(Column{
+			Field: "locked_until",
+			Type:  "timestamp",
+		}))
+		Expect(columns).To(ContainElement(Column{
+			Field: "priority",
+			Type:  "int",
+		}))
+		Expect(columns).To(ContainElement(Column{
+			Field: "reference",
+			Type:  "varchar",
+		}))
+	})
+
+	It("has a dead_jobs table", func() {
+		rows, err := gobble.Database().Connection.Db.Query("SELECT COLUMN_NAME, DATA_TYPE FROM INFORMATION_SCHEMA.COLUMNS WHERE TABLE_NAME = 'dead_jobs'")
+		if err != nil {
+			panic(err)
+		}
+		defer rows.Close()
+		columns := []Column{}
+
+		for rows.Next() {
+			var Field, Type string
+			if err := rows.Scan(&Field, &Type); err != nil {
+				panic(err)
+			}
+			columns = append(columns, Column{
+				Field: Field,
+				Type:  Type,
+			})
+		}
+
+		Expect(columns).To(ContainElement(Column{
+			Field: "id",
+			Type:  "int",
+		}))
+		Expect(columns).To(ContainElement(Column{
+			Field: "job_id",
+			Type:  "int",
+		}))
+		Expect(columns).To(ContainElement(Column{
+			Field: "payload",
+			Type:  "longtext",
+		}))
+		Expect(columns).To(ContainElement(Column{
+			Field: "retry_count",
+			Type:  "int",
+		}))
+		Expect(columns).To(ContainElement(Column{
+			Field: "last_error",
+			Type:  "text",
+		}))
+		Expect(columns).To(ContainElement(Column{
+			Field: "last_attempt_at",
+			Type:  "timestamp",
+		}))
+		Expect(columns).To(ContainElement(Column{
+			Field: "created_at",
+			Type:  "timestamp",
+		}))
 	})
 
 	It("only ever instantiates a single DB object", func() {
@@ -0,0 +1,28 @@
+package gobble
+
+import "time"
+
+type DeadJob struct {
+	ID            int       `db:"id"`
+	JobID         int       `db:"job_id"`
+	Payload       string    `db:"payload"`
+	RetryCount    int       `db:"retry_count"`
+	LastError     string    `db:"last_error"`
+	LastAttemptAt time.Time `db:"last_attempt_at"`
+	CreatedAt     time.Time `db:"created_at"`
+	Priority      int       `db:"priority"`
+	Reference     string    `db:"reference"`
+}
+
+func NewDeadJob(job Job) DeadJob {
+	return DeadJob{
+		JobID:         job.ID,
+		Payload:       job.Payload,
+		RetryCount:    job.RetryCount,
+		LastError:     job.LastError,
+		LastAttemptAt: job.ActiveAt,
+		CreatedAt:     time.Now().Truncate(1 * time.Second).UTC(),
+		Priority:      job.Priority,
+		Reference:     job.Reference,
+	}
+}
@@ -12,7 +12,12 @@ type Job struct {
 	Version     int64     `db:"version"`
 	RetryCount  int       `db:"retry_count"`
 	ActiveAt    time.Time `db:"active_at"`
+	LockedUntil time.Time `db:"locked_until"`
+	Priority    int       `db:"priority"`
+	Reference   string    `db:"reference"`
 	ShouldRetry bool      `db:"-"`
+	ShouldBury  bool      `db:"-"`
+	LastError   string    `db:"-"`
 }
 
 func NewJob(data interface{}) Job {
@@ -36,3 +41,10 @@ func (job *Job) Retry(duration time.Duration) {
 	job.ActiveAt = time.Now().Add(duration)
 	job.ShouldRetry = true
 }
+
+func (job *Job) Bury(reason string) {
+	job.WorkerID = ""
+	job.LastError = reason
+	job.ShouldRetry = false
+	job.ShouldBury = true
+}
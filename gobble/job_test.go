@@ -58,4 +58,21 @@ var _ = Describe("Job", func() {
 			Expect(job.ShouldRetry).To(BeTrue())
 		})
 	})
+
+	Describe("Bury", func() {
+		It("sets up the job to be moved to the dead jobs table", func() {
+			job := gobble.NewJob("the data")
+			job.RetryCount = 10
+			job.WorkerID = "my-id"
+			job.ShouldRetry = true
+
+			job.Bury("the last error")
+
+			Expect(job.WorkerID).To(Equal(""))
+			Expect(job.RetryCount).To(Equal(10))
+			Expect(job.LastError).To(Equal("the last error"))
+			Expect(job.ShouldRetry).To(BeFalse())
+			Expect(job.ShouldBury).To(BeTrue())
+		})
+	})
 })
@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE `dead_jobs` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `job_id` int(11) NOT NULL,
+  `payload` longtext,
+  `retry_count` int(11) NOT NULL DEFAULT 0,
+  `last_error` text,
+  `last_attempt_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8;
+
+-- +goose Down
+DROP TABLE dead_jobs;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE `jobs` ADD locked_until timestamp NOT NULL DEFAULT '1970-01-01 00:00:01';
+
+-- +goose Down
+ALTER TABLE `jobs` DROP COLUMN locked_until;
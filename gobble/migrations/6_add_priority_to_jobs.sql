@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE `jobs` ADD priority int(11) NOT NULL DEFAULT 0;
+CREATE INDEX `jobs_priority_active_at` ON `jobs` (`priority`, `active_at`);
+
+-- +goose Down
+DROP INDEX `jobs_priority_active_at` ON `jobs`;
+ALTER TABLE `jobs` DROP COLUMN priority;
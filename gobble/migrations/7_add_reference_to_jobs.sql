@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE `jobs` ADD reference varchar(255) NOT NULL DEFAULT '';
+CREATE INDEX `jobs_reference` ON `jobs` (`reference`);
+
+-- +goose Down
+DROP INDEX `jobs_reference` ON `jobs`;
+ALTER TABLE `jobs` DROP COLUMN reference;
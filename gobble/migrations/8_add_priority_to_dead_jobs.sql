@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE `dead_jobs` ADD priority int(11) NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE `dead_jobs` DROP COLUMN priority;
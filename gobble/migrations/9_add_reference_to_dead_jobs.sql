@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE `dead_jobs` ADD reference varchar(255) NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE `dead_jobs` DROP COLUMN reference;
@@ -2,26 +2,55 @@ package gobble
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
+	"log"
 	"math/rand"
+	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/coopernurse/gorp"
 )
 
-var WaitMaxDuration = 5 * time.Second
+var (
+	WaitMaxDuration = 5 * time.Second
+	LeaseDuration   = 1 * time.Minute
+	BatchSize       = 10
+)
+
+var ErrJobReserved = errors.New("gobble: job is reserved by a worker")
+
+// Jobs that a queue has claimed but not yet handed to a worker are held under
+// the ID of the queue, which always starts with this prefix.
+const queueIDPrefix = "queue-"
 
 type QueueInterface interface {
 	Enqueue(Job) (Job, error)
+	EnqueueWithTransaction(Job, Execer) (Job, error)
 	Reserve(string) <-chan Job
 	Dequeue(Job)
 	Requeue(Job)
-	Unlock()
+	Bury(Job)
+	KeepAlive(Job, <-chan bool)
+	Cancel(string) error
+	Close()
+	DeadJobs() ([]DeadJob, error)
+	FindDeadJob(int) (DeadJob, error)
+	ReplayDeadJob(int) (Job, error)
+	PurgeDeadJob(int) error
+	PurgeDeadJobs() (int, error)
 }
 
 type Queue struct {
-	config   Config
-	database *DB
-	closed   bool
+	ID        string
+	config    Config
+	database  *DB
+	reserved  chan Job
+	fetchOnce sync.Once
+	closed    chan bool
+	closeOnce sync.Once
 }
 
 func NewQueue(config Config) *Queue {
@@ -29,9 +58,24 @@ func NewQueue(config Config) *Queue {
 		config.WaitMaxDuration = WaitMaxDuration
 	}
 
+	if config.LeaseDuration == 0 {
+		config.LeaseDuration = LeaseDuration
+	}
+
+	if config.BatchSize == 0 {
+		config.BatchSize = BatchSize
+	}
+
+	if config.Logger == nil {
+		config.Logger = log.New(os.Stdout, "", log.LstdFlags)
+	}
+
 	return &Queue{
+		ID:       fmt.Sprintf("%s%d", queueIDPrefix, os.Getpid()),
 		database: Database(),
 		config:   config,
+		reserved: make(chan Job),
+		closed:   make(chan bool),
 	}
 }
 
@@ -44,9 +88,34 @@ func (queue *Queue) Enqueue(job Job) (Job, error) {
 	return job, nil
 }
 
+// Execer runs statements for EnqueueWithTransaction, usually as part of a
+// transaction the caller has begun on the same database as the queue.
+type Execer interface {
+	Exec(string, ...interface{}) (sql.Result, error)
+}
+
+// EnqueueWithTransaction inserts the job through the caller's transaction, so
+// that the job only becomes visible to workers if the caller's other changes
+// are committed along with it.
+func (queue *Queue) EnqueueWithTransaction(job Job, transaction Execer) (Job, error) {
+	result, err := transaction.Exec("INSERT INTO `jobs` (`worker_id`, `payload`, `version`, `retry_count`, `active_at`, `locked_until`, `priority`, `reference`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		job.WorkerID, job.Payload, job.Version, job.RetryCount, job.ActiveAt, job.LockedUntil, job.Priority, job.Reference)
+	if err != nil {
+		return job, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return job, err
+	}
+	job.ID = int(id)
+
+	return job, nil
+}
+
 func (queue *Queue) Requeue(job Job) {
 	_, err := queue.database.Connection.Update(&job)
-	if err != nil {
+	if err != nil && !isLeaseLost(err) {
 		panic(err)
 	}
 }
@@ -58,19 +127,42 @@ func (queue *Queue) Reserve(workerID string) <-chan Job {
 	return channel
 }
 
+// Close stops the queue from reserving any more jobs. Reservations that are
+// still waiting for a job give up, and any job that was reserved but not yet
+// handed to a worker is released back to the queue.
 func (queue *Queue) Close() {
-	queue.closed = true
+	queue.closeOnce.Do(func() {
+		close(queue.closed)
+	})
+}
+
+func (queue *Queue) isClosed() bool {
+	select {
+	case <-queue.closed:
+		return true
+	default:
+		return false
+	}
 }
 
 func (queue *Queue) reserve(channel chan Job, workerID string) {
+	queue.fetchOnce.Do(func() {
+		go queue.fetch()
+	})
+
 	job := Job{}
 	for job.ID == 0 {
 		var err error
 
-		job = queue.findJob()
-		job, err = queue.updateJob(job, workerID)
+		select {
+		case job = <-queue.reserved:
+		case <-queue.closed:
+			return
+		}
+
+		job, err = queue.updateJob(job, workerID, time.Now().Add(queue.config.LeaseDuration))
 		if err != nil {
-			if _, ok := err.(gorp.OptimisticLockError); ok {
+			if isLeaseLost(err) {
 				job = Job{}
 				continue
 			} else {
@@ -78,46 +170,255 @@ func (queue *Queue) reserve(channel chan Job, workerID string) {
 			}
 		}
 	}
-	if queue.closed {
-		queue.updateJob(job, "")
-		return
-	}
 
-	channel <- job
+	select {
+	case channel <- job:
+	case <-queue.closed:
+		queue.updateJob(job, "", time.Now())
+	}
 }
 
 func (queue *Queue) Dequeue(job Job) {
 	_, err := queue.database.Connection.Delete(&job)
-	if err != nil {
+	if err != nil && !isLeaseLost(err) {
 		panic(err)
 	}
 }
 
-func (queue Queue) Unlock() {
-	_, err := queue.database.Connection.Exec("UPDATE `jobs` set `worker_id` = \"\" WHERE `worker_id` != \"\"")
+// KeepAlive renews the lease on a reserved job until done is closed, so that
+// the job is not reclaimed by another worker while it is being performed. A
+// lease that cannot be renewed is tried again on the next tick.
+func (queue *Queue) KeepAlive(job Job, done <-chan bool) {
+	ticker := time.NewTicker(queue.config.LeaseDuration / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			queue.renewLease(job)
+		case <-done:
+			return
+		}
+	}
+}
+
+func (queue *Queue) Bury(job Job) {
+	transaction, err := queue.database.Connection.Begin()
+	if err != nil {
+		panic(err)
+	}
+
+	deadJob := NewDeadJob(job)
+	err = transaction.Insert(&deadJob)
+	if err != nil {
+		transaction.Rollback()
+		panic(err)
+	}
+
+	_, err = transaction.Delete(&job)
+	if err != nil {
+		transaction.Rollback()
+		if isLeaseLost(err) {
+			return
+		}
+		panic(err)
+	}
+
+	err = transaction.Commit()
 	if err != nil {
 		panic(err)
 	}
 }
 
-func (queue *Queue) findJob() Job {
+// Cancel deletes the job with the given reference, as long as no worker
+// currently holds a lease on it. Jobs that a queue has only claimed can still
+// be canceled, since the queue skips them when their lease cannot be handed
+// to a worker. It returns sql.ErrNoRows if there is no such job, and
+// ErrJobReserved if a worker has already reserved it.
+func (queue *Queue) Cancel(reference string) error {
+	transaction, err := queue.database.Connection.Begin()
+	if err != nil {
+		return err
+	}
+
 	job := Job{}
-	for job.ID == 0 {
-		err := queue.database.Connection.SelectOne(&job, "SELECT * FROM `jobs` WHERE `worker_id` = \"\" AND `active_at` <= ? LIMIT 1", time.Now())
+	err = transaction.SelectOne(&job, "SELECT * FROM `jobs` WHERE `reference` = ? FOR UPDATE", reference)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	heldByWorker := job.WorkerID != "" && !strings.HasPrefix(job.WorkerID, queueIDPrefix)
+	if heldByWorker && job.LockedUntil.After(time.Now()) {
+		transaction.Rollback()
+		return ErrJobReserved
+	}
+
+	_, err = transaction.Delete(&job)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	return transaction.Commit()
+}
+
+func (queue *Queue) DeadJobs() ([]DeadJob, error) {
+	deadJobs := []DeadJob{}
+	_, err := queue.database.Connection.Select(&deadJobs, "SELECT * FROM `dead_jobs` ORDER BY `created_at` DESC, `id` DESC")
+	if err != nil {
+		return []DeadJob{}, err
+	}
+
+	return deadJobs, nil
+}
+
+func (queue *Queue) FindDeadJob(id int) (DeadJob, error) {
+	deadJob := DeadJob{}
+	err := queue.database.Connection.SelectOne(&deadJob, "SELECT * FROM `dead_jobs` WHERE `id` = ?", id)
+	if err != nil {
+		return DeadJob{}, err
+	}
+
+	return deadJob, nil
+}
+
+func (queue *Queue) ReplayDeadJob(id int) (Job, error) {
+	transaction, err := queue.database.Connection.Begin()
+	if err != nil {
+		return Job{}, err
+	}
+
+	deadJob := DeadJob{}
+	err = transaction.SelectOne(&deadJob, "SELECT * FROM `dead_jobs` WHERE `id` = ? FOR UPDATE", id)
+	if err != nil {
+		transaction.Rollback()
+		return Job{}, err
+	}
+
+	job := Job{
+		Payload:   deadJob.Payload,
+		ActiveAt:  time.Now(),
+		Priority:  deadJob.Priority,
+		Reference: deadJob.Reference,
+	}
+	err = transaction.Insert(&job)
+	if err != nil {
+		transaction.Rollback()
+		return Job{}, err
+	}
+
+	_, err = transaction.Delete(&deadJob)
+	if err != nil {
+		transaction.Rollback()
+		return Job{}, err
+	}
+
+	err = transaction.Commit()
+	if err != nil {
+		return Job{}, err
+	}
+
+	return job, nil
+}
+
+func (queue *Queue) PurgeDeadJob(id int) error {
+	result, err := queue.database.Connection.Exec("DELETE FROM `dead_jobs` WHERE `id` = ?", id)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (queue *Queue) PurgeDeadJobs() (int, error) {
+	result, err := queue.database.Connection.Exec("DELETE FROM `dead_jobs`")
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+// fetch claims batches of available jobs and hands them out one at a time as
+// workers reserve them. The next batch is only claimed once every job of the
+// last one has been handed out, so the queue never holds more than a batch of
+// jobs that no worker has started. When the queue is closed, the jobs that
+// were not handed out are released.
+func (queue *Queue) fetch() {
+	for !queue.isClosed() {
+		jobs, err := queue.claimJobs()
 		if err != nil {
-			if err == sql.ErrNoRows {
-				job = Job{}
-				queue.waitUpTo(queue.config.WaitMaxDuration)
-				continue
-			}
 			panic(err)
 		}
+
+		if len(jobs) == 0 {
+			queue.waitUpTo(queue.config.WaitMaxDuration)
+			continue
+		}
+
+		for _, job := range jobs {
+			select {
+			case queue.reserved <- job:
+			case <-queue.closed:
+				queue.updateJob(job, "", time.Now())
+			}
+		}
 	}
-	return job
 }
 
-func (queue *Queue) updateJob(job Job, workerID string) (Job, error) {
+// claimJobs leases up to a batch of the highest priority, longest waiting
+// jobs to this queue in a single transaction. Rows locked by other queues are skipped rather
+// than waited on, so concurrent queues claim disjoint batches.
+func (queue *Queue) claimJobs() ([]Job, error) {
+	transaction, err := queue.database.Connection.Begin()
+	if err != nil {
+		return []Job{}, err
+	}
+
+	jobs := []Job{}
+	now := time.Now()
+	_, err = transaction.Select(&jobs, "SELECT * FROM `jobs` WHERE (`worker_id` = \"\" OR `locked_until` < ?) AND `active_at` <= ? ORDER BY `priority` DESC, `active_at`, `id` LIMIT ? FOR UPDATE SKIP LOCKED", now, now, queue.config.BatchSize)
+	if err != nil {
+		transaction.Rollback()
+		return []Job{}, err
+	}
+
+	for i := range jobs {
+		jobs[i].WorkerID = queue.ID
+		jobs[i].LockedUntil = now.Add(queue.config.LeaseDuration)
+		_, err = transaction.Update(&jobs[i])
+		if err != nil {
+			transaction.Rollback()
+			return []Job{}, err
+		}
+	}
+
+	err = transaction.Commit()
+	if err != nil {
+		return []Job{}, err
+	}
+
+	return jobs, nil
+}
+
+func (queue *Queue) updateJob(job Job, workerID string, lockedUntil time.Time) (Job, error) {
 	job.WorkerID = workerID
+	job.LockedUntil = lockedUntil
 	_, err := queue.database.Connection.Update(&job)
 	if err != nil {
 		return job, err
@@ -125,8 +426,26 @@ func (queue *Queue) updateJob(job Job, workerID string) (Job, error) {
 	return job, nil
 }
 
+func (queue *Queue) renewLease(job Job) {
+	_, err := queue.database.Connection.Exec("UPDATE `jobs` SET `locked_until` = ? WHERE `id` = ? AND `worker_id` = ?", time.Now().Add(queue.config.LeaseDuration), job.ID, job.WorkerID)
+	if err != nil {
+		queue.config.Logger.Printf("Failed to renew the lease on job %d, trying again shortly. Error: %s", job.ID, err)
+	}
+}
+
+// A job whose lease expired may have been reclaimed by another worker, in
+// which case its version no longer matches and that worker now owns it.
+func isLeaseLost(err error) bool {
+	_, ok := err.(gorp.OptimisticLockError)
+	return ok
+}
+
 func (queue *Queue) waitUpTo(max time.Duration) {
 	rand.Seed(time.Now().UnixNano())
 	waitTime := rand.Int63n(int64(max))
-	<-time.After(time.Duration(waitTime))
+
+	select {
+	case <-time.After(time.Duration(waitTime)):
+	case <-queue.closed:
+	}
 }
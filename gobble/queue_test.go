@@ -1,6 +1,7 @@
 package gobble_test
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/gobble"
@@ -48,6 +49,50 @@ var _ = Describe("Queue", func() {
 		})
 	})
 
+	Describe("EnqueueWithTransaction", func() {
+		It("only sticks the job in the database table when the transaction commits", func() {
+			transaction, err := gobble.Database().Connection.Begin()
+			if err != nil {
+				panic(err)
+			}
+
+			job, err := queue.EnqueueWithTransaction(gobble.NewJob(map[string]bool{
+				"testing": true,
+			}), transaction)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(job.ID).NotTo(BeZero())
+
+			err = transaction.Rollback()
+			if err != nil {
+				panic(err)
+			}
+
+			count, err := gobble.Database().Connection.SelectInt("SELECT COUNT(*) FROM `jobs`")
+			if err != nil {
+				panic(err)
+			}
+			Expect(count).To(Equal(int64(0)))
+
+			transaction, err = gobble.Database().Connection.Begin()
+			if err != nil {
+				panic(err)
+			}
+
+			job, err = queue.EnqueueWithTransaction(job, transaction)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = transaction.Commit()
+			if err != nil {
+				panic(err)
+			}
+
+			var committed gobble.Job
+			err = gobble.Database().Connection.SelectOne(&committed, "SELECT * FROM `jobs` WHERE `id` = ?", job.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(committed.Payload).To(Equal(`{"testing":true}`))
+		})
+	})
+
 	Describe("Requeue", func() {
 		It("updates the queue in the database", func() {
 			job := gobble.NewJob(map[string]bool{
@@ -181,6 +226,90 @@ var _ = Describe("Queue", func() {
 
 			Expect(job.ID).To(Equal(job2.ID))
 		})
+
+		It("picks the job that has been waiting the longest", func() {
+			queue.Enqueue(gobble.Job{
+				ActiveAt: time.Now().Add(-1 * time.Minute),
+			})
+			job2, err := queue.Enqueue(gobble.Job{
+				ActiveAt: time.Now().Add(-1 * time.Hour),
+			})
+			if err != nil {
+				panic(err)
+			}
+
+			job := <-queue.Reserve("worker-id")
+
+			Expect(job.ID).To(Equal(job2.ID))
+		})
+
+		It("picks the job with the highest priority", func() {
+			queue.Enqueue(gobble.Job{
+				ActiveAt: time.Now().Add(-1 * time.Hour),
+			})
+			job2, err := queue.Enqueue(gobble.Job{
+				ActiveAt: time.Now().Add(-1 * time.Minute),
+				Priority: 10,
+			})
+			if err != nil {
+				panic(err)
+			}
+
+			job := <-queue.Reserve("worker-id")
+
+			Expect(job.ID).To(Equal(job2.ID))
+		})
+
+		It("claims a batch of jobs for the queue at once", func() {
+			queue = gobble.NewQueue(gobble.Config{
+				WaitMaxDuration: 50 * time.Millisecond,
+				BatchSize:       3,
+			})
+
+			for i := 0; i < 5; i++ {
+				queue.Enqueue(gobble.Job{})
+			}
+
+			job := <-queue.Reserve("worker-id")
+			Expect(job.WorkerID).To(Equal("worker-id"))
+
+			claimed := func() int {
+				results, err := gobble.Database().Connection.Select(gobble.Job{}, "SELECT * FROM `jobs` WHERE `worker_id` = ?", queue.ID)
+				if err != nil {
+					panic(err)
+				}
+				return len(results)
+			}
+
+			Eventually(claimed).Should(Equal(2))
+			Consistently(claimed).Should(Equal(2))
+		})
+
+		It("skips jobs that are locked by another queue", func() {
+			job1, err := queue.Enqueue(gobble.Job{})
+			if err != nil {
+				panic(err)
+			}
+			job2, err := queue.Enqueue(gobble.Job{})
+			if err != nil {
+				panic(err)
+			}
+
+			transaction, err := gobble.Database().Connection.Begin()
+			if err != nil {
+				panic(err)
+			}
+			defer transaction.Rollback()
+
+			_, err = transaction.Exec("SELECT * FROM `jobs` WHERE `id` = ? FOR UPDATE", job1.ID)
+			if err != nil {
+				panic(err)
+			}
+
+			var reservedJob gobble.Job
+			Eventually(queue.Reserve("worker-id")).Should(Receive(&reservedJob))
+			Expect(reservedJob.ID).To(Equal(job2.ID))
+		})
 	})
 
 	Describe("Dequeue", func() {
@@ -204,28 +333,372 @@ var _ = Describe("Queue", func() {
 		})
 	})
 
-	Describe("Unlock", func() {
-		It("clears the workerID values for any jobs in the queue", func() {
-			queue.Enqueue(gobble.Job{})
-			results, err := gobble.Database().Connection.Select(gobble.Job{}, "SELECT * FROM `jobs` WHERE `worker_id` = ''")
+	Describe("Cancel", func() {
+		It("deletes the job with the given reference", func() {
+			queue.Enqueue(gobble.Job{
+				Reference: "message-1",
+				ActiveAt:  time.Now().Add(1 * time.Hour),
+			})
+			other, err := queue.Enqueue(gobble.Job{
+				Reference: "message-2",
+			})
+			if err != nil {
+				panic(err)
+			}
+
+			err = queue.Cancel("message-1")
+			Expect(err).NotTo(HaveOccurred())
+
+			results, err := gobble.Database().Connection.Select(gobble.Job{}, "SELECT * FROM `jobs`")
+			if err != nil {
+				panic(err)
+			}
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].(*gobble.Job).ID).To(Equal(other.ID))
+		})
+
+		It("returns sql.ErrNoRows when there is no job with the given reference", func() {
+			err := queue.Cancel("missing")
+			Expect(err).To(Equal(sql.ErrNoRows))
+		})
+
+		It("deletes a job that the queue has claimed but not handed to a worker", func() {
+			queue = gobble.NewQueue(gobble.Config{
+				WaitMaxDuration: 50 * time.Millisecond,
+				BatchSize:       2,
+			})
+
+			for _, reference := range []string{"message-1", "message-2"} {
+				queue.Enqueue(gobble.Job{
+					Reference: reference,
+				})
+			}
+			<-queue.Reserve("my-worker")
+
+			err := queue.Cancel("message-2")
+			Expect(err).NotTo(HaveOccurred())
+
+			results, err := gobble.Database().Connection.Select(gobble.Job{}, "SELECT * FROM `jobs`")
 			if err != nil {
 				panic(err)
 			}
 			Expect(results).To(HaveLen(1))
+			Expect(results[0].(*gobble.Job).Reference).To(Equal("message-1"))
+		})
 
+		It("returns ErrJobReserved when a worker holds a lease on the job", func() {
+			queue.Enqueue(gobble.Job{
+				Reference: "message-1",
+			})
 			<-queue.Reserve("my-worker")
-			results, err = gobble.Database().Connection.Select(gobble.Job{}, "SELECT * FROM `jobs` WHERE `worker_id` = ''")
+
+			err := queue.Cancel("message-1")
+			Expect(err).To(Equal(gobble.ErrJobReserved))
+		})
+	})
+
+	Describe("Bury", func() {
+		It("moves the job into the dead jobs table", func() {
+			job, err := queue.Enqueue(gobble.Job{
+				Payload:    "the-payload",
+				RetryCount: 10,
+				Priority:   10,
+				Reference:  "message-1",
+				ActiveAt:   time.Now().Add(-1 * time.Minute).Truncate(1 * time.Second),
+			})
+			if err != nil {
+				panic(err)
+			}
+
+			job.Bury("the relay is on fire")
+			queue.Bury(job)
+
+			results, err := gobble.Database().Connection.Select(gobble.Job{}, "SELECT * FROM `jobs`")
 			if err != nil {
 				panic(err)
 			}
 			Expect(results).To(HaveLen(0))
 
-			queue.Unlock()
-			results, err = gobble.Database().Connection.Select(gobble.Job{}, "SELECT * FROM `jobs` WHERE `worker_id` = ''")
+			deadJobs, err := queue.DeadJobs()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deadJobs).To(HaveLen(1))
+
+			deadJob := deadJobs[0]
+			Expect(deadJob.ID).NotTo(BeZero())
+			Expect(deadJob.JobID).To(Equal(job.ID))
+			Expect(deadJob.Payload).To(Equal("the-payload"))
+			Expect(deadJob.RetryCount).To(Equal(10))
+			Expect(deadJob.LastError).To(Equal("the relay is on fire"))
+			Expect(deadJob.Priority).To(Equal(10))
+			Expect(deadJob.Reference).To(Equal("message-1"))
+			Expect(deadJob.LastAttemptAt).To(BeTemporally("~", job.ActiveAt, 1*time.Second))
+			Expect(deadJob.CreatedAt).To(BeTemporally("~", time.Now(), 2*time.Second))
+		})
+	})
+
+	Context("with dead jobs", func() {
+		var deadJob1, deadJob2 gobble.DeadJob
+
+		BeforeEach(func() {
+			for _, payload := range []string{"first", "second"} {
+				job, err := queue.Enqueue(gobble.Job{
+					Payload:   payload,
+					Priority:  10,
+					Reference: "message-" + payload,
+				})
+				if err != nil {
+					panic(err)
+				}
+
+				job.Bury("boom")
+				queue.Bury(job)
+			}
+
+			deadJobs, err := queue.DeadJobs()
 			if err != nil {
 				panic(err)
 			}
-			Expect(results).To(HaveLen(1))
+			Expect(deadJobs).To(HaveLen(2))
+
+			for _, deadJob := range deadJobs {
+				switch deadJob.Payload {
+				case "first":
+					deadJob1 = deadJob
+				case "second":
+					deadJob2 = deadJob
+				}
+			}
+		})
+
+		Describe("FindDeadJob", func() {
+			It("finds the dead job with the given id", func() {
+				deadJob, err := queue.FindDeadJob(deadJob2.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deadJob).To(Equal(deadJob2))
+			})
+
+			It("returns sql.ErrNoRows when the dead job does not exist", func() {
+				_, err := queue.FindDeadJob(deadJob2.ID + 100)
+				Expect(err).To(Equal(sql.ErrNoRows))
+			})
+		})
+
+		Describe("ReplayDeadJob", func() {
+			It("moves the dead job back into the queue with a fresh retry count", func() {
+				job, err := queue.ReplayDeadJob(deadJob1.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(job.ID).NotTo(BeZero())
+				Expect(job.Payload).To(Equal("first"))
+				Expect(job.RetryCount).To(Equal(0))
+				Expect(job.Priority).To(Equal(10))
+				Expect(job.Reference).To(Equal("message-first"))
+
+				_, err = queue.FindDeadJob(deadJob1.ID)
+				Expect(err).To(Equal(sql.ErrNoRows))
+
+				reservedJob := <-queue.Reserve("worker-id")
+				Expect(reservedJob.ID).To(Equal(job.ID))
+				Expect(reservedJob.Payload).To(Equal("first"))
+				Expect(reservedJob.Priority).To(Equal(10))
+				Expect(reservedJob.Reference).To(Equal("message-first"))
+			})
+
+			It("can cancel the replayed job by its reference", func() {
+				_, err := queue.ReplayDeadJob(deadJob1.ID)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = queue.Cancel("message-first")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns sql.ErrNoRows when the dead job does not exist", func() {
+				_, err := queue.ReplayDeadJob(deadJob2.ID + 100)
+				Expect(err).To(Equal(sql.ErrNoRows))
+			})
+		})
+
+		Describe("PurgeDeadJob", func() {
+			It("deletes the dead job with the given id", func() {
+				err := queue.PurgeDeadJob(deadJob1.ID)
+				Expect(err).NotTo(HaveOccurred())
+
+				deadJobs, err := queue.DeadJobs()
+				if err != nil {
+					panic(err)
+				}
+				Expect(deadJobs).To(Equal([]gobble.DeadJob{deadJob2}))
+			})
+
+			It("returns sql.ErrNoRows when the dead job does not exist", func() {
+				err := queue.PurgeDeadJob(deadJob2.ID + 100)
+				Expect(err).To(Equal(sql.ErrNoRows))
+			})
+		})
+
+		Describe("PurgeDeadJobs", func() {
+			It("deletes all of the dead jobs", func() {
+				count, err := queue.PurgeDeadJobs()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(count).To(Equal(2))
+
+				deadJobs, err := queue.DeadJobs()
+				if err != nil {
+					panic(err)
+				}
+				Expect(deadJobs).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("leases", func() {
+		reload := func(job gobble.Job) gobble.Job {
+			reloadedJob := gobble.Job{}
+			err := gobble.Database().Connection.SelectOne(&reloadedJob, "SELECT * FROM `jobs` where id = ?", job.ID)
+			if err != nil {
+				panic(err)
+			}
+			return reloadedJob
+		}
+
+		It("leases the job to the worker that reserved it", func() {
+			queue.Enqueue(gobble.Job{})
+
+			job := <-queue.Reserve("my-worker")
+
+			Expect(reload(job).LockedUntil).To(BeTemporally("~", time.Now().Add(gobble.LeaseDuration), 2*time.Second))
+		})
+
+		It("does not reclaim jobs whose lease has not expired", func() {
+			queue.Enqueue(gobble.Job{})
+			<-queue.Reserve("my-worker")
+
+			Consistently(queue.Reserve("another-worker")).ShouldNot(Receive())
+		})
+
+		It("reclaims jobs whose lease has expired", func() {
+			job, err := queue.Enqueue(gobble.Job{
+				WorkerID:    "crashed-worker",
+				LockedUntil: time.Now().Add(-1 * time.Minute),
+			})
+			if err != nil {
+				panic(err)
+			}
+
+			var reservedJob gobble.Job
+			Eventually(queue.Reserve("my-worker")).Should(Receive(&reservedJob))
+			Expect(reservedJob.ID).To(Equal(job.ID))
+			Expect(reservedJob.WorkerID).To(Equal("my-worker"))
+		})
+
+		It("ignores a job that was reclaimed by another worker when dequeuing it", func() {
+			job, err := queue.Enqueue(gobble.Job{
+				WorkerID:    "slow-worker",
+				LockedUntil: time.Now().Add(-1 * time.Minute),
+			})
+			if err != nil {
+				panic(err)
+			}
+
+			<-queue.Reserve("my-worker")
+
+			Expect(func() {
+				queue.Dequeue(job)
+			}).NotTo(Panic())
+			Expect(reload(job).WorkerID).To(Equal("my-worker"))
+		})
+
+		Describe("KeepAlive", func() {
+			It("renews the lease until it is told to stop", func() {
+				queue = gobble.NewQueue(gobble.Config{
+					WaitMaxDuration: 50 * time.Millisecond,
+					LeaseDuration:   3 * time.Second,
+				})
+
+				queue.Enqueue(gobble.Job{})
+				job := <-queue.Reserve("my-worker")
+				lockedUntil := reload(job).LockedUntil
+
+				done := make(chan bool)
+				go queue.KeepAlive(job, done)
+
+				Eventually(func() time.Time {
+					return reload(job).LockedUntil
+				}, 3*time.Second).Should(BeTemporally(">", lockedUntil))
+
+				close(done)
+			})
+
+			It("does not renew the lease of a job that was reclaimed by another worker", func() {
+				queue = gobble.NewQueue(gobble.Config{
+					WaitMaxDuration: 50 * time.Millisecond,
+					LeaseDuration:   3 * time.Second,
+				})
+
+				job, err := queue.Enqueue(gobble.Job{
+					WorkerID:    "slow-worker",
+					LockedUntil: time.Now().Add(-1 * time.Minute),
+				})
+				if err != nil {
+					panic(err)
+				}
+				<-queue.Reserve("my-worker")
+				lockedUntil := reload(job).LockedUntil
+
+				done := make(chan bool)
+				go queue.KeepAlive(job, done)
+
+				Consistently(func() time.Time {
+					return reload(job).LockedUntil
+				}, 2*time.Second).Should(Equal(lockedUntil))
+
+				close(done)
+			})
+		})
+	})
+
+	Describe("Close", func() {
+		It("releases a job that was reserved but never received by a worker", func() {
+			job, err := queue.Enqueue(gobble.Job{})
+			if err != nil {
+				panic(err)
+			}
+
+			queue.Reserve("my-worker")
+
+			workerID := func() string {
+				reloadedJob := gobble.Job{}
+				err := gobble.Database().Connection.SelectOne(&reloadedJob, "SELECT * FROM `jobs` where id = ?", job.ID)
+				if err != nil {
+					panic(err)
+				}
+				return reloadedJob.WorkerID
+			}
+
+			Eventually(workerID).Should(Equal("my-worker"))
+
+			queue.Close()
+
+			Eventually(workerID).Should(Equal(""))
+		})
+
+		It("stops reserving jobs", func() {
+			channel := queue.Reserve("my-worker")
+
+			queue.Close()
+
+			job, err := queue.Enqueue(gobble.Job{})
+			if err != nil {
+				panic(err)
+			}
+
+			Consistently(channel).ShouldNot(Receive())
+
+			reloadedJob := gobble.Job{}
+			err = gobble.Database().Connection.SelectOne(&reloadedJob, "SELECT * FROM `jobs` where id = ?", job.ID)
+			if err != nil {
+				panic(err)
+			}
+			Expect(reloadedJob.WorkerID).To(Equal(""))
 		})
 	})
 })
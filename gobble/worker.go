@@ -24,10 +24,16 @@ func NewWorker(id int, queue QueueInterface, callback func(*Job)) Worker {
 func (worker *Worker) Perform() int {
 	select {
 	case job := <-worker.queue.Reserve(worker.ID):
+		done := make(chan bool)
+		go worker.queue.KeepAlive(job, done)
+
 		worker.callback(&job)
+		close(done)
 
 		if job.ShouldRetry {
 			worker.queue.Requeue(job)
+		} else if job.ShouldBury {
+			worker.queue.Bury(job)
 		} else {
 			worker.queue.Dequeue(job)
 		}
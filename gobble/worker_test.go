@@ -75,6 +75,68 @@ var _ = Describe("Worker", func() {
 			Expect(retriedJob.RetryCount).To(Equal(1))
 			Expect(retriedJob.ActiveAt).To(BeTemporally("~", time.Now().Add(1*time.Minute), 1*time.Minute))
 		})
+
+		It("moves jobs that are marked for burial into the dead jobs table", func() {
+			callback = func(job *gobble.Job) {
+				job.Bury("it went badly")
+			}
+			worker = gobble.NewWorker(1, queue, callback)
+
+			job, err := queue.Enqueue(gobble.Job{
+				Payload: "the-payload",
+			})
+			if err != nil {
+				panic(err)
+			}
+
+			worker.Perform()
+
+			results, err := gobble.Database().Connection.Select(gobble.Job{}, "SELECT * FROM `jobs`")
+			if err != nil {
+				panic(err)
+			}
+			Expect(len(results)).To(Equal(0))
+
+			deadJobs, err := queue.DeadJobs()
+			if err != nil {
+				panic(err)
+			}
+			Expect(deadJobs).To(HaveLen(1))
+			Expect(deadJobs[0].JobID).To(Equal(job.ID))
+			Expect(deadJobs[0].Payload).To(Equal("the-payload"))
+			Expect(deadJobs[0].LastError).To(Equal("it went badly"))
+		})
+
+		It("keeps the lease on the job alive while the callback runs", func() {
+			queue = gobble.NewQueue(gobble.Config{
+				LeaseDuration: 300 * time.Millisecond,
+			})
+
+			var leasedUntil, renewedUntil time.Time
+			callback = func(job *gobble.Job) {
+				reloadedJob := gobble.Job{}
+				err := gobble.Database().Connection.SelectOne(&reloadedJob, "SELECT * FROM `jobs` where id = ?", job.ID)
+				if err != nil {
+					panic(err)
+				}
+				leasedUntil = reloadedJob.LockedUntil
+
+				time.Sleep(1500 * time.Millisecond)
+
+				err = gobble.Database().Connection.SelectOne(&reloadedJob, "SELECT * FROM `jobs` where id = ?", job.ID)
+				if err != nil {
+					panic(err)
+				}
+				renewedUntil = reloadedJob.LockedUntil
+			}
+			worker = gobble.NewWorker(1, queue, callback)
+
+			queue.Enqueue(gobble.Job{})
+
+			worker.Perform()
+
+			Expect(renewedUntil).To(BeTemporally(">", leasedUntil))
+		})
 	})
 
 	Describe("Work", func() {
@@ -0,0 +1,99 @@
+package mail
+
+import (
+	"errors"
+	"fmt"
+	"net/smtp"
+	"strings"
+)
+
+// TokenSource hands out OAuth2 bearer tokens for XOAUTH2 authentication.
+type TokenSource interface {
+	Token() (string, error)
+}
+
+type loginAuth struct {
+	username string
+	password string
+	host     string
+}
+
+// LoginAuth returns an smtp.Auth that implements the LOGIN mechanism, which
+// sends the username and password in answer to the server's prompts. Like
+// smtp.PlainAuth it refuses to send credentials over an unencrypted
+// connection to anything other than localhost.
+func LoginAuth(username, password, host string) smtp.Auth {
+	return loginAuth{
+		username: username,
+		password: password,
+		host:     host,
+	}
+}
+
+func (auth loginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if !server.TLS && !isLocalhost(server.Name) {
+		return "", nil, errors.New("unencrypted connection")
+	}
+
+	if server.Name != auth.host {
+		return "", nil, errors.New("wrong host name")
+	}
+
+	return "LOGIN", nil, nil
+}
+
+func (auth loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+
+	prompt := strings.ToLower(strings.TrimSpace(string(fromServer)))
+	switch {
+	case strings.HasPrefix(prompt, "username"):
+		return []byte(auth.username), nil
+	case strings.HasPrefix(prompt, "password"):
+		return []byte(auth.password), nil
+	default:
+		return nil, fmt.Errorf("unexpected LOGIN prompt %q", fromServer)
+	}
+}
+
+type xoauth2Auth struct {
+	username string
+	tokens   TokenSource
+}
+
+// XOAUTH2Auth returns an smtp.Auth that authenticates with a bearer token
+// taken from the TokenSource each time a session is opened.
+func XOAUTH2Auth(username string, tokens TokenSource) smtp.Auth {
+	return xoauth2Auth{
+		username: username,
+		tokens:   tokens,
+	}
+}
+
+func (auth xoauth2Auth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	token, err := auth.tokens.Token()
+	if err != nil {
+		return "", nil, err
+	}
+
+	response := fmt.Sprintf("user=%s\x01auth=Bearer %s\x01\x01", auth.username, token)
+
+	return "XOAUTH2", []byte(response), nil
+}
+
+// Next answers the error challenge that the server sends when it rejects the
+// token with an empty response, so that the server replies with its final
+// error code.
+func (auth xoauth2Auth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if more {
+		return []byte{}, nil
+	}
+
+	return nil, nil
+}
+
+func isLocalhost(name string) bool {
+	return name == "localhost" || name == "127.0.0.1" || name == "::1"
+}
@@ -0,0 +1,90 @@
+package mail_test
+
+import (
+	"errors"
+	"net/smtp"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type fakeTokenSource struct {
+	token string
+	err   error
+}
+
+func (source fakeTokenSource) Token() (string, error) {
+	return source.token, source.err
+}
+
+var _ = Describe("Auth", func() {
+	Describe("LoginAuth", func() {
+		var auth smtp.Auth
+
+		BeforeEach(func() {
+			auth = mail.LoginAuth("user", "pass", "smtp.example.com")
+		})
+
+		It("answers the username and password prompts", func() {
+			mechanism, response, err := auth.Start(&smtp.ServerInfo{Name: "smtp.example.com", TLS: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mechanism).To(Equal("LOGIN"))
+			Expect(response).To(BeNil())
+
+			response, err = auth.Next([]byte("Username:"), true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(response)).To(Equal("user"))
+
+			response, err = auth.Next([]byte("Password:"), true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(response)).To(Equal("pass"))
+
+			response, err = auth.Next(nil, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(BeNil())
+		})
+
+		It("returns an error for an unexpected prompt", func() {
+			_, err := auth.Next([]byte("Favourite colour:"), true)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("refuses to send credentials over an unencrypted connection", func() {
+			_, _, err := auth.Start(&smtp.ServerInfo{Name: "smtp.example.com", TLS: false})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("refuses to send credentials to a different host", func() {
+			_, _, err := auth.Start(&smtp.ServerInfo{Name: "evil.example.com", TLS: true})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("XOAUTH2Auth", func() {
+		It("sends the username and bearer token", func() {
+			auth := mail.XOAUTH2Auth("user@example.com", fakeTokenSource{token: "the-token"})
+
+			mechanism, response, err := auth.Start(&smtp.ServerInfo{Name: "smtp.example.com", TLS: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mechanism).To(Equal("XOAUTH2"))
+			Expect(string(response)).To(Equal("user=user@example.com\x01auth=Bearer the-token\x01\x01"))
+		})
+
+		It("answers an error challenge with an empty response", func() {
+			auth := mail.XOAUTH2Auth("user@example.com", fakeTokenSource{token: "the-token"})
+
+			response, err := auth.Next([]byte(`{"status":"401"}`), true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal([]byte{}))
+		})
+
+		It("returns an error when a token cannot be loaded", func() {
+			auth := mail.XOAUTH2Auth("user@example.com", fakeTokenSource{err: errors.New("token endpoint is down")})
+
+			_, _, err := auth.Start(&smtp.ServerInfo{Name: "smtp.example.com", TLS: true})
+			Expect(err).To(Equal(errors.New("token endpoint is down")))
+		})
+	})
+})
@@ -0,0 +1,92 @@
+package mail
+
+import (
+	"sync"
+	"time"
+)
+
+type CircuitBreakerConfig struct {
+	Threshold int
+	Cooldown  time.Duration
+}
+
+// CircuitBreaker stops calls to a relay once it has failed Threshold times in
+// a row. After the cooldown a single trial call is let through: if it
+// succeeds the breaker closes again, and if it fails the cooldown restarts.
+type CircuitBreaker struct {
+	config   CircuitBreakerConfig
+	mutex    *sync.Mutex
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+func NewCircuitBreaker(config CircuitBreakerConfig) *CircuitBreaker {
+	if config.Threshold == 0 {
+		config.Threshold = 3
+	}
+
+	if config.Cooldown == 0 {
+		config.Cooldown = 30 * time.Second
+	}
+
+	return &CircuitBreaker{
+		config: config,
+		mutex:  &sync.Mutex{},
+	}
+}
+
+// Allow reports whether a call may be made.
+func (breaker *CircuitBreaker) Allow() bool {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	if breaker.failures < breaker.config.Threshold {
+		return true
+	}
+
+	if !breaker.trial && time.Since(breaker.openedAt) >= breaker.config.Cooldown {
+		breaker.trial = true
+		return true
+	}
+
+	return false
+}
+
+func (breaker *CircuitBreaker) Success() {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	breaker.failures = 0
+	breaker.trial = false
+}
+
+func (breaker *CircuitBreaker) Failure() {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	breaker.failures++
+	breaker.trial = false
+	if breaker.failures >= breaker.config.Threshold {
+		breaker.openedAt = time.Now()
+	}
+}
+
+// Trip opens the breaker at once, as though the threshold had been reached.
+func (breaker *CircuitBreaker) Trip() {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	if breaker.failures < breaker.config.Threshold {
+		breaker.failures = breaker.config.Threshold
+	}
+	breaker.trial = false
+	breaker.openedAt = time.Now()
+}
+
+func (breaker *CircuitBreaker) IsOpen() bool {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	return breaker.failures >= breaker.config.Threshold
+}
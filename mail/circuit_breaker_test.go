@@ -0,0 +1,90 @@
+package mail_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CircuitBreaker", func() {
+	var breaker *mail.CircuitBreaker
+
+	BeforeEach(func() {
+		breaker = mail.NewCircuitBreaker(mail.CircuitBreakerConfig{
+			Threshold: 2,
+			Cooldown:  20 * time.Millisecond,
+		})
+	})
+
+	It("allows calls until the threshold of consecutive failures is reached", func() {
+		Expect(breaker.Allow()).To(BeTrue())
+		breaker.Failure()
+		Expect(breaker.Allow()).To(BeTrue())
+		breaker.Failure()
+
+		Expect(breaker.IsOpen()).To(BeTrue())
+		Expect(breaker.Allow()).To(BeFalse())
+	})
+
+	It("forgets earlier failures after a success", func() {
+		breaker.Failure()
+		breaker.Success()
+		breaker.Failure()
+
+		Expect(breaker.IsOpen()).To(BeFalse())
+		Expect(breaker.Allow()).To(BeTrue())
+	})
+
+	It("opens at once when tripped", func() {
+		breaker.Trip()
+
+		Expect(breaker.IsOpen()).To(BeTrue())
+		Expect(breaker.Allow()).To(BeFalse())
+
+		time.Sleep(30 * time.Millisecond)
+		Expect(breaker.Allow()).To(BeTrue())
+	})
+
+	Context("when the cooldown has passed", func() {
+		BeforeEach(func() {
+			breaker.Failure()
+			breaker.Failure()
+			time.Sleep(30 * time.Millisecond)
+		})
+
+		It("lets a single trial call through", func() {
+			Expect(breaker.Allow()).To(BeTrue())
+			Expect(breaker.Allow()).To(BeFalse())
+		})
+
+		It("closes when the trial call succeeds", func() {
+			breaker.Allow()
+			breaker.Success()
+
+			Expect(breaker.IsOpen()).To(BeFalse())
+			Expect(breaker.Allow()).To(BeTrue())
+		})
+
+		It("opens for another cooldown when the trial call fails", func() {
+			breaker.Allow()
+			breaker.Failure()
+
+			Expect(breaker.Allow()).To(BeFalse())
+			time.Sleep(30 * time.Millisecond)
+			Expect(breaker.Allow()).To(BeTrue())
+		})
+	})
+
+	It("defaults to opening after three failures for thirty seconds", func() {
+		breaker = mail.NewCircuitBreaker(mail.CircuitBreakerConfig{})
+
+		breaker.Failure()
+		breaker.Failure()
+		Expect(breaker.Allow()).To(BeTrue())
+		breaker.Failure()
+		Expect(breaker.Allow()).To(BeFalse())
+	})
+})
@@ -2,9 +2,9 @@ package mail
 
 import (
 	"crypto/tls"
-	"encoding/base64"
+	"crypto/x509"
 	"errors"
-	"fmt"
+	"io"
 	"log"
 	"net"
 	"net/smtp"
@@ -16,10 +16,24 @@ const (
 	AuthNone AuthMechanism = iota
 	AuthPlain
 	AuthCRAMMD5
+	AuthLogin
+	AuthXOAUTH2
 )
 
 type AuthMechanism int
 
+const (
+	TLSModeStartTLS TLSMode = iota
+	TLSModeNone
+	TLSModeImplicit
+)
+
+// TLSMode decides how a connection to the SMTP server is secured. With
+// STARTTLS the connection is upgraded after the greeting if the server offers
+// it, while with implicit TLS (SMTPS, usually on port 465) the handshake
+// happens before the server says anything.
+type TLSMode int
+
 type Client struct {
 	config Config
 	client *smtp.Client
@@ -33,16 +47,28 @@ type Config struct {
 	Pass           string
 	Secret         string
 	AuthMechanism  AuthMechanism
+	TokenSource    TokenSource
+	DKIM           *DKIMSigner
+	Pool           PoolConfig
+	Relays         []Relay
+	CircuitBreaker CircuitBreakerConfig
+	HTTP           HTTPTransportConfig
+	SendmailPath   string
+	File           FileTransportConfig
 	TestMode       bool
 	SkipVerifySSL  bool
-	DisableTLS     bool
+	TLSMode        TLSMode
+	Certificates   []tls.Certificate
+	RootCAs        *x509.CertPool
 	ConnectTimeout time.Duration
 	LoggingEnabled bool
 }
 
+// ClientInterface is implemented by every mail transport.
 type ClientInterface interface {
 	Connect() error
 	Send(Message) error
+	Close()
 }
 
 type connection struct {
@@ -95,7 +121,16 @@ func (c *Client) connect() chan connection {
 	channel := make(chan connection)
 
 	go func() {
-		client, err := smtp.Dial(net.JoinHostPort(c.config.Host, c.config.Port))
+		address := net.JoinHostPort(c.config.Host, c.config.Port)
+
+		var client *smtp.Client
+		var err error
+		if c.config.TLSMode == TLSModeImplicit {
+			client, err = c.dialTLS(address)
+		} else {
+			client, err = smtp.Dial(address)
+		}
+
 		channel <- connection{
 			client: client,
 			err:    err,
@@ -111,6 +146,31 @@ func (c *Client) Send(msg Message) error {
 		return nil
 	}
 
+	err := c.Open()
+	if err != nil {
+		return err
+	}
+
+	err = c.Deliver(msg)
+	if err != nil {
+		c.Close()
+		return err
+	}
+
+	c.PrintLog("Quitting...")
+	err = c.Quit()
+	if err != nil {
+		return c.Error(err)
+	}
+	c.PrintLog("Goodbye.")
+
+	return nil
+}
+
+// Open connects to the server and says hello, starting TLS and
+// authenticating unless TLS is disabled. The session is left open so that
+// any number of messages can be delivered over it.
+func (c *Client) Open() error {
 	err := c.Connect()
 	if err != nil {
 		return c.Error(err)
@@ -123,13 +183,15 @@ func (c *Client) Send(msg Message) error {
 	}
 	c.PrintLog("Hello complete.")
 
-	if !c.config.DisableTLS {
-		c.PrintLog("Starting TLS...")
-		err = c.StartTLS()
-		if err != nil {
-			return c.Error(err)
+	if c.config.TLSMode != TLSModeNone {
+		if c.config.TLSMode == TLSModeStartTLS {
+			c.PrintLog("Starting TLS...")
+			err = c.StartTLS()
+			if err != nil {
+				return c.Error(err)
+			}
+			c.PrintLog("TLS connection opened.")
 		}
-		c.PrintLog("TLS connection opened.")
 
 		c.PrintLog("Starting authentication...")
 		err = c.Auth()
@@ -139,40 +201,62 @@ func (c *Client) Send(msg Message) error {
 		c.PrintLog("Authenticated.")
 	}
 
+	return nil
+}
+
+// Deliver sends a single message over a session that has been opened. When
+// the server permanently rejects the recipient or the message, the session is
+// left open so that it can be reset and used for the next message. It is
+// closed on any other failure.
+func (c *Client) Deliver(msg Message) error {
 	c.PrintLog("Sending mail from: %s", msg.From)
-	err = c.client.Mail(msg.From)
+	err := c.client.Mail(msg.From)
 	if err != nil {
-		return c.Error(err)
+		return c.Error(NewSMTPError("MAIL", err))
 	}
 
 	c.PrintLog("Sending mail to: %s", msg.To)
 	err = c.client.Rcpt(msg.To)
 	if err != nil {
-		return c.Error(err)
+		return c.rejected(NewSMTPError("RCPT", err))
 	}
 
 	c.PrintLog("Sending mail data...")
-	c.PrintLog("Message Data: %s", base64.StdEncoding.EncodeToString([]byte(msg.Data())))
 	err = c.Data(msg)
 	if err != nil {
-		return c.Error(err)
+		return c.rejected(NewSMTPError("DATA", err))
 	}
 	c.PrintLog("Mail data sent.")
 
-	c.PrintLog("Quitting...")
-	err = c.Quit()
-	if err != nil {
-		return c.Error(err)
+	return nil
+}
+
+// rejected keeps the session open if the server only refused the message
+// that was being delivered.
+func (c *Client) rejected(err error) error {
+	if undeliverable(err) {
+		c.logger.Printf("SMTP Error: %s", err.Error())
+		return err
 	}
-	c.PrintLog("Goodbye.")
 
-	return nil
+	return c.Error(err)
+}
+
+// Reset aborts the current mail transaction so that the session can be used
+// to deliver another message.
+func (c *Client) Reset() error {
+	return c.client.Reset()
+}
+
+// Noop checks that the server is still answering on an open session.
+func (c *Client) Noop() error {
+	return c.client.Noop()
 }
 
 func (c *Client) Hello() error {
 	err := c.client.Hello("localhost")
 	if err != nil {
-		return err
+		return NewSMTPError("EHLO", err)
 	}
 
 	return nil
@@ -184,24 +268,45 @@ func (c *Client) Extension(name string) (bool, string) {
 
 func (c *Client) StartTLS() error {
 	if ok, _ := c.Extension("STARTTLS"); ok {
-		err := c.client.StartTLS(&tls.Config{
-			ServerName:         c.config.Host,
-			InsecureSkipVerify: c.config.SkipVerifySSL,
-		})
+		err := c.client.StartTLS(c.tlsConfig())
 		if err != nil {
-			return err
+			return NewSMTPError("STARTTLS", err)
 		}
 	}
 
 	return nil
 }
 
+func (c *Client) dialTLS(address string) (*smtp.Client, error) {
+	conn, err := tls.Dial("tcp", address, c.tlsConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := smtp.NewClient(conn, c.config.Host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (c *Client) tlsConfig() *tls.Config {
+	return &tls.Config{
+		ServerName:         c.config.Host,
+		InsecureSkipVerify: c.config.SkipVerifySSL,
+		Certificates:       c.config.Certificates,
+		RootCAs:            c.config.RootCAs,
+	}
+}
+
 func (c *Client) Auth() error {
 	if ok, _ := c.Extension("AUTH"); ok {
 		if mechanism := c.AuthMechanism(); mechanism != nil {
 			err := c.client.Auth(mechanism)
 			if err != nil {
-				return err
+				return NewSMTPError("AUTH", err)
 			}
 		}
 	}
@@ -217,6 +322,12 @@ func (c *Client) AuthMechanism() smtp.Auth {
 	case AuthPlain:
 		c.PrintLog("Using PLAIN to authenticate")
 		return smtp.PlainAuth("", c.config.User, c.config.Pass, c.config.Host)
+	case AuthLogin:
+		c.PrintLog("Using LOGIN to authenticate")
+		return LoginAuth(c.config.User, c.config.Pass, c.config.Host)
+	case AuthXOAUTH2:
+		c.PrintLog("Using XOAUTH2 to authenticate")
+		return XOAUTH2Auth(c.config.User, c.config.TokenSource)
 	default:
 		c.PrintLog("No authentication mechanism configured")
 		return nil
@@ -224,13 +335,21 @@ func (c *Client) AuthMechanism() smtp.Auth {
 }
 
 func (c *Client) Data(msg Message) error {
+	var data io.WriterTo = msg
+	if c.config.DKIM != nil {
+		signed, err := c.config.DKIM.Sign(msg.Data())
+		if err != nil {
+			return err
+		}
+		data = strings.NewReader(signed)
+	}
+
 	wc, err := c.client.Data()
 	if err != nil {
 		return err
 	}
 
-	data := strings.Replace(string(msg.Data()), "%", "%%", -1)
-	_, err = fmt.Fprintf(wc, data)
+	_, err = data.WriteTo(wc)
 	if err != nil {
 		return err
 	}
@@ -254,12 +373,28 @@ func (c *Client) Quit() error {
 	return nil
 }
 
+// Close ends the session, dropping the connection if the server does not
+// answer the QUIT.
+func (c *Client) Close() {
+	if c.client == nil {
+		return
+	}
+
+	err := c.client.Quit()
+	if err != nil {
+		c.client.Close()
+	}
+	c.client = nil
+}
+
 func (c *Client) Error(err error) error {
 	if c.client != nil {
 		failure := c.client.Quit()
 		if failure != nil {
-			return failure
+			c.logger.Printf("SMTP Error: %s", failure.Error())
+			c.client.Close()
 		}
+		c.client = nil
 	}
 
 	c.logger.Printf("SMTP Error: %s", err.Error())
@@ -2,6 +2,10 @@ package mail_test
 
 import (
 	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"log"
 	"net"
@@ -15,6 +19,19 @@ import (
 	. "github.com/onsi/gomega"
 )
 
+var messageDate = time.Date(2015, time.January, 20, 20, 23, 38, 0, time.UTC)
+
+// dataLines splits a message into lines the way the test SMTP server
+// records them.
+func dataLines(msg mail.Message) []string {
+	lines := strings.Split(strings.TrimSuffix(msg.Data(), "\r\n"), "\r\n")
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+
+	return lines
+}
+
 var _ = Describe("Mail", func() {
 	var mailServer *SMTPServer
 	var client *mail.Client
@@ -34,7 +51,6 @@ var _ = Describe("Mail", func() {
 			Pass:          "pass",
 			TestMode:      false,
 			SkipVerifySSL: true,
-			DisableTLS:    false,
 		}
 
 		config.Host, config.Port, err = net.SplitHostPort(mailServer.URL.String())
@@ -143,6 +159,8 @@ var _ = Describe("Mail", func() {
 
 		It("can send mail", func() {
 			msg := mail.Message{
+				ID:      "message-123",
+				Date:    messageDate,
 				From:    "me@example.com",
 				To:      "you@example.com",
 				Subject: "Urgent! Read now!",
@@ -166,12 +184,79 @@ var _ = Describe("Mail", func() {
 
 			Expect(delivery.Sender).To(Equal("me@example.com"))
 			Expect(delivery.Recipient).To(Equal("you@example.com"))
-			Expect(delivery.Data).To(Equal(strings.Split(msg.Data(), "\n")))
+			Expect(delivery.Data).To(Equal(dataLines(msg)))
 			Expect(delivery.UsedTLS).To(BeTrue())
 		})
 
+		It("does not log the message itself", func() {
+			config.LoggingEnabled = true
+			client, err := mail.NewClient(config, logger)
+			if err != nil {
+				panic(err)
+			}
+
+			err = client.Send(mail.Message{
+				From:    "me@example.com",
+				To:      "you@example.com",
+				Subject: "Your password",
+				Body: []mail.Part{
+					{
+						ContentType: "text/plain",
+						Content:     "Your new password is hunter2",
+					},
+				},
+			})
+			if err != nil {
+				panic(err)
+			}
+
+			Expect(buffer.String()).To(ContainSubstring("[SMTP] Sending mail data...\n[SMTP] Mail data sent.\n"))
+		})
+
+		It("signs the message when DKIM is configured", func() {
+			key, err := rsa.GenerateKey(rand.Reader, 2048)
+			if err != nil {
+				panic(err)
+			}
+
+			config.DKIM = mail.NewDKIMSigner([]mail.DKIMKey{
+				{Domain: "example.com", Selector: "notifications", Signer: key},
+			})
+			client, err = mail.NewClient(config, logger)
+			if err != nil {
+				panic(err)
+			}
+
+			msg := mail.Message{
+				From:    "me@example.com",
+				To:      "you@example.com",
+				Subject: "Urgent! Read now!",
+				Body: []mail.Part{
+					{
+						ContentType: "text/plain",
+						Content:     "This email is the most important thing you will read all day!",
+					},
+				},
+			}
+
+			err = client.Send(msg)
+			if err != nil {
+				panic(err)
+			}
+
+			Eventually(func() int {
+				return len(mailServer.Deliveries)
+			}).Should(Equal(1))
+
+			delivery := mailServer.Deliveries[0]
+			Expect(delivery.Data[0]).To(Equal("DKIM-Signature: v=1; a=rsa-sha256; c=relaxed/relaxed; d=example.com; s=notifications;"))
+			Expect(delivery.Data).To(ContainElement("From: me@example.com"))
+		})
+
 		It("can make multiple requests", func() {
 			firstMsg := mail.Message{
+				ID:      "message-123",
+				Date:    messageDate,
 				From:    "me@example.com",
 				To:      "you@example.com",
 				Subject: "Urgent! Read now!",
@@ -195,9 +280,11 @@ var _ = Describe("Mail", func() {
 
 			Expect(delivery.Sender).To(Equal("me@example.com"))
 			Expect(delivery.Recipient).To(Equal("you@example.com"))
-			Expect(delivery.Data).To(Equal(strings.Split(firstMsg.Data(), "\n")))
+			Expect(delivery.Data).To(Equal(dataLines(firstMsg)))
 
 			secondMsg := mail.Message{
+				ID:      "message-123",
+				Date:    messageDate,
 				From:    "first@example.com",
 				To:      "second@example.com",
 				Subject: "Boring. Do not read.",
@@ -221,7 +308,55 @@ var _ = Describe("Mail", func() {
 
 			Expect(delivery.Sender).To(Equal("first@example.com"))
 			Expect(delivery.Recipient).To(Equal("second@example.com"))
-			Expect(delivery.Data).To(Equal(strings.Split(secondMsg.Data(), "\n")))
+			Expect(delivery.Data).To(Equal(dataLines(secondMsg)))
+		})
+
+		Context("when the server rejects the recipient", func() {
+			var msg mail.Message
+
+			BeforeEach(func() {
+				msg = mail.Message{
+					From:    "me@example.com",
+					To:      "nobody@example.com",
+					Subject: "Urgent! Read now!",
+					Body: []mail.Part{
+						{
+							ContentType: "text/plain",
+							Content:     "This email is the most important thing you will read all day!",
+						},
+					},
+				}
+			})
+
+			It("returns an SMTP error carrying the reply code", func() {
+				mailServer.RcptReply = "550 5.1.1 mailbox does not exist"
+
+				err := client.Send(msg)
+				Expect(err).To(Equal(mail.SMTPError{
+					Command: "RCPT",
+					Code:    550,
+					Message: "5.1.1 mailbox does not exist",
+				}))
+				Expect(err.(mail.SMTPError).Undeliverable()).To(BeTrue())
+			})
+
+			It("returns a transient SMTP error for 4xx replies", func() {
+				mailServer.RcptReply = "451 4.3.0 try again later"
+
+				err := client.Send(msg)
+				Expect(err).To(BeAssignableToTypeOf(mail.SMTPError{}))
+				Expect(err.(mail.SMTPError).Code).To(Equal(451))
+				Expect(err.(mail.SMTPError).Undeliverable()).To(BeFalse())
+			})
+
+			It("reconnects for the next message", func() {
+				mailServer.RcptReply = "550 5.1.1 mailbox does not exist"
+				client.Send(msg)
+
+				mailServer.RcptReply = ""
+				err := client.Send(msg)
+				Expect(err).NotTo(HaveOccurred())
+			})
 		})
 
 		Context("when configured to use TLS", func() {
@@ -237,6 +372,8 @@ var _ = Describe("Mail", func() {
 
 			It("communicates over TLS", func() {
 				msg := mail.Message{
+					ID:      "message-123",
+					Date:    messageDate,
 					From:    "me@example.com",
 					To:      "you@example.com",
 					Subject: "Urgent! Read now!",
@@ -260,8 +397,91 @@ var _ = Describe("Mail", func() {
 
 				Expect(delivery.Sender).To(Equal("me@example.com"))
 				Expect(delivery.Recipient).To(Equal("you@example.com"))
-				Expect(delivery.Data).To(Equal(strings.Split(msg.Data(), "\n")))
+				Expect(delivery.Data).To(Equal(dataLines(msg)))
+				Expect(delivery.UsedTLS).To(BeTrue())
+			})
+		})
+
+		Context("when configured to use implicit TLS", func() {
+			var msg mail.Message
+
+			BeforeEach(func() {
+				mailServer.ImplicitTLS = true
+				config.TLSMode = mail.TLSModeImplicit
+
+				msg = mail.Message{
+					From:    "me@example.com",
+					To:      "you@example.com",
+					Subject: "Urgent! Read now!",
+					Body: []mail.Part{
+						{
+							ContentType: "text/plain",
+							Content:     "This email is the most important thing you will read all day!",
+						},
+					},
+				}
+			})
+
+			It("communicates over TLS from the start of the connection", func() {
+				var err error
+
+				client, err = mail.NewClient(config, logger)
+				if err != nil {
+					panic(err)
+				}
+
+				err = client.Send(msg)
+				if err != nil {
+					panic(err)
+				}
+
+				Eventually(func() int {
+					return len(mailServer.Deliveries)
+				}).Should(Equal(1))
+				delivery := mailServer.Deliveries[0]
+
+				Expect(delivery.Recipient).To(Equal("you@example.com"))
 				Expect(delivery.UsedTLS).To(BeTrue())
+				Expect(delivery.ClientCertificates).To(Equal(0))
+			})
+
+			It("presents the configured client certificate", func() {
+				certificate, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+				if err != nil {
+					panic(err)
+				}
+				config.Certificates = []tls.Certificate{certificate}
+
+				client, err = mail.NewClient(config, logger)
+				if err != nil {
+					panic(err)
+				}
+
+				err = client.Send(msg)
+				if err != nil {
+					panic(err)
+				}
+
+				Eventually(func() int {
+					return len(mailServer.Deliveries)
+				}).Should(Equal(1))
+				Expect(mailServer.Deliveries[0].ClientCertificates).To(Equal(1))
+			})
+
+			It("verifies the server against the configured CA bundle", func() {
+				var err error
+
+				config.SkipVerifySSL = false
+				config.RootCAs = x509.NewCertPool()
+
+				client, err = mail.NewClient(config, logger)
+				if err != nil {
+					panic(err)
+				}
+
+				err = client.Send(msg)
+				Expect(err).To(HaveOccurred())
+				Expect(mailServer.Deliveries).To(BeEmpty())
 			})
 		})
 
@@ -270,7 +490,7 @@ var _ = Describe("Mail", func() {
 				var err error
 
 				mailServer.SupportsTLS = false
-				config.DisableTLS = true
+				config.TLSMode = mail.TLSModeNone
 				client, err = mail.NewClient(config, logger)
 				if err != nil {
 					panic(err)
@@ -279,6 +499,8 @@ var _ = Describe("Mail", func() {
 
 			It("does not authenticate", func() {
 				msg := mail.Message{
+					ID:      "message-123",
+					Date:    messageDate,
 					From:    "me@example.com",
 					To:      "you@example.com",
 					Subject: "Urgent! Read now!",
@@ -302,7 +524,7 @@ var _ = Describe("Mail", func() {
 
 				Expect(delivery.Sender).To(Equal("me@example.com"))
 				Expect(delivery.Recipient).To(Equal("you@example.com"))
-				Expect(delivery.Data).To(Equal(strings.Split(msg.Data(), "\n")))
+				Expect(delivery.Data).To(Equal(dataLines(msg)))
 				Expect(delivery.UsedTLS).To(BeFalse())
 			})
 		})
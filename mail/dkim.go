@@ -0,0 +1,267 @@
+package mail
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	netmail "net/mail"
+	"strings"
+	"time"
+)
+
+// DKIMSignedHeaders are the headers covered by a DKIM signature, when they
+// are present in the message.
+var DKIMSignedHeaders = []string{
+	"From",
+	"Reply-To",
+	"To",
+	"Subject",
+	"Date",
+	"Mime-Version",
+	"Content-Type",
+	"Content-Transfer-Encoding",
+	"X-CF-Client-ID",
+	"X-CF-Notification-ID",
+}
+
+type DKIMKey struct {
+	Domain   string
+	Selector string
+	Signer   crypto.Signer
+}
+
+// DKIMSigner adds a DKIM-Signature header to outgoing messages, using the key
+// configured for the domain of the From address. Both the headers and the
+// body are canonicalized with the "relaxed" algorithm.
+type DKIMSigner struct {
+	keys map[string]DKIMKey
+}
+
+func NewDKIMSigner(keys []DKIMKey) *DKIMSigner {
+	signer := &DKIMSigner{
+		keys: map[string]DKIMKey{},
+	}
+
+	for _, key := range keys {
+		signer.keys[strings.ToLower(key.Domain)] = key
+	}
+
+	return signer
+}
+
+// ParseDKIMPrivateKey reads a PEM encoded RSA (PKCS #1 or PKCS #8) or
+// Ed25519 (PKCS #8) private key.
+func ParseDKIMPrivateKey(data string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("DKIM private key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		return key, nil
+	case ed25519.PrivateKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("DKIM private key of type %T is not supported", key)
+	}
+}
+
+// Sign returns the message with a DKIM-Signature header prepended. Messages
+// from a domain that has no key, or whose parent domains have no key, are
+// returned unchanged.
+func (signer *DKIMSigner) Sign(data string) (string, error) {
+	headers, body := splitMessage(data)
+
+	key, ok := signer.keyFor(headers)
+	if !ok {
+		return data, nil
+	}
+
+	var algorithm string
+	switch key.Signer.(type) {
+	case *rsa.PrivateKey:
+		algorithm = "rsa-sha256"
+	case ed25519.PrivateKey:
+		algorithm = "ed25519-sha256"
+	default:
+		return "", fmt.Errorf("DKIM private key of type %T is not supported", key.Signer)
+	}
+
+	bodyHash := sha256.Sum256([]byte(RelaxedBody(body)))
+
+	names := []string{}
+	canonicalized := bytes.NewBuffer([]byte{})
+	for _, name := range DKIMSignedHeaders {
+		header, ok := findHeader(headers, name)
+		if !ok {
+			continue
+		}
+
+		names = append(names, strings.ToLower(name))
+		canonicalized.WriteString(RelaxedHeader(header))
+		canonicalized.WriteString("\r\n")
+	}
+
+	signature := fmt.Sprintf("DKIM-Signature: v=1; a=%s; c=relaxed/relaxed; d=%s; s=%s;\r\n\tt=%d; h=%s;\r\n\tbh=%s;\r\n\tb=",
+		algorithm, key.Domain, key.Selector, time.Now().Unix(), strings.Join(names, ":"),
+		base64.StdEncoding.EncodeToString(bodyHash[:]))
+	canonicalized.WriteString(RelaxedHeader(signature))
+
+	hash := sha256.Sum256(canonicalized.Bytes())
+
+	var signed []byte
+	var err error
+	switch key.Signer.(type) {
+	case *rsa.PrivateKey:
+		signed, err = key.Signer.Sign(rand.Reader, hash[:], crypto.SHA256)
+	case ed25519.PrivateKey:
+		signed, err = key.Signer.Sign(rand.Reader, hash[:], crypto.Hash(0))
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return signature + base64.StdEncoding.EncodeToString(signed) + "\r\n" + data, nil
+}
+
+func (signer *DKIMSigner) keyFor(headers []string) (DKIMKey, bool) {
+	from, ok := findHeader(headers, "From")
+	if !ok {
+		return DKIMKey{}, false
+	}
+
+	address, err := netmail.ParseAddress(strings.TrimSpace(from[strings.Index(from, ":")+1:]))
+	if err != nil {
+		return DKIMKey{}, false
+	}
+
+	domain := strings.ToLower(address.Address[strings.LastIndex(address.Address, "@")+1:])
+	for domain != "" {
+		if key, ok := signer.keys[domain]; ok {
+			return key, true
+		}
+
+		index := strings.Index(domain, ".")
+		if index < 0 {
+			break
+		}
+		domain = domain[index+1:]
+	}
+
+	return DKIMKey{}, false
+}
+
+// splitMessage separates the header fields, with any continuation lines
+// folded back into the field they belong to, from the body.
+func splitMessage(data string) ([]string, string) {
+	data = strings.Replace(data, "\r\n", "\n", -1)
+
+	var head, body string
+	if index := strings.Index(data, "\n\n"); index >= 0 {
+		head, body = data[:index], data[index+2:]
+	} else {
+		head = data
+	}
+
+	headers := []string{}
+	for _, line := range strings.Split(head, "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(headers) > 0 {
+			headers[len(headers)-1] += "\n" + line
+			continue
+		}
+		headers = append(headers, line)
+	}
+
+	return headers, body
+}
+
+// findHeader returns the last instance of a header field, since signers
+// and verifiers both work from the bottom of the header block up.
+func findHeader(headers []string, name string) (string, bool) {
+	for i := len(headers) - 1; i >= 0; i-- {
+		index := strings.Index(headers[i], ":")
+		if index < 0 {
+			continue
+		}
+
+		if strings.EqualFold(strings.TrimSpace(headers[i][:index]), name) {
+			return headers[i], true
+		}
+	}
+
+	return "", false
+}
+
+// RelaxedHeader canonicalizes a header field as described in RFC 6376,
+// section 3.4.2, without the trailing CRLF.
+func RelaxedHeader(header string) string {
+	index := strings.Index(header, ":")
+	name := strings.ToLower(strings.TrimSpace(header[:index]))
+
+	value := header[index+1:]
+	value = strings.Replace(value, "\r\n", "", -1)
+	value = strings.Replace(value, "\n", "", -1)
+	value = strings.TrimSpace(collapseWhitespace(value))
+
+	return name + ":" + value
+}
+
+// RelaxedBody canonicalizes a message body as described in RFC 6376,
+// section 3.4.4, with CRLF line endings.
+func RelaxedBody(body string) string {
+	lines := strings.Split(strings.Replace(body, "\r\n", "\n", -1), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(collapseWhitespace(line), " ")
+	}
+
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return strings.Join(lines, "\r\n") + "\r\n"
+}
+
+func collapseWhitespace(value string) string {
+	buffer := bytes.NewBuffer([]byte{})
+	space := false
+	for _, char := range value {
+		if char == ' ' || char == '\t' {
+			space = true
+			continue
+		}
+
+		if space {
+			buffer.WriteRune(' ')
+			space = false
+		}
+		buffer.WriteRune(char)
+	}
+
+	if space {
+		buffer.WriteRune(' ')
+	}
+
+	return buffer.String()
+}
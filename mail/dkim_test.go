@@ -0,0 +1,221 @@
+package mail_test
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"regexp"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// verifyDKIM checks the first DKIM-Signature of a message the way a
+// receiving server would, and returns the tags of the signature.
+func verifyDKIM(data string, publicKey crypto.PublicKey) map[string]string {
+	data = strings.Replace(data, "\r\n", "\n", -1)
+	parts := strings.SplitN(data, "\n\n", 2)
+	head, body := parts[0], parts[1]
+
+	headers := []string{}
+	for _, line := range strings.Split(head, "\n") {
+		if strings.HasPrefix(line, "\t") || strings.HasPrefix(line, " ") {
+			headers[len(headers)-1] += "\n" + line
+		} else {
+			headers = append(headers, line)
+		}
+	}
+
+	Expect(headers[0]).To(MatchRegexp("^DKIM-Signature:"))
+	signature := headers[0]
+
+	tags := map[string]string{}
+	for _, tag := range strings.Split(signature[len("DKIM-Signature:"):], ";") {
+		tag = regexp.MustCompile(`\s+`).ReplaceAllString(tag, "")
+		if tag == "" {
+			continue
+		}
+		pair := strings.SplitN(tag, "=", 2)
+		tags[pair[0]] = pair[1]
+	}
+
+	bodyHash := sha256.Sum256([]byte(mail.RelaxedBody(body)))
+	Expect(tags["bh"]).To(Equal(base64.StdEncoding.EncodeToString(bodyHash[:])))
+
+	canonicalized := ""
+	for _, name := range strings.Split(tags["h"], ":") {
+		for _, header := range headers[1:] {
+			if strings.EqualFold(strings.SplitN(header, ":", 2)[0], name) {
+				canonicalized += mail.RelaxedHeader(header) + "\r\n"
+			}
+		}
+	}
+	unsigned := regexp.MustCompile(`b=[^;]*$`).ReplaceAllString(signature, "b=")
+	canonicalized += mail.RelaxedHeader(unsigned)
+
+	hash := sha256.Sum256([]byte(canonicalized))
+	signed, err := base64.StdEncoding.DecodeString(tags["b"])
+	Expect(err).NotTo(HaveOccurred())
+
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		err = rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], signed)
+		Expect(err).NotTo(HaveOccurred())
+	case ed25519.PublicKey:
+		Expect(ed25519.Verify(key, hash[:], signed)).To(BeTrue())
+	}
+
+	return tags
+}
+
+var _ = Describe("DKIM", func() {
+	var message mail.Message
+	var rsaKey *rsa.PrivateKey
+	var ed25519Key ed25519.PrivateKey
+
+	BeforeEach(func() {
+		var err error
+
+		rsaKey, err = rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			panic(err)
+		}
+
+		_, ed25519Key, err = ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			panic(err)
+		}
+
+		message = mail.Message{
+			From:    "Notifications <no-reply@example.com>",
+			To:      "you@example.org",
+			Subject: "Urgent!   Read now!",
+			Headers: []string{"X-CF-Notification-ID: message-123"},
+			Body: []mail.Part{
+				{
+					ContentType: "text/plain",
+					Content:     "This email is the most important thing you will read all day!  \n\n\n",
+				},
+			},
+		}
+	})
+
+	Describe("Sign", func() {
+		It("signs the message with an RSA key", func() {
+			signer := mail.NewDKIMSigner([]mail.DKIMKey{
+				{Domain: "example.com", Selector: "notifications", Signer: rsaKey},
+			})
+
+			signed, err := signer.Sign(message.Data())
+			Expect(err).NotTo(HaveOccurred())
+
+			tags := verifyDKIM(signed, rsaKey.Public())
+			Expect(tags["a"]).To(Equal("rsa-sha256"))
+			Expect(tags["c"]).To(Equal("relaxed/relaxed"))
+			Expect(tags["d"]).To(Equal("example.com"))
+			Expect(tags["s"]).To(Equal("notifications"))
+			Expect(tags["h"]).To(Equal("from:to:subject:date:mime-version:content-type:content-transfer-encoding:x-cf-notification-id"))
+		})
+
+		It("signs the message with an Ed25519 key", func() {
+			signer := mail.NewDKIMSigner([]mail.DKIMKey{
+				{Domain: "example.com", Selector: "notifications", Signer: ed25519Key},
+			})
+
+			signed, err := signer.Sign(message.Data())
+			Expect(err).NotTo(HaveOccurred())
+
+			tags := verifyDKIM(signed, ed25519Key.Public())
+			Expect(tags["a"]).To(Equal("ed25519-sha256"))
+		})
+
+		It("chooses the key for the domain of the From address", func() {
+			signer := mail.NewDKIMSigner([]mail.DKIMKey{
+				{Domain: "example.net", Selector: "other", Signer: ed25519Key},
+				{Domain: "example.com", Selector: "notifications", Signer: rsaKey},
+			})
+
+			signed, err := signer.Sign(message.Data())
+			Expect(err).NotTo(HaveOccurred())
+
+			tags := verifyDKIM(signed, rsaKey.Public())
+			Expect(tags["d"]).To(Equal("example.com"))
+		})
+
+		It("uses the key of a parent domain for subdomains", func() {
+			message.From = "no-reply@mail.Example.com"
+			signer := mail.NewDKIMSigner([]mail.DKIMKey{
+				{Domain: "example.com", Selector: "notifications", Signer: rsaKey},
+			})
+
+			signed, err := signer.Sign(message.Data())
+			Expect(err).NotTo(HaveOccurred())
+
+			tags := verifyDKIM(signed, rsaKey.Public())
+			Expect(tags["d"]).To(Equal("example.com"))
+		})
+
+		It("leaves messages from other domains unsigned", func() {
+			message.From = "no-reply@example.net"
+			signer := mail.NewDKIMSigner([]mail.DKIMKey{
+				{Domain: "example.com", Selector: "notifications", Signer: rsaKey},
+			})
+
+			data := message.Data()
+			signed, err := signer.Sign(data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(signed).To(Equal(data))
+		})
+	})
+
+	Describe("canonicalization", func() {
+		It("canonicalizes headers as in the example from RFC 6376", func() {
+			Expect(mail.RelaxedHeader("A: X")).To(Equal("a:X"))
+			Expect(mail.RelaxedHeader("B : Y\t\r\n\tZ  ")).To(Equal("b:Y Z"))
+		})
+
+		It("canonicalizes the body as in the example from RFC 6376", func() {
+			Expect(mail.RelaxedBody(" C \r\nD \t E\r\n\r\n\r\n")).To(Equal(" C\r\nD E\r\n"))
+		})
+
+		It("canonicalizes an empty body to nothing", func() {
+			Expect(mail.RelaxedBody("\r\n\r\n")).To(Equal(""))
+		})
+	})
+
+	Describe("ParseDKIMPrivateKey", func() {
+		It("parses PKCS #1 RSA keys", func() {
+			data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})
+
+			key, err := mail.ParseDKIMPrivateKey(string(data))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(BeAssignableToTypeOf(&rsa.PrivateKey{}))
+			Expect(key.(*rsa.PrivateKey).Equal(rsaKey)).To(BeTrue())
+		})
+
+		It("parses PKCS #8 Ed25519 keys", func() {
+			der, err := x509.MarshalPKCS8PrivateKey(ed25519Key)
+			if err != nil {
+				panic(err)
+			}
+			data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+			key, err := mail.ParseDKIMPrivateKey(string(data))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key).To(Equal(ed25519Key))
+		})
+
+		It("returns an error for data that is not PEM encoded", func() {
+			_, err := mail.ParseDKIMPrivateKey("not a key")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,159 @@
+package mail
+
+import (
+	"bufio"
+	"io"
+	"mime"
+	"mime/multipart"
+	netmail "net/mail"
+	"net/textproto"
+	"strings"
+)
+
+const NotificationIDHeader = "X-CF-Notification-ID"
+
+// DeliveryStatusNotification is a bounce message, as described by RFC 3464,
+// reporting on the delivery of a message that was sent earlier.
+type DeliveryStatusNotification struct {
+	NotificationID string
+	Recipients     []DeliveryStatus
+}
+
+// DeliveryStatus is the outcome of delivering to a single recipient.
+type DeliveryStatus struct {
+	FinalRecipient string
+	Action         string
+	Status         string
+	DiagnosticCode string
+}
+
+// HardBounce reports whether the delivery failed permanently, meaning the
+// recipient should not be sent any more messages.
+func (status DeliveryStatus) HardBounce() bool {
+	return status.Action == "failed" && strings.HasPrefix(status.Status, "5.")
+}
+
+type DSNParseError string
+
+func (err DSNParseError) Error() string {
+	return "Delivery status notification could not be parsed: " + string(err)
+}
+
+// ParseDSN reads a raw multipart/report message. The notification ID is taken
+// from the X-CF-Notification-ID header of the original message, which most
+// relays return alongside the delivery status.
+func ParseDSN(reader io.Reader) (DeliveryStatusNotification, error) {
+	var dsn DeliveryStatusNotification
+
+	message, err := netmail.ReadMessage(reader)
+	if err != nil {
+		return dsn, DSNParseError(err.Error())
+	}
+
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	if err != nil {
+		return dsn, DSNParseError(err.Error())
+	}
+
+	if !strings.HasPrefix(mediaType, "multipart/") || params["boundary"] == "" {
+		return dsn, DSNParseError("message is not a multipart report")
+	}
+
+	foundStatus := false
+	parts := multipart.NewReader(message.Body, params["boundary"])
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return dsn, DSNParseError(err.Error())
+		}
+
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		switch partType {
+		case "message/delivery-status":
+			dsn.Recipients, err = parseDeliveryStatus(part)
+			if err != nil {
+				return dsn, DSNParseError(err.Error())
+			}
+			foundStatus = true
+		case "message/rfc822", "text/rfc822-headers":
+			header, err := readHeader(part)
+			if err != nil {
+				return dsn, DSNParseError(err.Error())
+			}
+			dsn.NotificationID = strings.TrimSpace(header.Get(NotificationIDHeader))
+		}
+	}
+
+	if !foundStatus {
+		return dsn, DSNParseError("message has no delivery status")
+	}
+
+	return dsn, nil
+}
+
+func parseDeliveryStatus(reader io.Reader) ([]DeliveryStatus, error) {
+	statuses := []DeliveryStatus{}
+	textReader := textproto.NewReader(bufio.NewReader(reader))
+
+	// The first group of fields describes the whole message, every
+	// following group describes a single recipient.
+	_, err := textReader.ReadMIMEHeader()
+	if err != nil {
+		if err == io.EOF {
+			return statuses, nil
+		}
+		return statuses, err
+	}
+
+	for {
+		header, err := textReader.ReadMIMEHeader()
+		if len(header) > 0 {
+			statuses = append(statuses, DeliveryStatus{
+				FinalRecipient: strings.Trim(fieldValue(header.Get("Final-Recipient")), "<>"),
+				Action:         strings.ToLower(strings.TrimSpace(header.Get("Action"))),
+				Status:         statusCode(header.Get("Status")),
+				DiagnosticCode: fieldValue(header.Get("Diagnostic-Code")),
+			})
+		}
+
+		if err == io.EOF {
+			return statuses, nil
+		}
+		if err != nil {
+			return statuses, err
+		}
+	}
+}
+
+func readHeader(reader io.Reader) (textproto.MIMEHeader, error) {
+	header, err := textproto.NewReader(bufio.NewReader(reader)).ReadMIMEHeader()
+	if err == io.EOF {
+		err = nil
+	}
+
+	return header, err
+}
+
+// fieldValue strips the type from fields such as "rfc822; user@example.com"
+// or "smtp; 550 5.1.1 unknown user".
+func fieldValue(field string) string {
+	parts := strings.SplitN(field, ";", 2)
+	if len(parts) == 2 {
+		field = parts[1]
+	}
+
+	return strings.TrimSpace(field)
+}
+
+// statusCode drops any comment that follows the code, as in "5.1.1 (bad mailbox)".
+func statusCode(field string) string {
+	fields := strings.Fields(field)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	return fields[0]
+}
@@ -0,0 +1,116 @@
+package mail_test
+
+import (
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const bounceMessage = `Return-Path: <>
+From: Mail Delivery System <MAILER-DAEMON@relay.example.com>
+To: notifications@example.com
+Subject: Undelivered Mail Returned to Sender
+MIME-Version: 1.0
+Content-Type: multipart/report; report-type=delivery-status; boundary="BOUNDARY"
+
+--BOUNDARY
+Content-Type: text/plain; charset=us-ascii
+
+Your message could not be delivered to one or more recipients.
+
+--BOUNDARY
+Content-Type: message/delivery-status
+
+Reporting-MTA: dns; relay.example.com
+Arrival-Date: Tue, 20 Jan 2015 10:00:00 +0000
+
+Final-Recipient: rfc822; <Gone@Example.com>
+Original-Recipient: rfc822; gone@example.com
+Action: failed
+Status: 5.1.1 (bad destination mailbox)
+Diagnostic-Code: smtp; 550 5.1.1 user unknown
+
+Final-Recipient: rfc822; busy@example.com
+Action: delayed
+Status: 4.2.2
+Diagnostic-Code: smtp; 452 4.2.2 mailbox full
+
+--BOUNDARY
+Content-Type: text/rfc822-headers
+
+X-CF-Client-ID: some-client
+X-CF-Notification-ID: message-123
+From: notifications@example.com
+To: gone@example.com
+Subject: Hello
+
+--BOUNDARY--
+`
+
+var _ = Describe("ParseDSN", func() {
+	It("parses the delivery status of each recipient", func() {
+		dsn, err := mail.ParseDSN(strings.NewReader(bounceMessage))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(dsn.Recipients).To(Equal([]mail.DeliveryStatus{
+			{
+				FinalRecipient: "Gone@Example.com",
+				Action:         "failed",
+				Status:         "5.1.1",
+				DiagnosticCode: "550 5.1.1 user unknown",
+			},
+			{
+				FinalRecipient: "busy@example.com",
+				Action:         "delayed",
+				Status:         "4.2.2",
+				DiagnosticCode: "452 4.2.2 mailbox full",
+			},
+		}))
+	})
+
+	It("finds the notification ID in the returned headers", func() {
+		dsn, err := mail.ParseDSN(strings.NewReader(bounceMessage))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(dsn.NotificationID).To(Equal("message-123"))
+	})
+
+	It("finds the notification ID in a returned message", func() {
+		message := strings.Replace(bounceMessage, "Content-Type: text/rfc822-headers", "Content-Type: message/rfc822", 1)
+		message = strings.Replace(message, "Subject: Hello\n", "Subject: Hello\n\nThe original body\n", 1)
+
+		dsn, err := mail.ParseDSN(strings.NewReader(message))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(dsn.NotificationID).To(Equal("message-123"))
+	})
+
+	It("returns an error when the message is not a report", func() {
+		_, err := mail.ParseDSN(strings.NewReader("Subject: Hello\nContent-Type: text/plain\n\nHi there\n"))
+		Expect(err).To(BeAssignableToTypeOf(mail.DSNParseError("")))
+	})
+
+	It("returns an error when the report has no delivery status", func() {
+		message := strings.Replace(bounceMessage, "Content-Type: message/delivery-status", "Content-Type: text/plain", 1)
+
+		_, err := mail.ParseDSN(strings.NewReader(message))
+		Expect(err).To(BeAssignableToTypeOf(mail.DSNParseError("")))
+	})
+})
+
+var _ = Describe("DeliveryStatus", func() {
+	Describe("HardBounce", func() {
+		It("is true for permanent failures", func() {
+			Expect(mail.DeliveryStatus{Action: "failed", Status: "5.1.1"}.HardBounce()).To(BeTrue())
+		})
+
+		It("is false for transient failures and delays", func() {
+			Expect(mail.DeliveryStatus{Action: "failed", Status: "4.4.7"}.HardBounce()).To(BeFalse())
+			Expect(mail.DeliveryStatus{Action: "delayed", Status: "4.2.2"}.HardBounce()).To(BeFalse())
+			Expect(mail.DeliveryStatus{Action: "delivered", Status: "2.0.0"}.HardBounce()).To(BeFalse())
+		})
+	})
+})
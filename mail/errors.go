@@ -0,0 +1,49 @@
+package mail
+
+import (
+	"fmt"
+	"net/textproto"
+)
+
+// SMTPError is returned by the Client when the SMTP server rejects a command.
+// It carries the command that was rejected along with the reply code and
+// message sent back by the server.
+type SMTPError struct {
+	Command string
+	Code    int
+	Message string
+}
+
+func NewSMTPError(command string, err error) error {
+	if protoErr, ok := err.(*textproto.Error); ok {
+		return SMTPError{
+			Command: command,
+			Code:    protoErr.Code,
+			Message: protoErr.Msg,
+		}
+	}
+
+	return err
+}
+
+func (err SMTPError) Error() string {
+	return fmt.Sprintf("%s failed with %d %s", err.Command, err.Code, err.Message)
+}
+
+// Permanent reports whether the server replied with a 5xx code, meaning the
+// same command will keep failing if it is sent again.
+func (err SMTPError) Permanent() bool {
+	return err.Code >= 500 && err.Code < 600
+}
+
+// Undeliverable reports whether the server permanently rejected the recipient
+// or the message itself, as opposed to rejecting the connection or the
+// credentials of the client.
+func (err SMTPError) Undeliverable() bool {
+	return err.Permanent() && (err.Command == "RCPT" || err.Command == "DATA")
+}
+
+func undeliverable(err error) bool {
+	smtpErr, ok := err.(SMTPError)
+	return ok && smtpErr.Undeliverable()
+}
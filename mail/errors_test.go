@@ -0,0 +1,55 @@
+package mail_test
+
+import (
+	"errors"
+	"net/textproto"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SMTPError", func() {
+	Describe("NewSMTPError", func() {
+		It("wraps SMTP replies with the command that was rejected", func() {
+			err := mail.NewSMTPError("RCPT", &textproto.Error{Code: 550, Msg: "no such user"})
+
+			Expect(err).To(Equal(mail.SMTPError{
+				Command: "RCPT",
+				Code:    550,
+				Message: "no such user",
+			}))
+			Expect(err.Error()).To(Equal("RCPT failed with 550 no such user"))
+		})
+
+		It("returns other errors unchanged", func() {
+			original := errors.New("connection reset by peer")
+
+			Expect(mail.NewSMTPError("RCPT", original)).To(Equal(original))
+		})
+	})
+
+	Describe("Permanent", func() {
+		It("is true for 5xx replies only", func() {
+			Expect(mail.SMTPError{Code: 550}.Permanent()).To(BeTrue())
+			Expect(mail.SMTPError{Code: 421}.Permanent()).To(BeFalse())
+		})
+	})
+
+	Describe("Undeliverable", func() {
+		It("is true when the recipient or the message is permanently rejected", func() {
+			Expect(mail.SMTPError{Command: "RCPT", Code: 550}.Undeliverable()).To(BeTrue())
+			Expect(mail.SMTPError{Command: "DATA", Code: 554}.Undeliverable()).To(BeTrue())
+		})
+
+		It("is false for transient replies", func() {
+			Expect(mail.SMTPError{Command: "RCPT", Code: 450}.Undeliverable()).To(BeFalse())
+		})
+
+		It("is false when the client itself is rejected", func() {
+			Expect(mail.SMTPError{Command: "AUTH", Code: 535}.Undeliverable()).To(BeFalse())
+			Expect(mail.SMTPError{Command: "MAIL", Code: 550}.Undeliverable()).To(BeFalse())
+		})
+	})
+})
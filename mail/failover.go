@@ -0,0 +1,148 @@
+package mail
+
+import (
+	"errors"
+	"log"
+	"net"
+)
+
+// ErrNoRelayAvailable is returned when the circuit breaker of every relay
+// is open.
+var ErrNoRelayAvailable = errors.New("No SMTP relay is available")
+
+// RelaySender is implemented by transports that can report which relay
+// accepted a message.
+type RelaySender interface {
+	SendVia(Message) (string, error)
+}
+
+// Relay is one of several SMTP servers that messages can be sent through,
+// along with the credentials it expects.
+type Relay struct {
+	Host          string
+	Port          string
+	User          string
+	Pass          string
+	Secret        string
+	AuthMechanism AuthMechanism
+	TLSMode       TLSMode
+}
+
+func (relay Relay) Name() string {
+	return net.JoinHostPort(relay.Host, relay.Port)
+}
+
+// ForRelay returns a copy of the configuration that connects to the relay.
+func (config Config) ForRelay(relay Relay) Config {
+	config.Host = relay.Host
+	config.Port = relay.Port
+	config.User = relay.User
+	config.Pass = relay.Pass
+	config.Secret = relay.Secret
+	config.AuthMechanism = relay.AuthMechanism
+	config.TLSMode = relay.TLSMode
+	config.Relays = nil
+
+	return config
+}
+
+// FailoverTransport sends each message through the first relay that accepts
+// it, in the order the relays are configured. A relay is skipped when it
+// cannot be reached, answers with a 4xx reply, or rejects the handshake, and
+// a circuit breaker keeps relays that keep failing from being tried for a
+// while. Messages that a relay permanently rejects are not tried elsewhere.
+type FailoverTransport struct {
+	relays []*relayTransport
+	logger *log.Logger
+}
+
+type relayTransport struct {
+	relay   Relay
+	pool    *Pool
+	breaker *CircuitBreaker
+}
+
+func NewFailoverTransport(config Config, logger *log.Logger) *FailoverTransport {
+	transport := &FailoverTransport{
+		logger: logger,
+	}
+
+	for _, relay := range config.Relays {
+		transport.relays = append(transport.relays, &relayTransport{
+			relay:   relay,
+			pool:    NewPool(config.ForRelay(relay), config.Pool, logger),
+			breaker: NewCircuitBreaker(config.CircuitBreaker),
+		})
+	}
+
+	return transport
+}
+
+// Connect succeeds as soon as one of the relays can be reached.
+func (transport *FailoverTransport) Connect() error {
+	err := ErrNoRelayAvailable
+
+	for _, relay := range transport.relays {
+		if !relay.breaker.Allow() {
+			continue
+		}
+
+		err = relay.pool.Connect()
+		if err == nil {
+			relay.breaker.Success()
+			return nil
+		}
+
+		relay.breaker.Failure()
+		transport.logger.Printf("SMTP relay %s could not be reached: %s", relay.relay.Name(), err.Error())
+	}
+
+	return err
+}
+
+func (transport *FailoverTransport) Send(msg Message) error {
+	_, err := transport.SendVia(msg)
+
+	return err
+}
+
+func (transport *FailoverTransport) SendVia(msg Message) (string, error) {
+	err := ErrNoRelayAvailable
+
+	for _, relay := range transport.relays {
+		if !relay.breaker.Allow() {
+			continue
+		}
+
+		err = relay.pool.Send(msg)
+		if err == nil || !failOver(err) {
+			relay.breaker.Success()
+			return relay.relay.Name(), err
+		}
+
+		relay.breaker.Failure()
+		transport.logger.Printf("SMTP relay %s failed, trying the next relay: %s", relay.relay.Name(), err.Error())
+	}
+
+	return "", err
+}
+
+// Trip opens the circuit breaker of the named relay, so that it is skipped
+// until its cooldown has passed.
+func (transport *FailoverTransport) Trip(name string) {
+	for _, relay := range transport.relays {
+		if relay.relay.Name() == name {
+			relay.breaker.Trip()
+		}
+	}
+}
+
+func (transport *FailoverTransport) Close() {
+	for _, relay := range transport.relays {
+		relay.pool.Close()
+	}
+}
+
+func failOver(err error) bool {
+	return !undeliverable(err)
+}
@@ -0,0 +1,196 @@
+package mail_test
+
+import (
+	"bytes"
+	"log"
+	"net"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FailoverTransport", func() {
+	var primary, secondary *SMTPServer
+	var transport *mail.FailoverTransport
+	var buffer *bytes.Buffer
+	var config mail.Config
+	var msg mail.Message
+	var stopped map[*SMTPServer]bool
+
+	stop := func(server *SMTPServer) {
+		if !stopped[server] {
+			server.Close()
+			stopped[server] = true
+		}
+	}
+
+	delivered := func(server *SMTPServer) int {
+		count := 0
+		for _, delivery := range server.Deliveries {
+			if len(delivery.Data) > 0 {
+				count++
+			}
+		}
+		return count
+	}
+
+	relayFor := func(server *SMTPServer) mail.Relay {
+		host, port, err := net.SplitHostPort(server.URL.String())
+		if err != nil {
+			panic(err)
+		}
+
+		return mail.Relay{
+			Host: host,
+			Port: port,
+			User: "user",
+			Pass: "pass",
+		}
+	}
+
+	BeforeEach(func() {
+		buffer = bytes.NewBuffer([]byte{})
+		stopped = map[*SMTPServer]bool{}
+		primary = NewSMTPServer("user", "pass")
+		secondary = NewSMTPServer("user", "pass")
+
+		config = mail.Config{
+			SkipVerifySSL: true,
+			Relays:        []mail.Relay{relayFor(primary), relayFor(secondary)},
+			CircuitBreaker: mail.CircuitBreakerConfig{
+				Threshold: 1,
+				Cooldown:  time.Minute,
+			},
+		}
+
+		msg = mail.Message{
+			From:    "me@example.com",
+			To:      "you@example.com",
+			Subject: "Urgent! Read now!",
+			Body: []mail.Part{
+				{
+					ContentType: "text/plain",
+					Content:     "This email is the most important thing you will read all day!",
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		transport = mail.NewFailoverTransport(config, log.New(buffer, "", 0))
+	})
+
+	AfterEach(func() {
+		transport.Close()
+		stop(primary)
+		stop(secondary)
+	})
+
+	It("is used by the smtp transport when relays are configured", func() {
+		client, err := mail.NewTransport("smtp", config, log.New(buffer, "", 0))
+		Expect(err).NotTo(HaveOccurred())
+		defer client.Close()
+
+		Expect(client).To(BeAssignableToTypeOf(&mail.FailoverTransport{}))
+	})
+
+	Describe("SendVia", func() {
+		It("sends through the first relay", func() {
+			relay, err := transport.SendVia(msg)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(relay).To(Equal(primary.URL.String()))
+			Eventually(func() int { return delivered(primary) }).Should(Equal(1))
+			Expect(delivered(secondary)).To(Equal(0))
+		})
+
+		It("fails over when the first relay cannot be reached", func() {
+			stop(primary)
+
+			relay, err := transport.SendVia(msg)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(relay).To(Equal(secondary.URL.String()))
+			Eventually(func() int { return delivered(secondary) }).Should(Equal(1))
+			Expect(buffer.String()).To(ContainSubstring("SMTP relay " + primary.URL.String() + " failed, trying the next relay"))
+		})
+
+		It("fails over when the first relay replies with a temporary error", func() {
+			primary.RcptReply = "451 Try again later"
+
+			relay, err := transport.SendVia(msg)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(relay).To(Equal(secondary.URL.String()))
+			Eventually(func() int { return delivered(secondary) }).Should(Equal(1))
+		})
+
+		It("does not fail over when the message is permanently rejected", func() {
+			primary.RcptReply = "550 No such user"
+
+			relay, err := transport.SendVia(msg)
+			Expect(err).To(BeAssignableToTypeOf(mail.SMTPError{}))
+			Expect(err.(mail.SMTPError).Code).To(Equal(550))
+
+			Expect(relay).To(Equal(primary.URL.String()))
+			Expect(delivered(secondary)).To(Equal(0))
+		})
+
+		It("stops trying a relay once its circuit breaker opens", func() {
+			primary.RcptReply = "451 Try again later"
+
+			_, err := transport.SendVia(msg)
+			Expect(err).NotTo(HaveOccurred())
+
+			primary.RcptReply = ""
+			relay, err := transport.SendVia(msg)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(relay).To(Equal(secondary.URL.String()))
+			Expect(delivered(primary)).To(Equal(0))
+		})
+
+		It("returns the last error when every relay fails", func() {
+			primary.RcptReply = "451 Try again later"
+			secondary.RcptReply = "452 Mailbox full"
+
+			relay, err := transport.SendVia(msg)
+			Expect(err).To(BeAssignableToTypeOf(mail.SMTPError{}))
+			Expect(err.(mail.SMTPError).Code).To(Equal(452))
+			Expect(relay).To(Equal(""))
+
+			_, err = transport.SendVia(msg)
+			Expect(err).To(Equal(mail.ErrNoRelayAvailable))
+		})
+	})
+
+	Describe("Trip", func() {
+		It("skips the relay until its cooldown has passed", func() {
+			transport.Trip(primary.URL.String())
+
+			relay, err := transport.SendVia(msg)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(relay).To(Equal(secondary.URL.String()))
+			Expect(delivered(primary)).To(Equal(0))
+		})
+	})
+
+	Describe("Connect", func() {
+		It("succeeds when any relay can be reached", func() {
+			stop(primary)
+
+			Expect(transport.Connect()).To(BeNil())
+		})
+
+		It("fails when no relay can be reached", func() {
+			stop(primary)
+			stop(secondary)
+
+			Expect(transport.Connect()).NotTo(BeNil())
+		})
+	})
+})
@@ -0,0 +1,129 @@
+package mail
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	FileFormatMaildir = "maildir"
+	FileFormatEML     = "eml"
+)
+
+type FileTransportConfig struct {
+	Directory string
+	Format    string
+}
+
+// FileTransport writes every message into a directory instead of sending it,
+// either as a maildir that a mail client can open, or as one .eml file per
+// message. It is meant for working on templates locally, and writes messages
+// even in test mode.
+type FileTransport struct {
+	config   FileTransportConfig
+	logger   *log.Logger
+	hostname string
+	mutex    *sync.Mutex
+	count    int
+}
+
+func NewFileTransport(config Config, logger *log.Logger) (*FileTransport, error) {
+	fileConfig := config.File
+	if fileConfig.Format == "" {
+		fileConfig.Format = FileFormatMaildir
+	}
+
+	if fileConfig.Format != FileFormatMaildir && fileConfig.Format != FileFormatEML {
+		return nil, fmt.Errorf("Unknown mail file format %q, it is not one of [%s %s]", fileConfig.Format, FileFormatMaildir, FileFormatEML)
+	}
+
+	if fileConfig.Directory == "" {
+		return nil, fmt.Errorf("A directory is required to write mail files into")
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "localhost"
+	}
+
+	return &FileTransport{
+		config:   fileConfig,
+		logger:   logger,
+		hostname: hostname,
+		mutex:    &sync.Mutex{},
+	}, nil
+}
+
+func (transport *FileTransport) Connect() error {
+	directories := []string{transport.config.Directory}
+	if transport.config.Format == FileFormatMaildir {
+		directories = []string{
+			filepath.Join(transport.config.Directory, "tmp"),
+			filepath.Join(transport.config.Directory, "new"),
+			filepath.Join(transport.config.Directory, "cur"),
+		}
+	}
+
+	for _, directory := range directories {
+		err := os.MkdirAll(directory, 0755)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (transport *FileTransport) Send(msg Message) error {
+	err := transport.Connect()
+	if err != nil {
+		return err
+	}
+
+	name := transport.uniqueName()
+	data := []byte(msg.Data())
+
+	if transport.config.Format == FileFormatEML {
+		path := filepath.Join(transport.config.Directory, name+".eml")
+		err = ioutil.WriteFile(path, data, 0644)
+		if err != nil {
+			return err
+		}
+
+		transport.logger.Printf("Wrote message to %s into %s", msg.To, path)
+		return nil
+	}
+
+	// Maildir readers only look in new/, so the message is written to tmp/
+	// first and moved once it is complete.
+	tmp := filepath.Join(transport.config.Directory, "tmp", name)
+	err = ioutil.WriteFile(tmp, data, 0644)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(transport.config.Directory, "new", name)
+	err = os.Rename(tmp, path)
+	if err != nil {
+		return err
+	}
+
+	transport.logger.Printf("Wrote message to %s into %s", msg.To, path)
+	return nil
+}
+
+func (transport *FileTransport) Close() {}
+
+func (transport *FileTransport) uniqueName() string {
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+
+	transport.count++
+
+	return fmt.Sprintf("%d.P%dQ%d.%s", time.Now().UnixNano(), os.Getpid(), transport.count, transport.hostname)
+}
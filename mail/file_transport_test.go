@@ -0,0 +1,108 @@
+package mail_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FileTransport", func() {
+	var directory string
+	var config mail.Config
+	var logger *log.Logger
+	var msg mail.Message
+
+	BeforeEach(func() {
+		var err error
+
+		directory, err = ioutil.TempDir("", "mail")
+		if err != nil {
+			panic(err)
+		}
+
+		config = mail.Config{
+			TestMode: true,
+			File: mail.FileTransportConfig{
+				Directory: filepath.Join(directory, "inbox"),
+			},
+		}
+		logger = log.New(bytes.NewBuffer([]byte{}), "", 0)
+
+		msg = mail.Message{
+			From:    "me@example.com",
+			To:      "you@example.com",
+			Subject: "Urgent! Read now!",
+			Body: []mail.Part{
+				{ContentType: "text/plain", Content: "the text"},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(directory)
+	})
+
+	It("writes messages into a maildir by default, even in test mode", func() {
+		transport, err := mail.NewFileTransport(config, logger)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = transport.Send(msg)
+		Expect(err).NotTo(HaveOccurred())
+		err = transport.Send(msg)
+		Expect(err).NotTo(HaveOccurred())
+
+		files, err := ioutil.ReadDir(filepath.Join(directory, "inbox", "new"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(HaveLen(2))
+
+		data, err := ioutil.ReadFile(filepath.Join(directory, "inbox", "new", files[0].Name()))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring("Subject: Urgent! Read now!"))
+
+		tmp, err := ioutil.ReadDir(filepath.Join(directory, "inbox", "tmp"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tmp).To(BeEmpty())
+
+		_, err = os.Stat(filepath.Join(directory, "inbox", "cur"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("writes each message into an .eml file", func() {
+		config.File.Format = mail.FileFormatEML
+
+		transport, err := mail.NewFileTransport(config, logger)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = transport.Send(msg)
+		Expect(err).NotTo(HaveOccurred())
+
+		matches, err := filepath.Glob(filepath.Join(directory, "inbox", "*.eml"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matches).To(HaveLen(1))
+
+		data, err := ioutil.ReadFile(matches[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring("To: you@example.com"))
+	})
+
+	It("returns an error for an unknown format", func() {
+		config.File.Format = "mbox"
+
+		_, err := mail.NewFileTransport(config, logger)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("returns an error when no directory is configured", func() {
+		config.File.Directory = ""
+
+		_, err := mail.NewFileTransport(config, logger)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,157 @@
+package mail
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type HTTPTransportConfig struct {
+	URL   string
+	Token string
+}
+
+// HTTPTransport hands messages to a mail provider by POSTing them as JSON.
+// The payload carries the rendered message in "raw" alongside its parts, so
+// that providers can use whichever they prefer.
+type HTTPTransport struct {
+	config Config
+	logger *log.Logger
+	client *http.Client
+}
+
+type HTTPError struct {
+	StatusCode int
+	Body       string
+}
+
+func (err HTTPError) Error() string {
+	return fmt.Sprintf("Mail provider responded with %d %s", err.StatusCode, err.Body)
+}
+
+type httpTransportPayload struct {
+	From    string            `json:"from"`
+	ReplyTo string            `json:"reply_to,omitempty"`
+	To      string            `json:"to"`
+	Subject string            `json:"subject"`
+	Headers map[string]string `json:"headers"`
+	Text    string            `json:"text,omitempty"`
+	HTML    string            `json:"html,omitempty"`
+	Raw     string            `json:"raw"`
+
+	Attachments []httpTransportAttachment `json:"attachments,omitempty"`
+}
+
+type httpTransportAttachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Content     []byte `json:"content"`
+	ContentID   string `json:"content_id,omitempty"`
+}
+
+func NewHTTPTransport(config Config, logger *log.Logger) *HTTPTransport {
+	timeout := config.ConnectTimeout
+	if timeout == 0 {
+		timeout = 15 * time.Second
+	}
+
+	return &HTTPTransport{
+		config: config,
+		logger: logger,
+		client: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: config.SkipVerifySSL,
+				},
+			},
+		},
+	}
+}
+
+func (transport *HTTPTransport) Connect() error {
+	return nil
+}
+
+func (transport *HTTPTransport) Send(msg Message) error {
+	if transport.config.TestMode {
+		transport.logger.Println("TEST_MODE is enabled, emails not being sent")
+		return nil
+	}
+
+	raw := msg.Data()
+	if transport.config.DKIM != nil {
+		var err error
+		raw, err = transport.config.DKIM.Sign(raw)
+		if err != nil {
+			return err
+		}
+	}
+
+	payload := httpTransportPayload{
+		From:    msg.From,
+		ReplyTo: msg.ReplyTo,
+		To:      msg.To,
+		Subject: msg.Subject,
+		Headers: map[string]string{},
+		Raw:     raw,
+	}
+
+	for _, header := range msg.Headers {
+		parts := strings.SplitN(header, ":", 2)
+		if len(parts) == 2 {
+			payload.Headers[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+		}
+	}
+
+	for _, part := range msg.Body {
+		switch part.ContentType {
+		case "text/plain":
+			payload.Text = part.Content
+		case "text/html":
+			payload.HTML = part.Content
+		}
+	}
+
+	for _, attachment := range msg.Attachments {
+		payload.Attachments = append(payload.Attachments, httpTransportAttachment(attachment))
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest("POST", transport.config.HTTP.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if transport.config.HTTP.Token != "" {
+		request.Header.Set("Authorization", "Bearer "+transport.config.HTTP.Token)
+	}
+
+	response, err := transport.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		responseBody, _ := ioutil.ReadAll(response.Body)
+		return HTTPError{
+			StatusCode: response.StatusCode,
+			Body:       strings.TrimSpace(string(responseBody)),
+		}
+	}
+
+	return nil
+}
+
+func (transport *HTTPTransport) Close() {}
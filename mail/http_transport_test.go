@@ -0,0 +1,142 @@
+package mail_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HTTPTransport", func() {
+	var server *httptest.Server
+	var transport *mail.HTTPTransport
+	var config mail.Config
+	var request *http.Request
+	var payload map[string]interface{}
+	var status int
+	var msg mail.Message
+
+	BeforeEach(func() {
+		status = http.StatusAccepted
+		payload = nil
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			request = req
+
+			body, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				panic(err)
+			}
+
+			err = json.Unmarshal(body, &payload)
+			if err != nil {
+				panic(err)
+			}
+
+			w.WriteHeader(status)
+			w.Write([]byte(`{"error":"no such domain"}`))
+		}))
+
+		config = mail.Config{
+			HTTP: mail.HTTPTransportConfig{
+				URL:   server.URL + "/v1/send",
+				Token: "api-token",
+			},
+		}
+
+		msg = mail.Message{
+			From:    "me@example.com",
+			ReplyTo: "support@example.com",
+			To:      "you@example.com",
+			Subject: "Urgent! Read now!",
+			Headers: []string{"X-CF-Notification-ID: message-123"},
+			Body: []mail.Part{
+				{ContentType: "text/plain", Content: "the text"},
+				{ContentType: "text/html", Content: "<p>the html</p>"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		transport = mail.NewHTTPTransport(config, log.New(bytes.NewBuffer([]byte{}), "", 0))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("posts the message as JSON to the provider", func() {
+		err := transport.Send(msg)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(request.Method).To(Equal("POST"))
+		Expect(request.URL.Path).To(Equal("/v1/send"))
+		Expect(request.Header.Get("Content-Type")).To(Equal("application/json"))
+		Expect(request.Header.Get("Authorization")).To(Equal("Bearer api-token"))
+
+		Expect(payload["from"]).To(Equal("me@example.com"))
+		Expect(payload["reply_to"]).To(Equal("support@example.com"))
+		Expect(payload["to"]).To(Equal("you@example.com"))
+		Expect(payload["subject"]).To(Equal("Urgent! Read now!"))
+		Expect(payload["headers"]).To(Equal(map[string]interface{}{
+			"X-CF-Notification-ID": "message-123",
+		}))
+		Expect(payload["text"]).To(Equal("the text"))
+		Expect(payload["html"]).To(Equal("<p>the html</p>"))
+		Expect(payload["raw"]).To(ContainSubstring("Subject: Urgent! Read now!"))
+		Expect(payload).NotTo(HaveKey("attachments"))
+	})
+
+	It("includes attachments with their content base64 encoded", func() {
+		msg.Attachments = []mail.Attachment{
+			{Filename: "invoice.pdf", ContentType: "application/pdf", Content: []byte("%PDF-1.4")},
+			{Filename: "logo.png", ContentType: "image/png", Content: []byte("PNG"), ContentID: "logo"},
+		}
+
+		err := transport.Send(msg)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(payload["attachments"]).To(Equal([]interface{}{
+			map[string]interface{}{
+				"filename":     "invoice.pdf",
+				"content_type": "application/pdf",
+				"content":      "JVBERi0xLjQ=",
+			},
+			map[string]interface{}{
+				"filename":     "logo.png",
+				"content_type": "image/png",
+				"content":      "UE5H",
+				"content_id":   "logo",
+			},
+		}))
+	})
+
+	It("returns an error when the provider rejects the message", func() {
+		status = http.StatusBadRequest
+
+		err := transport.Send(msg)
+		Expect(err).To(Equal(mail.HTTPError{
+			StatusCode: http.StatusBadRequest,
+			Body:       `{"error":"no such domain"}`,
+		}))
+	})
+
+	Context("when in test mode", func() {
+		BeforeEach(func() {
+			config.TestMode = true
+		})
+
+		It("does not post the message", func() {
+			err := transport.Send(msg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(payload).To(BeNil())
+		})
+	})
+})
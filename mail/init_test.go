@@ -82,7 +82,10 @@ type SMTPServer struct {
 	Deliveries      []Delivery
 	Listener        *net.TCPListener
 	SupportsTLS     bool
+	ImplicitTLS     bool
+	RcptReply       string
 	ConnectWait     time.Duration
+	Connections     int
 	halt            chan bool
 	ConnectionState string
 }
@@ -92,6 +95,8 @@ type Delivery struct {
 	Sender    string
 	Data      []string
 	UsedTLS   bool
+	// ClientCertificates is only recorded for implicit TLS connections.
+	ClientCertificates int
 }
 
 func NewSMTPServer(user, pass string) *SMTPServer {
@@ -153,6 +158,20 @@ func (server *SMTPServer) Close() {
 func (server *SMTPServer) Respond(conn net.Conn) {
 	<-time.After(server.ConnectWait)
 	server.ConnectionState = StateConnected
+	server.Connections++
+
+	clientCertificates := 0
+	if server.ImplicitTLS {
+		tlsConn := tls.Server(conn, server.tlsConfig())
+		err := tlsConn.Handshake()
+		if err != nil {
+			conn.Close()
+			return
+		}
+
+		clientCertificates = len(tlsConn.ConnectionState().PeerCertificates)
+		conn = tlsConn
+	}
 
 	input := bufio.NewReader(conn)
 	output := bufio.NewWriter(conn)
@@ -170,11 +189,19 @@ Loop:
 			server.RespondToAuthPlain(output)
 		case strings.Contains(msg, "MAIL FROM"):
 			server.RespondToMailFrom(output, msg)
+			if server.ImplicitTLS {
+				server.CurrentDelivery.UsedTLS = true
+				server.CurrentDelivery.ClientCertificates = clientCertificates
+			}
 		case strings.Contains(msg, "RCPT TO"):
 			server.RespondToRcptTo(output, msg)
 		case strings.Contains(msg, "DATA"):
 			server.RespondToData(output)
 			server.RecordData(output, input)
+		case strings.Contains(msg, "RSET"):
+			server.RespondToReset(output)
+		case strings.Contains(msg, "NOOP"):
+			server.RespondToNoop(output)
 		case strings.Contains(msg, "QUIT"):
 			server.RespondToQuit(output)
 			break Loop
@@ -206,15 +233,22 @@ func (server *SMTPServer) RespondToStartTLS(conn net.Conn, input *bufio.Reader,
 
 	server.CurrentDelivery.UsedTLS = true
 
+	tlsConn := tls.Server(conn, server.tlsConfig())
+
+	return tlsConn, bufio.NewReader(tlsConn), bufio.NewWriter(tlsConn)
+}
+
+func (server *SMTPServer) tlsConfig() *tls.Config {
 	cert, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
 	if err != nil {
 		log.Fatalf("server: loadkeys: %s", err)
 	}
-	config := tls.Config{Certificates: []tls.Certificate{cert}}
-	config.Rand = rand.Reader
-	tlsConn := tls.Server(conn, &config)
 
-	return tlsConn, bufio.NewReader(tlsConn), bufio.NewWriter(tlsConn)
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		Rand:         rand.Reader,
+		ClientAuth:   tls.RequestClientCert,
+	}
 }
 
 func (server *SMTPServer) RespondToAuthPlain(output *bufio.Writer) {
@@ -238,6 +272,12 @@ func (server *SMTPServer) RespondToRcptTo(output *bufio.Writer, msg string) {
 	recipient = strings.Trim(recipient, "<>")
 	server.CurrentDelivery.Recipient = recipient
 
+	if server.RcptReply != "" {
+		output.WriteString(server.RcptReply + "\r\n")
+		output.Flush()
+		return
+	}
+
 	output.WriteString("250 OK\r\n")
 	output.Flush()
 }
@@ -263,6 +303,19 @@ func (server *SMTPServer) RecordData(output *bufio.Writer, input *bufio.Reader)
 	output.Flush()
 }
 
+func (server *SMTPServer) RespondToReset(output *bufio.Writer) {
+	server.Deliveries = append(server.Deliveries, server.CurrentDelivery)
+	server.CurrentDelivery = Delivery{}
+
+	output.WriteString("250 OK\r\n")
+	output.Flush()
+}
+
+func (server *SMTPServer) RespondToNoop(output *bufio.Writer) {
+	output.WriteString("250 OK\r\n")
+	output.Flush()
+}
+
 func (server *SMTPServer) RespondToQuit(output *bufio.Writer) {
 	output.WriteString("221 BYE\r\n")
 	output.Flush()
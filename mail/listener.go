@@ -0,0 +1,156 @@
+package mail
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+)
+
+// MessageHandler is given the raw data of every message a Listener receives.
+type MessageHandler func(io.Reader) error
+
+// Listener is a minimal SMTP and LMTP server that hands every message it
+// receives to a MessageHandler. It is meant to sit behind a relay that
+// forwards bounce messages, and does not relay mail anywhere itself.
+type Listener struct {
+	handler  MessageHandler
+	logger   *log.Logger
+	mutex    *sync.Mutex
+	listener net.Listener
+	closed   bool
+}
+
+func NewListener(handler MessageHandler, logger *log.Logger) *Listener {
+	return &Listener{
+		handler: handler,
+		logger:  logger,
+		mutex:   &sync.Mutex{},
+	}
+}
+
+func (l *Listener) Run(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	l.mutex.Lock()
+	if l.closed {
+		l.mutex.Unlock()
+		listener.Close()
+		return nil
+	}
+	l.listener = listener
+	l.mutex.Unlock()
+
+	l.logger.Printf("Listening for bounces on %s\n", listener.Addr())
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			l.mutex.Lock()
+			closed := l.closed
+			l.mutex.Unlock()
+
+			if closed {
+				return nil
+			}
+			return err
+		}
+
+		go l.serve(conn)
+	}
+}
+
+func (l *Listener) Close() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.closed = true
+	if l.listener != nil {
+		l.listener.Close()
+	}
+}
+
+func (l *Listener) serve(conn net.Conn) {
+	defer conn.Close()
+
+	text := textproto.NewConn(conn)
+	reply := func(code int, message string) {
+		text.PrintfLine("%d %s", code, message)
+	}
+
+	reply(220, "notifications ready")
+
+	lmtp := false
+	recipients := 0
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch command {
+		case "HELO", "EHLO":
+			reply(250, "Hello")
+		case "LHLO":
+			lmtp = true
+			reply(250, "Hello")
+		case "MAIL":
+			recipients = 0
+			reply(250, "OK")
+		case "RCPT":
+			recipients++
+			reply(250, "OK")
+		case "DATA":
+			if recipients == 0 {
+				reply(503, "Need RCPT command")
+				continue
+			}
+			reply(354, "End data with <CR><LF>.<CR><LF>")
+
+			code, message := l.receive(text.DotReader())
+
+			replies := 1
+			if lmtp {
+				replies = recipients
+			}
+			for i := 0; i < replies; i++ {
+				reply(code, message)
+			}
+			recipients = 0
+		case "RSET":
+			recipients = 0
+			reply(250, "OK")
+		case "NOOP":
+			reply(250, "OK")
+		case "QUIT":
+			reply(221, "Bye")
+			return
+		default:
+			reply(502, "Command not implemented")
+		}
+	}
+}
+
+func (l *Listener) receive(data io.Reader) (int, string) {
+	err := l.handler(data)
+	io.Copy(ioutil.Discard, data)
+
+	if err != nil {
+		l.logger.Printf("Failed to process received message: %s", err.Error())
+
+		if _, ok := err.(DSNParseError); ok {
+			return 554, fmt.Sprintf("Message rejected: %s", err.Error())
+		}
+		return 451, "Message could not be processed, try again later"
+	}
+
+	return 250, "OK"
+}
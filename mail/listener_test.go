@@ -0,0 +1,149 @@
+package mail_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/smtp"
+	"net/textproto"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Listener", func() {
+	var listener *mail.Listener
+	var address string
+	var received chan string
+	var handlerError error
+
+	BeforeEach(func() {
+		received = make(chan string, 10)
+		handlerError = nil
+
+		handler := func(data io.Reader) error {
+			body, err := ioutil.ReadAll(data)
+			if err != nil {
+				return err
+			}
+			received <- string(body)
+
+			return handlerError
+		}
+
+		tcpListener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			panic(err)
+		}
+		address = tcpListener.Addr().String()
+		tcpListener.Close()
+
+		listener = mail.NewListener(handler, log.New(bytes.NewBuffer([]byte{}), "", 0))
+		go listener.Run(address)
+
+		Eventually(func() error {
+			conn, err := net.Dial("tcp", address)
+			if err == nil {
+				conn.Close()
+			}
+			return err
+		}).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		listener.Close()
+	})
+
+	send := func() error {
+		client, err := smtp.Dial(address)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		err = client.Mail("MAILER-DAEMON@relay.example.com")
+		if err != nil {
+			return err
+		}
+
+		err = client.Rcpt("bounces@notifications.example.com")
+		if err != nil {
+			return err
+		}
+
+		writer, err := client.Data()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprint(writer, "Subject: bounce\r\n\r\nthe bounce\r\n")
+
+		err = writer.Close()
+		if err != nil {
+			return err
+		}
+
+		return client.Quit()
+	}
+
+	It("hands each message received over SMTP to the handler", func() {
+		err := send()
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(received).Should(Receive(Equal("Subject: bounce\n\nthe bounce\n")))
+	})
+
+	It("rejects messages that are not bounces", func() {
+		handlerError = mail.DSNParseError("message is not a multipart report")
+
+		err := send()
+		Expect(err).To(BeAssignableToTypeOf(&textproto.Error{}))
+		Expect(err.(*textproto.Error).Code).To(Equal(554))
+	})
+
+	It("asks the sender to try again when the message cannot be processed", func() {
+		handlerError = errors.New("database is down")
+
+		err := send()
+		Expect(err).To(BeAssignableToTypeOf(&textproto.Error{}))
+		Expect(err.(*textproto.Error).Code).To(Equal(451))
+	})
+
+	It("replies once per recipient over LMTP", func() {
+		conn, err := textproto.Dial("tcp", address)
+		if err != nil {
+			panic(err)
+		}
+		defer conn.Close()
+
+		_, _, err = conn.ReadResponse(220)
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, command := range []string{"LHLO relay.example.com", "MAIL FROM:<>", "RCPT TO:<one@example.com>", "RCPT TO:<two@example.com>"} {
+			_, err = conn.Cmd("%s", command)
+			Expect(err).NotTo(HaveOccurred())
+			_, _, err = conn.ReadResponse(250)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		_, err = conn.Cmd("DATA")
+		Expect(err).NotTo(HaveOccurred())
+		_, _, err = conn.ReadResponse(354)
+		Expect(err).NotTo(HaveOccurred())
+
+		writer := conn.DotWriter()
+		fmt.Fprint(writer, "Subject: bounce\r\n\r\nthe bounce\r\n")
+		writer.Close()
+
+		_, _, err = conn.ReadResponse(250)
+		Expect(err).NotTo(HaveOccurred())
+		_, _, err = conn.ReadResponse(250)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -2,38 +2,40 @@ package mail
 
 import (
 	"bytes"
-	"io/ioutil"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
 	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	netmail "net/mail"
+	"net/textproto"
+	"sort"
 	"strings"
-	"text/template"
-
-	"gopkg.in/gomail.v1"
+	"time"
 )
 
-const emailTemplate = `{{range .Headers}}{{.}}
-{{end}}Date: {{.Date}}
-Mime-Version: {{.MimeVersion}}
-Content-Type: {{.ContentType}}
-{{if .ContentTransferEncoding}}Content-Transfer-Encoding: {{.ContentTransferEncoding}}
-{{end}}From: {{.From}}{{if .ReplyTo}}
-Reply-To: {{.ReplyTo}}{{end}}
-To: {{.To}}
-Subject: {{.Subject}}
+// maxLineLength is the length that header fields are folded at, as
+// recommended by RFC 5322.
+const maxLineLength = 78
 
-{{.CompiledBody}}`
+// base64LineLength is the longest line of base64 encoded content allowed by
+// RFC 2045.
+const base64LineLength = 76
 
 type Message struct {
-	Date                    string
-	MimeVersion             string
-	ContentType             string
-	ContentTransferEncoding string
-	From                    string
-	ReplyTo                 string
-	To                      string
-	Subject                 string
-	Body                    []Part
-	Headers                 []string
-	CompiledBody            string
+	// ID is the notification ID, which the Message-ID is derived from.
+	ID          string
+	Date        time.Time
+	From        string
+	ReplyTo     string
+	To          string
+	Subject     string
+	Body        []Part
+	Attachments []Attachment
+	Headers     []string
 }
 
 type Part struct {
@@ -41,53 +43,322 @@ type Part struct {
 	Content     string
 }
 
-func (msg *Message) Data() string {
-	buf := bytes.NewBuffer([]byte{})
+// Attachment is a file sent along with the message. Attachments with a
+// ContentID are shown inline, and can be referred to from the HTML part as
+// "cid:<ContentID>".
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+	ContentID   string
+}
+
+// entity is a MIME entity: the header fields that describe its content and
+// a function that writes the content.
+type entity struct {
+	header textproto.MIMEHeader
+	body   func(io.Writer) error
+}
+
+// Data returns the message as it is written by WriteTo.
+func (msg Message) Data() string {
+	buffer := bytes.NewBuffer([]byte{})
 
-	err := msg.CompileBody()
+	_, err := msg.WriteTo(buffer)
 	if err != nil {
 		panic(err)
 	}
 
-	tmpl, err := template.New("test").Parse(emailTemplate)
+	return buffer.String()
+}
+
+// WriteTo writes the message in MIME format, with CRLF line endings, encoded
+// headers, quoted-printable bodies and base64 attachments.
+func (msg Message) WriteTo(w io.Writer) (int64, error) {
+	writer := &countingWriter{writer: w}
+	content := msg.content()
+
+	fields := msg.header()
+	names := []string{}
+	for name := range content.header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fields = append(fields, [2]string{name, content.header.Get(name)})
+	}
+
+	for _, field := range fields {
+		_, err := io.WriteString(writer, foldHeader(field[0], field[1]))
+		if err != nil {
+			return writer.count, err
+		}
+	}
+
+	_, err := io.WriteString(writer, "\r\n")
 	if err != nil {
-		panic(err)
+		return writer.count, err
+	}
+
+	err = content.body(writer)
+
+	return writer.count, err
+}
+
+// MessageID is derived from the notification ID so that a message keeps its
+// Message-ID when its delivery is retried.
+func (msg Message) MessageID() string {
+	id := msg.ID
+	if id == "" {
+		id = randomID()
+	}
+
+	domain := "localhost"
+	if address, err := netmail.ParseAddress(msg.From); err == nil {
+		domain = address.Address[strings.LastIndex(address.Address, "@")+1:]
 	}
 
-	err = tmpl.Execute(buf, msg)
+	return fmt.Sprintf("<%s@%s>", id, domain)
+}
+
+func (msg Message) header() [][2]string {
+	fields := [][2]string{}
+	for _, header := range msg.Headers {
+		parts := strings.SplitN(header, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		fields = append(fields, [2]string{strings.TrimSpace(parts[0]), encodeText(parts[1])})
+	}
+
+	date := msg.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	fields = append(fields,
+		[2]string{"Message-ID", msg.MessageID()},
+		[2]string{"Date", date.Format(time.RFC1123Z)},
+		[2]string{"From", encodeAddress(msg.From)},
+	)
+
+	if msg.ReplyTo != "" {
+		fields = append(fields, [2]string{"Reply-To", encodeAddress(msg.ReplyTo)})
+	}
+
+	return append(fields,
+		[2]string{"To", encodeAddress(msg.To)},
+		[2]string{"Subject", encodeText(msg.Subject)},
+		[2]string{"MIME-Version", "1.0"},
+	)
+}
+
+// content nests the body parts as multipart/alternative, inside
+// multipart/related when there are inline attachments, inside
+// multipart/mixed when there are other attachments.
+func (msg Message) content() entity {
+	var content entity
+	switch len(msg.Body) {
+	case 0:
+		content = partEntity(Part{ContentType: "text/plain"})
+	case 1:
+		content = partEntity(msg.Body[0])
+	default:
+		parts := []entity{}
+		for _, part := range msg.Body {
+			parts = append(parts, partEntity(part))
+		}
+		content = multipartEntity("multipart/alternative", parts)
+	}
+
+	inline := []entity{content}
+	attached := []entity{}
+	for _, attachment := range msg.Attachments {
+		if attachment.ContentID != "" {
+			inline = append(inline, attachmentEntity(attachment))
+		} else {
+			attached = append(attached, attachmentEntity(attachment))
+		}
+	}
+
+	if len(inline) > 1 {
+		content = multipartEntity("multipart/related", inline)
+	}
+
+	if len(attached) > 0 {
+		content = multipartEntity("multipart/mixed", append([]entity{content}, attached...))
+	}
+
+	return content
+}
+
+func partEntity(part Part) entity {
+	return entity{
+		header: textproto.MIMEHeader{
+			"Content-Type":              {partContentType(part)},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		},
+		body: func(w io.Writer) error {
+			return writeQuotedPrintable(w, part.Content)
+		},
+	}
+}
+
+func attachmentEntity(attachment Attachment) entity {
+	contentType := mime.FormatMediaType(attachment.ContentType, map[string]string{"name": attachment.Filename})
+	if contentType == "" {
+		contentType = mime.FormatMediaType("application/octet-stream", map[string]string{"name": attachment.Filename})
+	}
+
+	disposition := "attachment"
+	if attachment.ContentID != "" {
+		disposition = "inline"
+	}
+
+	header := textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"base64"},
+		"Content-Disposition":       {mime.FormatMediaType(disposition, map[string]string{"filename": attachment.Filename})},
+	}
+	if attachment.ContentID != "" {
+		header.Set("Content-ID", "<"+attachment.ContentID+">")
+	}
+
+	return entity{
+		header: header,
+		body: func(w io.Writer) error {
+			return writeBase64(w, attachment.Content)
+		},
+	}
+}
+
+func multipartEntity(mediaType string, parts []entity) entity {
+	boundary := randomID()
+
+	return entity{
+		header: textproto.MIMEHeader{
+			"Content-Type": {mime.FormatMediaType(mediaType, map[string]string{"boundary": boundary})},
+		},
+		body: func(w io.Writer) error {
+			writer := multipart.NewWriter(w)
+			err := writer.SetBoundary(boundary)
+			if err != nil {
+				return err
+			}
+
+			for _, part := range parts {
+				partWriter, err := writer.CreatePart(part.header)
+				if err != nil {
+					return err
+				}
+
+				err = part.body(partWriter)
+				if err != nil {
+					return err
+				}
+			}
+
+			return writer.Close()
+		},
+	}
+}
+
+func writeBase64(w io.Writer, content []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(content)
+	for len(encoded) > 0 {
+		length := base64LineLength
+		if len(encoded) < length {
+			length = len(encoded)
+		}
+
+		_, err := io.WriteString(w, encoded[:length]+"\r\n")
+		if err != nil {
+			return err
+		}
+		encoded = encoded[length:]
+	}
+
+	return nil
+}
+
+func writeQuotedPrintable(w io.Writer, content string) error {
+	writer := quotedprintable.NewWriter(w)
+
+	_, err := io.WriteString(writer, content)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
-	return buf.String()
+	return writer.Close()
 }
 
-func (msg *Message) CompileBody() error {
-	message := gomail.NewMessage()
-	for _, part := range msg.Body {
-		message.AddAlternative(part.ContentType, part.Content)
+func partContentType(part Part) string {
+	return mime.FormatMediaType(part.ContentType, map[string]string{"charset": "UTF-8"})
+}
+
+// lineBreaks are replaced in header fields so that they cannot start new
+// fields.
+var lineBreaks = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ")
+
+// foldHeader returns a header field, ending in CRLF, with lines longer than
+// maxLineLength broken before whitespace.
+func foldHeader(name, value string) string {
+	value = lineBreaks.Replace(strings.TrimSpace(value))
+
+	folded := bytes.NewBufferString(lineBreaks.Replace(name) + ":")
+	lineLength := folded.Len()
+	for i, word := range strings.Split(value, " ") {
+		if i > 0 && lineLength+len(word)+1 > maxLineLength {
+			folded.WriteString("\r\n")
+			lineLength = 0
+		}
+		folded.WriteString(" " + word)
+		lineLength += len(word) + 1
 	}
+	folded.WriteString("\r\n")
+
+	return folded.String()
+}
 
-	m := message.Export()
-	body, err := ioutil.ReadAll(m.Body)
+// encodeText encodes header text that is not plain ASCII as RFC 2047 encoded
+// words.
+func encodeText(text string) string {
+	return mime.QEncoding.Encode("utf-8", lineBreaks.Replace(strings.TrimSpace(text)))
+}
+
+// encodeAddress encodes the display name of an address. Addresses that cannot
+// be parsed are written as they are.
+func encodeAddress(text string) string {
+	address, err := netmail.ParseAddress(text)
 	if err != nil {
-		panic(err)
+		return encodeText(text)
 	}
 
-	msg.CompiledBody = strings.Replace(string(body), "\r\n", "\n", -1)
-	msg.Date = m.Header.Get("Date")
-	msg.MimeVersion = m.Header.Get("Mime-Version")
-	msg.ContentType = m.Header.Get("Content-Type")
-	msg.ContentTransferEncoding = m.Header.Get("Content-Transfer-Encoding")
+	if address.Name == "" {
+		return address.Address
+	}
 
-	return nil
+	return address.String()
 }
 
-func (msg Message) Boundary() string {
-	_, params, err := mime.ParseMediaType(msg.ContentType)
+func randomID() string {
+	id := make([]byte, 16)
+	_, err := rand.Read(id)
 	if err != nil {
 		panic(err)
 	}
 
-	return params["boundary"]
+	return hex.EncodeToString(id)
+}
+
+type countingWriter struct {
+	writer io.Writer
+	count  int64
+}
+
+func (writer *countingWriter) Write(p []byte) (int, error) {
+	n, err := writer.writer.Write(p)
+	writer.count += int64(n)
+
+	return n, err
 }
@@ -1,6 +1,13 @@
 package mail_test
 
 import (
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	netmail "net/mail"
 	"strings"
 	"time"
 
@@ -11,135 +18,291 @@ import (
 )
 
 var _ = Describe("Message", func() {
+	var msg mail.Message
+
+	parse := func(data string) *netmail.Message {
+		parsed, err := netmail.ReadMessage(strings.NewReader(data))
+		if err != nil {
+			panic(err)
+		}
+
+		return parsed
+	}
+
+	decode := func(header string) string {
+		decoded, err := new(mime.WordDecoder).DecodeHeader(header)
+		if err != nil {
+			panic(err)
+		}
+
+		return decoded
+	}
+
+	BeforeEach(func() {
+		msg = mail.Message{
+			ID:      "notification-123",
+			Date:    time.Date(2015, time.January, 20, 20, 23, 38, 0, time.UTC),
+			From:    "me@example.com",
+			To:      "you@example.com",
+			Subject: "Super Urgent! Read Now!",
+			Body: []mail.Part{
+				{
+					ContentType: "text/plain",
+					Content:     "Banana",
+				},
+				{
+					ContentType: "text/html",
+					Content:     "<header>banana</header>",
+				},
+			},
+		}
+	})
+
 	Describe("Data", func() {
-		var msg mail.Message
+		It("writes the headers followed by the alternative parts", func() {
+			parsed := parse(msg.Data())
+
+			Expect(parsed.Header.Get("Message-ID")).To(Equal("<notification-123@example.com>"))
+			Expect(parsed.Header.Get("Date")).To(Equal("Tue, 20 Jan 2015 20:23:38 +0000"))
+			Expect(parsed.Header.Get("From")).To(Equal("me@example.com"))
+			Expect(parsed.Header.Get("To")).To(Equal("you@example.com"))
+			Expect(parsed.Header.Get("Subject")).To(Equal("Super Urgent! Read Now!"))
+			Expect(parsed.Header.Get("MIME-Version")).To(Equal("1.0"))
+			Expect(parsed.Header).NotTo(HaveKey("Reply-To"))
+
+			mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mediaType).To(Equal("multipart/alternative"))
+
+			reader := multipart.NewReader(parsed.Body, params["boundary"])
+			for _, expected := range msg.Body {
+				part, err := reader.NextPart()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(part.Header.Get("Content-Type")).To(Equal(expected.ContentType + "; charset=UTF-8"))
+
+				content, err := ioutil.ReadAll(part)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(content)).To(Equal(expected.Content))
+			}
+
+			_, err = reader.NextPart()
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("ends every line with CRLF", func() {
+			data := msg.Data()
+
+			Expect(strings.Count(data, "\n")).To(BeNumerically(">", 10))
+			Expect(strings.Count(data, "\n")).To(Equal(strings.Count(data, "\r\n")))
+		})
+
+		It("includes Reply-To and extra headers", func() {
+			msg.ReplyTo = "banana@chiquita.com"
+			msg.Headers = append(msg.Headers, "X-ClientID: banana")
+
+			parsed := parse(msg.Data())
+
+			Expect(parsed.Header.Get("Reply-To")).To(Equal("banana@chiquita.com"))
+			Expect(parsed.Header.Get("X-ClientID")).To(Equal("banana"))
+		})
+
+		It("writes a single part without a multipart wrapper", func() {
+			msg.Body = []mail.Part{
+				{
+					ContentType: "text/html",
+					Content:     "<header>banana</header>",
+				},
+			}
+
+			parsed := parse(msg.Data())
+
+			Expect(parsed.Header.Get("Content-Type")).To(Equal("text/html; charset=UTF-8"))
+			Expect(parsed.Header.Get("Content-Transfer-Encoding")).To(Equal("quoted-printable"))
+
+			body, err := ioutil.ReadAll(quotedprintable.NewReader(parsed.Body))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(body)).To(Equal("<header>banana</header>"))
+		})
+
+		It("encodes non-ASCII subjects and display names", func() {
+			msg.Subject = "Café résumé ☕"
+			msg.From = "Zoë Notifications <no-reply@example.com>"
+			msg.To = "you@example.com"
+
+			data := msg.Data()
+			parsed := parse(data)
+
+			Expect(data).NotTo(ContainSubstring("Café"))
+			Expect(decode(parsed.Header.Get("Subject"))).To(Equal("Café résumé ☕"))
+
+			from, err := parsed.Header.AddressList("From")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(from[0].Name).To(Equal("Zoë Notifications"))
+			Expect(from[0].Address).To(Equal("no-reply@example.com"))
+		})
+
+		It("folds long header lines", func() {
+			msg.Subject = strings.Repeat("A very long subject ", 10)
+
+			data := msg.Data()
+			head := data[:strings.Index(data, "\r\n\r\n")]
+			for _, line := range strings.Split(head, "\r\n") {
+				Expect(len(line)).To(BeNumerically("<=", 78))
+			}
+
+			Expect(parse(data).Header.Get("Subject")).To(Equal(strings.TrimSpace(msg.Subject)))
+		})
+
+		It("does not let header values start new headers", func() {
+			msg.Subject = "Hello\r\nBcc: everyone@example.com"
+
+			parsed := parse(msg.Data())
+
+			Expect(parsed.Header).NotTo(HaveKey("Bcc"))
+			Expect(parsed.Header.Get("Subject")).To(Equal("Hello Bcc: everyone@example.com"))
+		})
+
+		It("wraps long body lines", func() {
+			msg.Body = []mail.Part{
+				{
+					ContentType: "text/plain",
+					Content:     strings.Repeat("100% banana ", 20),
+				},
+			}
+
+			parsed := parse(msg.Data())
+			raw, err := ioutil.ReadAll(parsed.Body)
+			Expect(err).NotTo(HaveOccurred())
+			for _, line := range strings.Split(string(raw), "\r\n") {
+				Expect(len(line)).To(BeNumerically("<=", 76))
+			}
+
+			body, err := ioutil.ReadAll(quotedprintable.NewReader(bytes.NewReader(raw)))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(body)).To(Equal(strings.Repeat("100% banana ", 20)))
+		})
+	})
+
+	Describe("attachments", func() {
+		nextPart := func(reader *multipart.Reader) (*multipart.Part, string) {
+			part, err := reader.NextPart()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, params, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+			Expect(err).NotTo(HaveOccurred())
+
+			return part, params["boundary"]
+		}
 
 		BeforeEach(func() {
-			msg = mail.Message{
-				From:    "me@example.com",
-				To:      "you@example.com",
-				Subject: "Super Urgent! Read Now!",
-				Body: []mail.Part{
-					{
-						ContentType: "text/plain",
-						Content:     "Banana",
-					},
-					{
-						ContentType: "text/html",
-						Content:     "<header>banana</header>",
-					},
+			msg.Attachments = []mail.Attachment{
+				{
+					Filename:    "invoice.pdf",
+					ContentType: "application/pdf",
+					Content:     []byte(strings.Repeat("%PDF-1.4 ", 20)),
 				},
 			}
 		})
 
-		It("returns a populated data mail field as a string", func() {
-			parts := strings.Split(msg.Data(), "\n")
-			boundary := msg.Boundary()
-
-			Expect(parts).To(ConsistOf([]string{
-				"From: me@example.com",
-				"To: you@example.com",
-				"Subject: Super Urgent! Read Now!",
-				"Content-Type: multipart/alternative; boundary=" + boundary,
-				"Date: " + time.Now().Format(time.RFC822Z),
-				"Mime-Version: 1.0",
-				"",
-				"--" + boundary,
-				"Content-Type: text/plain; charset=UTF-8",
-				"Content-Transfer-Encoding: quoted-printable",
-				"",
-				"Banana",
-				"--" + boundary,
-				"Content-Type: text/html; charset=UTF-8",
-				"Content-Transfer-Encoding: quoted-printable",
-				"",
-				"<header>banana</header>",
-				"--" + boundary + "--",
-				"",
-			}))
-		})
-
-		Context("when optional fields are present", func() {
-			It("includes Reply-To in message body", func() {
-				msg.ReplyTo = "banana@chiquita.com"
-				parts := strings.Split(msg.Data(), "\n")
-				boundary := msg.Boundary()
-
-				Expect(parts).To(ConsistOf([]string{
-					"From: me@example.com",
-					"Reply-To: banana@chiquita.com",
-					"To: you@example.com",
-					"Subject: Super Urgent! Read Now!",
-					"Content-Type: multipart/alternative; boundary=" + boundary,
-					"Date: " + time.Now().Format(time.RFC822Z),
-					"Mime-Version: 1.0",
-					"",
-					"--" + boundary,
-					"Content-Type: text/plain; charset=UTF-8",
-					"Content-Transfer-Encoding: quoted-printable",
-					"",
-					"Banana",
-					"--" + boundary,
-					"Content-Type: text/html; charset=UTF-8",
-					"Content-Transfer-Encoding: quoted-printable",
-					"",
-					"<header>banana</header>",
-					"--" + boundary + "--",
-					"",
-				}))
-			})
+		It("wraps the body and attachments in multipart/mixed", func() {
+			parsed := parse(msg.Data())
 
-			It("includes headers in the response if there are any", func() {
-				msg.Headers = append(msg.Headers, "X-ClientID: banana")
-				parts := strings.Split(msg.Data(), "\n")
-				boundary := msg.Boundary()
-
-				Expect(parts).To(ConsistOf([]string{
-					"From: me@example.com",
-					"To: you@example.com",
-					"Subject: Super Urgent! Read Now!",
-					"X-ClientID: banana",
-					"Content-Type: multipart/alternative; boundary=" + boundary,
-					"Date: " + time.Now().Format(time.RFC822Z),
-					"Mime-Version: 1.0",
-					"",
-					"--" + boundary,
-					"Content-Type: text/plain; charset=UTF-8",
-					"Content-Transfer-Encoding: quoted-printable",
-					"",
-					"Banana",
-					"--" + boundary,
-					"Content-Type: text/html; charset=UTF-8",
-					"Content-Transfer-Encoding: quoted-printable",
-					"",
-					"<header>banana</header>",
-					"--" + boundary + "--",
-					"",
-				}))
-			})
+			mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mediaType).To(Equal("multipart/mixed"))
+
+			reader := multipart.NewReader(parsed.Body, params["boundary"])
+			body, _ := nextPart(reader)
+			Expect(body.Header.Get("Content-Type")).To(MatchRegexp(`^multipart/alternative; boundary=`))
+
+			attachment, _ := nextPart(reader)
+			Expect(attachment.Header.Get("Content-Type")).To(Equal(`application/pdf; name=invoice.pdf`))
+			Expect(attachment.Header.Get("Content-Disposition")).To(Equal(`attachment; filename=invoice.pdf`))
+			Expect(attachment.Header.Get("Content-Transfer-Encoding")).To(Equal("base64"))
+
+			raw, err := ioutil.ReadAll(attachment)
+			Expect(err).NotTo(HaveOccurred())
+			for _, line := range strings.Split(strings.TrimSpace(string(raw)), "\r\n") {
+				Expect(len(line)).To(BeNumerically("<=", 76))
+			}
 
-			It("includes only the parts necessary", func() {
-				msg.Body = []mail.Part{
-					{
-						ContentType: "text/html",
-						Content:     "<header>banana</header>",
-					},
-				}
-
-				parts := strings.Split(msg.Data(), "\n")
-
-				Expect(parts).To(Equal([]string{
-					"Date: " + time.Now().Format(time.RFC822Z),
-					"Mime-Version: 1.0",
-					"Content-Type: text/html; charset=UTF-8",
-					"Content-Transfer-Encoding: quoted-printable",
-					"From: me@example.com",
-					"To: you@example.com",
-					"Subject: Super Urgent! Read Now!",
-					"",
-					"<header>banana</header>",
-				}))
+			content, err := base64.StdEncoding.DecodeString(strings.Replace(string(raw), "\r\n", "", -1))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(content).To(Equal(msg.Attachments[0].Content))
+
+			_, err = reader.NextPart()
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("keeps inline attachments with the body in multipart/related", func() {
+			msg.Attachments = append(msg.Attachments, mail.Attachment{
+				Filename:    "logo.png",
+				ContentType: "image/png",
+				Content:     []byte("PNG"),
+				ContentID:   "logo",
 			})
+
+			parsed := parse(msg.Data())
+			_, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+			Expect(err).NotTo(HaveOccurred())
+
+			mixed := multipart.NewReader(parsed.Body, params["boundary"])
+			related, boundary := nextPart(mixed)
+			Expect(related.Header.Get("Content-Type")).To(MatchRegexp(`^multipart/related; boundary=`))
+
+			relatedReader := multipart.NewReader(related, boundary)
+			alternative, _ := nextPart(relatedReader)
+			Expect(alternative.Header.Get("Content-Type")).To(MatchRegexp(`^multipart/alternative; boundary=`))
+
+			logo, _ := nextPart(relatedReader)
+			Expect(logo.Header.Get("Content-ID")).To(Equal("<logo>"))
+			Expect(logo.Header.Get("Content-Disposition")).To(Equal(`inline; filename=logo.png`))
+
+			invoice, _ := nextPart(mixed)
+			Expect(invoice.Header.Get("Content-Disposition")).To(Equal(`attachment; filename=invoice.pdf`))
+		})
+
+		It("uses multipart/related alone when every attachment is inline", func() {
+			msg.Attachments[0].ContentID = "invoice"
+
+			parsed := parse(msg.Data())
+
+			Expect(parsed.Header.Get("Content-Type")).To(MatchRegexp(`^multipart/related; boundary=`))
+		})
+
+		It("falls back to application/octet-stream for unknown content types", func() {
+			msg.Body = msg.Body[:1]
+			msg.Attachments[0].ContentType = ""
+
+			Expect(msg.Data()).To(ContainSubstring("Content-Type: application/octet-stream; name=invoice.pdf\r\n"))
+		})
+	})
+
+	Describe("MessageID", func() {
+		It("is derived from the notification ID and the sender domain", func() {
+			msg.From = "Notifications <no-reply@notifications.example.com>"
+
+			Expect(msg.MessageID()).To(Equal("<notification-123@notifications.example.com>"))
+			Expect(msg.MessageID()).To(Equal(msg.MessageID()))
+		})
+
+		It("is random when there is no notification ID", func() {
+			msg.ID = ""
+
+			Expect(msg.MessageID()).To(MatchRegexp(`^<[0-9a-f]{32}@example\.com>$`))
+			Expect(msg.MessageID()).NotTo(Equal(msg.MessageID()))
+		})
+	})
+
+	Describe("WriteTo", func() {
+		It("streams the same data and reports how much was written", func() {
+			msg.Body = msg.Body[:1]
+			buffer := bytes.NewBuffer([]byte{})
+
+			n, err := msg.WriteTo(buffer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(buffer.Len())))
+			Expect(buffer.String()).To(Equal(msg.Data()))
 		})
 	})
 })
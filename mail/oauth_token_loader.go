@@ -0,0 +1,125 @@
+package mail
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/metrics"
+)
+
+type OAuthConfig struct {
+	TokenURL      string
+	ClientID      string
+	ClientSecret  string
+	Scope         string
+	SkipVerifySSL bool
+}
+
+// OAuthTokenLoader fetches bearer tokens from an OAuth2 token endpoint using
+// the client credentials grant. The token is cached and only fetched again
+// once it is about to expire.
+type OAuthTokenLoader struct {
+	config    OAuthConfig
+	client    *http.Client
+	mutex     *sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+type oauthTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+func NewOAuthTokenLoader(config OAuthConfig) *OAuthTokenLoader {
+	return &OAuthTokenLoader{
+		config: config,
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: config.SkipVerifySSL,
+				},
+			},
+		},
+		mutex: &sync.Mutex{},
+	}
+}
+
+func (loader *OAuthTokenLoader) Token() (string, error) {
+	loader.mutex.Lock()
+	defer loader.mutex.Unlock()
+
+	if loader.newTokenRequired() {
+		err := loader.fetch()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return loader.token, nil
+}
+
+func (loader *OAuthTokenLoader) newTokenRequired() bool {
+	toleratedRequestTime := 30 * time.Second
+
+	return loader.token == "" || time.Now().Add(toleratedRequestTime).After(loader.expiresAt)
+}
+
+func (loader *OAuthTokenLoader) fetch() error {
+	form := url.Values{
+		"grant_type": {"client_credentials"},
+	}
+	if loader.config.Scope != "" {
+		form.Set("scope", loader.config.Scope)
+	}
+
+	request, err := http.NewRequest("POST", loader.config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	request.SetBasicAuth(url.QueryEscape(loader.config.ClientID), url.QueryEscape(loader.config.ClientSecret))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+
+	then := time.Now()
+
+	response, err := loader.client.Do(request)
+
+	duration := time.Now().Sub(then)
+
+	metrics.NewMetric("histogram", map[string]interface{}{
+		"name":  "notifications.external-requests.smtp.oauth-token",
+		"value": duration.Seconds(),
+	}).Log()
+
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("SMTP OAuth token request failed with status %d", response.StatusCode)
+	}
+
+	var token oauthTokenResponse
+	err = json.NewDecoder(response.Body).Decode(&token)
+	if err != nil {
+		return err
+	}
+
+	if token.AccessToken == "" {
+		return fmt.Errorf("SMTP OAuth token response did not include an access token")
+	}
+
+	loader.token = token.AccessToken
+	loader.expiresAt = then.Add(time.Duration(token.ExpiresIn) * time.Second)
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package mail_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OAuthTokenLoader", func() {
+	var server *httptest.Server
+	var loader *mail.OAuthTokenLoader
+	var requests []*http.Request
+	var expiresIn int
+	var status int
+
+	BeforeEach(func() {
+		requests = []*http.Request{}
+		expiresIn = 3600
+		status = http.StatusOK
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			req.ParseForm()
+			requests = append(requests, req)
+
+			w.WriteHeader(status)
+			fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":%d}`, len(requests), expiresIn)
+		}))
+
+		loader = mail.NewOAuthTokenLoader(mail.OAuthConfig{
+			TokenURL:     server.URL + "/oauth/token",
+			ClientID:     "smtp-client",
+			ClientSecret: "smtp-secret",
+			Scope:        "smtp.send",
+		})
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("fetches a token with the client credentials grant", func() {
+		token, err := loader.Token()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).To(Equal("token-1"))
+
+		Expect(requests).To(HaveLen(1))
+		request := requests[0]
+		Expect(request.Method).To(Equal("POST"))
+		Expect(request.URL.Path).To(Equal("/oauth/token"))
+		Expect(request.PostForm.Get("grant_type")).To(Equal("client_credentials"))
+		Expect(request.PostForm.Get("scope")).To(Equal("smtp.send"))
+
+		username, password, ok := request.BasicAuth()
+		Expect(ok).To(BeTrue())
+		Expect(username).To(Equal("smtp-client"))
+		Expect(password).To(Equal("smtp-secret"))
+	})
+
+	It("reuses the token until it is about to expire", func() {
+		_, err := loader.Token()
+		Expect(err).NotTo(HaveOccurred())
+
+		token, err := loader.Token()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).To(Equal("token-1"))
+		Expect(requests).To(HaveLen(1))
+	})
+
+	It("fetches a new token once the old one is about to expire", func() {
+		expiresIn = 10
+
+		_, err := loader.Token()
+		Expect(err).NotTo(HaveOccurred())
+
+		token, err := loader.Token()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).To(Equal("token-2"))
+		Expect(requests).To(HaveLen(2))
+	})
+
+	It("returns an error when the token endpoint rejects the request", func() {
+		status = http.StatusUnauthorized
+
+		_, err := loader.Token()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("401"))
+	})
+})
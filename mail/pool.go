@@ -0,0 +1,271 @@
+package mail
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/metrics"
+)
+
+type PoolConfig struct {
+	MaxConnections           int
+	MaxIdleTime              time.Duration
+	MaxMessagesPerConnection int
+}
+
+type PoolStats struct {
+	Open     int
+	Idle     int
+	Dialed   int
+	Reused   int
+	Recycled int
+}
+
+// Pool delivers messages over a set of authenticated SMTP sessions that are
+// shared by every caller. Sessions are reset between messages rather than
+// closed, so the handshake is only paid when a new session is dialed. A
+// session is recycled once it has been idle for too long, has delivered too
+// many messages, or stops answering the server.
+type Pool struct {
+	config     Config
+	poolConfig PoolConfig
+	logger     *log.Logger
+	slots      chan bool
+	mutex      *sync.Mutex
+	idle       []*session
+	stats      PoolStats
+	closed     bool
+}
+
+type session struct {
+	client   *Client
+	sent     int
+	lastUsed time.Time
+}
+
+func NewPool(config Config, poolConfig PoolConfig, logger *log.Logger) *Pool {
+	if poolConfig.MaxConnections == 0 {
+		poolConfig.MaxConnections = 10
+	}
+
+	if poolConfig.MaxIdleTime == 0 {
+		poolConfig.MaxIdleTime = 30 * time.Second
+	}
+
+	if poolConfig.MaxMessagesPerConnection == 0 {
+		poolConfig.MaxMessagesPerConnection = 100
+	}
+
+	return &Pool{
+		config:     config,
+		poolConfig: poolConfig,
+		logger:     logger,
+		slots:      make(chan bool, poolConfig.MaxConnections),
+		mutex:      &sync.Mutex{},
+	}
+}
+
+// Connect makes sure that the SMTP server can be reached, dialing a new
+// session only if there is no idle one to reuse.
+func (p *Pool) Connect() error {
+	if p.config.TestMode {
+		return nil
+	}
+
+	p.slots <- true
+	defer func() { <-p.slots }()
+
+	p.mutex.Lock()
+	idle := len(p.idle)
+	p.mutex.Unlock()
+
+	if idle > 0 {
+		return nil
+	}
+
+	s, err := p.dial()
+	if err != nil {
+		return err
+	}
+
+	p.checkin(s)
+
+	return nil
+}
+
+func (p *Pool) Send(msg Message) error {
+	if p.config.TestMode {
+		p.logger.Println("TEST_MODE is enabled, emails not being sent")
+		return nil
+	}
+
+	p.slots <- true
+	defer func() { <-p.slots }()
+
+	s, err := p.checkout()
+	if err != nil {
+		return err
+	}
+
+	// A session whose message was rejected is still usable, and is reset
+	// when it is checked back in.
+	err = s.client.Deliver(msg)
+	if err != nil && !undeliverable(err) {
+		p.discard()
+		return err
+	}
+
+	s.sent++
+	p.checkin(s)
+
+	return err
+}
+
+// Close ends every idle session. Sessions that are delivering a message are
+// ended once they are done.
+func (p *Pool) Close() {
+	p.mutex.Lock()
+	p.closed = true
+	idle := p.idle
+	p.idle = nil
+	p.mutex.Unlock()
+
+	for _, s := range idle {
+		p.recycle(s)
+	}
+}
+
+func (p *Pool) Stats() PoolStats {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	stats := p.stats
+	stats.Idle = len(p.idle)
+
+	return stats
+}
+
+func (p *Pool) checkout() (*session, error) {
+	for {
+		s := p.popIdle()
+		if s == nil {
+			break
+		}
+
+		err := s.client.Noop()
+		if err != nil {
+			p.logger.Printf("Recycling broken SMTP connection: %s", err.Error())
+			p.recycle(s)
+			continue
+		}
+
+		p.count("reused", &p.stats.Reused)
+		return s, nil
+	}
+
+	return p.dial()
+}
+
+func (p *Pool) popIdle() *session {
+	p.mutex.Lock()
+	var expired, fresh []*session
+	for _, s := range p.idle {
+		if time.Since(s.lastUsed) > p.poolConfig.MaxIdleTime {
+			expired = append(expired, s)
+		} else {
+			fresh = append(fresh, s)
+		}
+	}
+
+	var s *session
+	if len(fresh) > 0 {
+		s = fresh[len(fresh)-1]
+		fresh = fresh[:len(fresh)-1]
+	}
+	p.idle = fresh
+	p.mutex.Unlock()
+
+	for _, stale := range expired {
+		p.recycle(stale)
+	}
+
+	return s
+}
+
+func (p *Pool) dial() (*session, error) {
+	client, err := NewClient(p.config, p.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	p.mutex.Lock()
+	p.stats.Open++
+	p.mutex.Unlock()
+	p.count("dialed", &p.stats.Dialed)
+	p.gauge()
+
+	return &session{client: client}, nil
+}
+
+func (p *Pool) checkin(s *session) {
+	p.mutex.Lock()
+	closed := p.closed
+	p.mutex.Unlock()
+
+	if closed || s.sent >= p.poolConfig.MaxMessagesPerConnection {
+		p.recycle(s)
+		return
+	}
+
+	err := s.client.Reset()
+	if err != nil {
+		p.logger.Printf("Recycling SMTP connection that could not be reset: %s", err.Error())
+		p.recycle(s)
+		return
+	}
+
+	s.lastUsed = time.Now()
+
+	p.mutex.Lock()
+	p.idle = append(p.idle, s)
+	p.mutex.Unlock()
+}
+
+func (p *Pool) recycle(s *session) {
+	s.client.Close()
+	p.discard()
+}
+
+// discard forgets a session that has already been closed.
+func (p *Pool) discard() {
+	p.mutex.Lock()
+	p.stats.Open--
+	p.mutex.Unlock()
+	p.count("recycled", &p.stats.Recycled)
+	p.gauge()
+}
+
+func (p *Pool) count(name string, counter *int) {
+	p.mutex.Lock()
+	*counter++
+	p.mutex.Unlock()
+
+	metrics.NewMetric("counter", map[string]interface{}{
+		"name": "notifications.smtp.pool." + name,
+	}).Log()
+}
+
+func (p *Pool) gauge() {
+	stats := p.Stats()
+
+	metrics.NewMetric("gauge", map[string]interface{}{
+		"name":  "notifications.smtp.pool.open",
+		"value": stats.Open,
+	}).Log()
+}
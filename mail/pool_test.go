@@ -0,0 +1,251 @@
+package mail_test
+
+import (
+	"bytes"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pool", func() {
+	var mailServer *SMTPServer
+	var pool *mail.Pool
+	var logger *log.Logger
+	var buffer *bytes.Buffer
+	var config mail.Config
+	var poolConfig mail.PoolConfig
+
+	message := func(to string) mail.Message {
+		return mail.Message{
+			From:    "me@example.com",
+			To:      to,
+			Subject: "Urgent! Read now!",
+			Body: []mail.Part{
+				{
+					ContentType: "text/plain",
+					Content:     "This email is the most important thing you will read all day!",
+				},
+			},
+		}
+	}
+
+	recipients := func() []string {
+		recipients := []string{}
+		for _, delivery := range mailServer.Deliveries {
+			if delivery.Recipient != "" {
+				recipients = append(recipients, delivery.Recipient)
+			}
+		}
+		return recipients
+	}
+
+	BeforeEach(func() {
+		var err error
+
+		buffer = bytes.NewBuffer([]byte{})
+		logger = log.New(buffer, "", 0)
+		mailServer = NewSMTPServer("user", "pass")
+
+		config = mail.Config{
+			User:          "user",
+			Pass:          "pass",
+			SkipVerifySSL: true,
+		}
+
+		config.Host, config.Port, err = net.SplitHostPort(mailServer.URL.String())
+		if err != nil {
+			panic(err)
+		}
+
+		poolConfig = mail.PoolConfig{}
+	})
+
+	JustBeforeEach(func() {
+		pool = mail.NewPool(config, poolConfig, logger)
+	})
+
+	AfterEach(func() {
+		pool.Close()
+		mailServer.Close()
+	})
+
+	Describe("Send", func() {
+		It("delivers several messages over one connection", func() {
+			for _, to := range []string{"one@example.com", "two@example.com", "three@example.com"} {
+				err := pool.Send(message(to))
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			Eventually(recipients).Should(Equal([]string{"one@example.com", "two@example.com", "three@example.com"}))
+			Expect(mailServer.Connections).To(Equal(1))
+
+			stats := pool.Stats()
+			Expect(stats.Open).To(Equal(1))
+			Expect(stats.Idle).To(Equal(1))
+			Expect(stats.Dialed).To(Equal(1))
+			Expect(stats.Reused).To(Equal(2))
+		})
+
+		Context("when a connection has delivered the maximum number of messages", func() {
+			BeforeEach(func() {
+				poolConfig.MaxMessagesPerConnection = 2
+			})
+
+			It("dials a new connection", func() {
+				for _, to := range []string{"one@example.com", "two@example.com", "three@example.com"} {
+					err := pool.Send(message(to))
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				Eventually(recipients).Should(Equal([]string{"one@example.com", "two@example.com", "three@example.com"}))
+				Expect(mailServer.Connections).To(Equal(2))
+				Expect(pool.Stats().Recycled).To(Equal(1))
+			})
+		})
+
+		Context("when a connection has been idle for too long", func() {
+			BeforeEach(func() {
+				poolConfig.MaxIdleTime = 10 * time.Millisecond
+			})
+
+			It("dials a new connection", func() {
+				err := pool.Send(message("one@example.com"))
+				Expect(err).NotTo(HaveOccurred())
+
+				time.Sleep(50 * time.Millisecond)
+
+				err = pool.Send(message("two@example.com"))
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(recipients).Should(Equal([]string{"one@example.com", "two@example.com"}))
+				Expect(mailServer.Connections).To(Equal(2))
+
+				stats := pool.Stats()
+				Expect(stats.Open).To(Equal(1))
+				Expect(stats.Recycled).To(Equal(1))
+			})
+		})
+
+		Context("when the server rejects a message", func() {
+			BeforeEach(func() {
+				mailServer.RcptReply = "550 5.1.1 No such user"
+			})
+
+			It("returns the error and resets the connection for the next message", func() {
+				err := pool.Send(message("nobody@example.com"))
+				Expect(err).To(BeAssignableToTypeOf(mail.SMTPError{}))
+
+				stats := pool.Stats()
+				Expect(stats.Open).To(Equal(1))
+				Expect(stats.Idle).To(Equal(1))
+
+				mailServer.RcptReply = ""
+				err = pool.Send(message("one@example.com"))
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(recipients).Should(Equal([]string{"nobody@example.com", "one@example.com"}))
+				Expect(mailServer.Connections).To(Equal(1))
+			})
+		})
+
+		Context("when the server fails a message temporarily", func() {
+			BeforeEach(func() {
+				mailServer.RcptReply = "451 4.3.0 Try again later"
+			})
+
+			It("returns the error and drops the connection", func() {
+				err := pool.Send(message("one@example.com"))
+				Expect(err).To(BeAssignableToTypeOf(mail.SMTPError{}))
+
+				stats := pool.Stats()
+				Expect(stats.Open).To(Equal(0))
+				Expect(stats.Idle).To(Equal(0))
+			})
+		})
+
+		Context("when many messages are sent at once", func() {
+			BeforeEach(func() {
+				poolConfig.MaxConnections = 2
+			})
+
+			It("never opens more than the maximum number of connections", func() {
+				group := sync.WaitGroup{}
+				for i := 0; i < 6; i++ {
+					group.Add(1)
+					go func() {
+						defer GinkgoRecover()
+						defer group.Done()
+
+						err := pool.Send(message("you@example.com"))
+						Expect(err).NotTo(HaveOccurred())
+						Expect(pool.Stats().Open).To(BeNumerically("<=", 2))
+					}()
+				}
+				group.Wait()
+
+				Expect(pool.Stats().Open).To(BeNumerically("<=", 2))
+				Expect(pool.Stats().Dialed).To(BeNumerically("<=", 2))
+			})
+		})
+
+		Context("when in test mode", func() {
+			BeforeEach(func() {
+				config.TestMode = true
+			})
+
+			It("does not connect to the smtp server", func() {
+				err := pool.Send(message("you@example.com"))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mailServer.Connections).To(Equal(0))
+				Expect(buffer.String()).To(ContainSubstring("TEST_MODE is enabled, emails not being sent"))
+			})
+		})
+	})
+
+	Describe("Connect", func() {
+		It("dials a connection that the next message is sent over", func() {
+			err := pool.Connect()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = pool.Send(message("you@example.com"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(recipients).Should(Equal([]string{"you@example.com"}))
+			Expect(mailServer.Connections).To(Equal(1))
+		})
+
+		Context("when the server cannot be reached", func() {
+			BeforeEach(func() {
+				config.ConnectTimeout = 100 * time.Millisecond
+				mailServer.ConnectWait = 1 * time.Second
+			})
+
+			It("returns an error", func() {
+				err := pool.Connect()
+				Expect(err).To(HaveOccurred())
+				Expect(pool.Stats().Open).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("Close", func() {
+		It("ends idle connections", func() {
+			err := pool.Send(message("you@example.com"))
+			Expect(err).NotTo(HaveOccurred())
+
+			pool.Close()
+
+			Eventually(func() string {
+				return mailServer.ConnectionState
+			}).Should(Equal(StateClosed))
+			Expect(pool.Stats().Open).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,67 @@
+package mail
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os/exec"
+	"strings"
+)
+
+// SendmailTransport pipes messages into a local sendmail compatible binary.
+type SendmailTransport struct {
+	config Config
+	logger *log.Logger
+}
+
+func NewSendmailTransport(config Config, logger *log.Logger) *SendmailTransport {
+	if config.SendmailPath == "" {
+		config.SendmailPath = "/usr/sbin/sendmail"
+	}
+
+	return &SendmailTransport{
+		config: config,
+		logger: logger,
+	}
+}
+
+func (transport *SendmailTransport) Connect() error {
+	if transport.config.TestMode {
+		return nil
+	}
+
+	_, err := exec.LookPath(transport.config.SendmailPath)
+
+	return err
+}
+
+func (transport *SendmailTransport) Send(msg Message) error {
+	if transport.config.TestMode {
+		transport.logger.Println("TEST_MODE is enabled, emails not being sent")
+		return nil
+	}
+
+	data := msg.Data()
+	if transport.config.DKIM != nil {
+		var err error
+		data, err = transport.config.DKIM.Sign(data)
+		if err != nil {
+			return err
+		}
+	}
+
+	stderr := bytes.NewBuffer([]byte{})
+	command := exec.Command(transport.config.SendmailPath, "-i", "-f", msg.From, "--", msg.To)
+	// sendmail reads messages with local line endings.
+	command.Stdin = strings.NewReader(strings.Replace(data, "\r\n", "\n", -1))
+	command.Stderr = stderr
+
+	err := command.Run()
+	if err != nil {
+		return fmt.Errorf("sendmail failed: %s %s", err.Error(), strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+func (transport *SendmailTransport) Close() {}
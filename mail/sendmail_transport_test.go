@@ -0,0 +1,104 @@
+package mail_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SendmailTransport", func() {
+	var directory string
+	var transport *mail.SendmailTransport
+	var config mail.Config
+	var msg mail.Message
+
+	BeforeEach(func() {
+		var err error
+
+		directory, err = ioutil.TempDir("", "sendmail")
+		if err != nil {
+			panic(err)
+		}
+
+		script := "#!/bin/sh\necho \"$@\" > " + filepath.Join(directory, "args") + "\ncat > " + filepath.Join(directory, "data") + "\n"
+		err = ioutil.WriteFile(filepath.Join(directory, "sendmail"), []byte(script), 0755)
+		if err != nil {
+			panic(err)
+		}
+
+		config = mail.Config{
+			SendmailPath: filepath.Join(directory, "sendmail"),
+		}
+
+		msg = mail.Message{
+			From:    "me@example.com",
+			To:      "you@example.com",
+			Subject: "Urgent! Read now!",
+			Body: []mail.Part{
+				{ContentType: "text/plain", Content: "the text"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		transport = mail.NewSendmailTransport(config, log.New(bytes.NewBuffer([]byte{}), "", 0))
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(directory)
+	})
+
+	It("pipes the message into sendmail", func() {
+		err := transport.Connect()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = transport.Send(msg)
+		Expect(err).NotTo(HaveOccurred())
+
+		args, err := ioutil.ReadFile(filepath.Join(directory, "args"))
+		if err != nil {
+			panic(err)
+		}
+		Expect(string(args)).To(Equal("-i -f me@example.com -- you@example.com\n"))
+
+		data, err := ioutil.ReadFile(filepath.Join(directory, "data"))
+		if err != nil {
+			panic(err)
+		}
+		Expect(string(data)).To(ContainSubstring("Subject: Urgent! Read now!"))
+		Expect(string(data)).To(ContainSubstring("the text"))
+	})
+
+	Context("when sendmail fails", func() {
+		BeforeEach(func() {
+			err := ioutil.WriteFile(config.SendmailPath, []byte("#!/bin/sh\necho 'no such user' >&2\nexit 67\n"), 0755)
+			if err != nil {
+				panic(err)
+			}
+		})
+
+		It("returns an error including its output", func() {
+			err := transport.Send(msg)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("no such user"))
+		})
+	})
+
+	Context("when the sendmail binary does not exist", func() {
+		BeforeEach(func() {
+			config.SendmailPath = filepath.Join(directory, "missing")
+		})
+
+		It("fails to connect", func() {
+			err := transport.Connect()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
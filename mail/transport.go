@@ -0,0 +1,68 @@
+package mail
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+)
+
+// TransportFactory builds a transport from the mail configuration.
+type TransportFactory func(Config, *log.Logger) (ClientInterface, error)
+
+var transports = map[string]TransportFactory{}
+var transportsMutex sync.Mutex
+
+func init() {
+	RegisterTransport("smtp", func(config Config, logger *log.Logger) (ClientInterface, error) {
+		if len(config.Relays) > 0 {
+			return NewFailoverTransport(config, logger), nil
+		}
+
+		return NewPool(config, config.Pool, logger), nil
+	})
+	RegisterTransport("http", func(config Config, logger *log.Logger) (ClientInterface, error) {
+		return NewHTTPTransport(config, logger), nil
+	})
+	RegisterTransport("sendmail", func(config Config, logger *log.Logger) (ClientInterface, error) {
+		return NewSendmailTransport(config, logger), nil
+	})
+	RegisterTransport("file", func(config Config, logger *log.Logger) (ClientInterface, error) {
+		return NewFileTransport(config, logger)
+	})
+}
+
+// RegisterTransport makes a transport available by name, replacing any
+// transport that was registered with the same name.
+func RegisterTransport(name string, factory TransportFactory) {
+	transportsMutex.Lock()
+	defer transportsMutex.Unlock()
+
+	transports[name] = factory
+}
+
+// TransportNames lists the registered transports in alphabetical order.
+func TransportNames() []string {
+	transportsMutex.Lock()
+	defer transportsMutex.Unlock()
+
+	names := []string{}
+	for name := range transports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func NewTransport(name string, config Config, logger *log.Logger) (ClientInterface, error) {
+	transportsMutex.Lock()
+	factory, ok := transports[name]
+	transportsMutex.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("Unknown mail transport %q, it is not one of %+v", name, TransportNames())
+	}
+
+	return factory(config, logger)
+}
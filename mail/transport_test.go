@@ -0,0 +1,64 @@
+package mail_test
+
+import (
+	"bytes"
+	"log"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type customTransport struct {
+	mail.ClientInterface
+}
+
+var _ = Describe("Transports", func() {
+	var logger *log.Logger
+
+	BeforeEach(func() {
+		logger = log.New(bytes.NewBuffer([]byte{}), "", 0)
+	})
+
+	It("includes the built in transports", func() {
+		Expect(mail.TransportNames()).To(ContainElement("file"))
+		Expect(mail.TransportNames()).To(ContainElement("http"))
+		Expect(mail.TransportNames()).To(ContainElement("sendmail"))
+		Expect(mail.TransportNames()).To(ContainElement("smtp"))
+	})
+
+	It("builds the transport registered under the given name", func() {
+		transport, err := mail.NewTransport("smtp", mail.Config{}, logger)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transport).To(BeAssignableToTypeOf(&mail.Pool{}))
+
+		transport, err = mail.NewTransport("http", mail.Config{}, logger)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transport).To(BeAssignableToTypeOf(&mail.HTTPTransport{}))
+
+		transport, err = mail.NewTransport("sendmail", mail.Config{}, logger)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transport).To(BeAssignableToTypeOf(&mail.SendmailTransport{}))
+
+		transport, err = mail.NewTransport("file", mail.Config{File: mail.FileTransportConfig{Directory: "/tmp/mail"}}, logger)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transport).To(BeAssignableToTypeOf(&mail.FileTransport{}))
+	})
+
+	It("builds transports that have been registered", func() {
+		mail.RegisterTransport("custom", func(config mail.Config, logger *log.Logger) (mail.ClientInterface, error) {
+			return customTransport{}, nil
+		})
+
+		transport, err := mail.NewTransport("custom", mail.Config{}, logger)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transport).To(Equal(customTransport{}))
+	})
+
+	It("returns an error for an unknown transport", func() {
+		_, err := mail.NewTransport("pigeon", mail.Config{}, logger)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`"pigeon"`))
+	})
+})
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+const (
+	CampaignStatusExpanding = "expanding"
+	CampaignStatusExpanded  = "expanded"
+)
+
+type Campaign struct {
+	ID             string    `db:"id"`
+	ClientID       string    `db:"client_id"`
+	Audience       string    `db:"audience"`
+	AudienceGUID   string    `db:"audience_guid"`
+	AudienceCursor string    `db:"audience_cursor"`
+	Status         string    `db:"status"`
+	ExpandedCount  int       `db:"expanded_count"`
+	QueuedCount    int       `db:"queued_count"`
+	DeliveredCount int       `db:"delivered_count"`
+	CreatedAt      time.Time `db:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type CampaignsRepoInterface interface {
+	Create(ConnectionInterface, Campaign) (Campaign, error)
+	Find(ConnectionInterface, string) (Campaign, error)
+	Update(ConnectionInterface, Campaign) (Campaign, error)
+	IncrementDeliveredCount(ConnectionInterface, string) error
+}
+
+type CampaignsRepo struct{}
+
+func NewCampaignsRepo() CampaignsRepo {
+	return CampaignsRepo{}
+}
+
+func (repo CampaignsRepo) Create(conn ConnectionInterface, campaign Campaign) (Campaign, error) {
+	campaign.CreatedAt = time.Now().Truncate(1 * time.Second).UTC()
+	campaign.UpdatedAt = campaign.CreatedAt
+
+	if campaign.Status == "" {
+		campaign.Status = CampaignStatusExpanding
+	}
+
+	err := conn.Insert(&campaign)
+	if err != nil {
+		return Campaign{}, err
+	}
+
+	return campaign, nil
+}
+
+func (repo CampaignsRepo) Find(conn ConnectionInterface, campaignID string) (Campaign, error) {
+	campaign := Campaign{}
+	err := conn.SelectOne(&campaign, "SELECT * FROM `campaigns` WHERE `id` = ?", campaignID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Campaign{}, NewRecordNotFoundError("Campaign with ID %q could not be found", campaignID)
+		}
+		return Campaign{}, err
+	}
+
+	return campaign, nil
+}
+
+// Update saves the progress of the campaign's expansion. The delivered count
+// is left alone, since deliveries are counted while the campaign expands.
+func (repo CampaignsRepo) Update(conn ConnectionInterface, campaign Campaign) (Campaign, error) {
+	campaign.UpdatedAt = time.Now().Truncate(1 * time.Second).UTC()
+	_, err := conn.Exec("UPDATE `campaigns` SET `audience_cursor` = ?, `status` = ?, `expanded_count` = ?, `queued_count` = ?, `updated_at` = ? WHERE `id` = ?",
+		campaign.AudienceCursor, campaign.Status, campaign.ExpandedCount, campaign.QueuedCount, campaign.UpdatedAt, campaign.ID)
+	if err != nil {
+		return campaign, err
+	}
+
+	return repo.Find(conn, campaign.ID)
+}
+
+// IncrementDeliveredCount counts one more delivered message against the
+// campaign. The count is kept on the campaign because messages are only kept
+// for a day.
+func (repo CampaignsRepo) IncrementDeliveredCount(conn ConnectionInterface, campaignID string) error {
+	_, err := conn.Exec("UPDATE `campaigns` SET `delivered_count` = `delivered_count` + 1 WHERE `id` = ?", campaignID)
+
+	return err
+}
@@ -0,0 +1,115 @@
+package models_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/application"
+	"github.com/cloudfoundry-incubator/notifications/models"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CampaignsRepo", func() {
+	var repo models.CampaignsRepo
+	var conn models.ConnectionInterface
+	var campaign models.Campaign
+
+	BeforeEach(func() {
+		TruncateTables()
+		repo = models.NewCampaignsRepo()
+		env := application.NewEnvironment()
+		conn = models.NewDatabase(models.Config{
+			DatabaseURL:    env.DatabaseURL,
+			MigrationsPath: env.ModelMigrationsDir,
+		}).Connection()
+		campaign = models.Campaign{
+			ID:           "campaign-123",
+			ClientID:     "my-client",
+			Audience:     "spaces",
+			AudienceGUID: "space-001",
+		}
+	})
+
+	Describe("Create", func() {
+		It("inserts an expanding campaign into the database", func() {
+			campaign, err := repo.Create(conn, campaign)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(campaign.Status).To(Equal(models.CampaignStatusExpanding))
+			Expect(campaign.CreatedAt).To(BeTemporally("~", time.Now(), 2*time.Second))
+
+			campaignFound, err := repo.Find(conn, campaign.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(campaignFound).To(Equal(campaign))
+		})
+	})
+
+	Describe("Find", func() {
+		It("returns a RecordNotFoundError when the campaign does not exist", func() {
+			_, err := repo.Find(conn, "missing-id")
+			Expect(err).To(BeAssignableToTypeOf(models.RecordNotFoundError("")))
+		})
+	})
+
+	Describe("IncrementDeliveredCount", func() {
+		It("counts one more delivered message against the campaign", func() {
+			campaign, err := repo.Create(conn, campaign)
+			if err != nil {
+				panic(err)
+			}
+
+			err = repo.IncrementDeliveredCount(conn, campaign.ID)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = repo.IncrementDeliveredCount(conn, campaign.ID)
+			Expect(err).NotTo(HaveOccurred())
+
+			campaignFound, err := repo.Find(conn, campaign.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(campaignFound.DeliveredCount).To(Equal(2))
+		})
+	})
+
+	Describe("Update", func() {
+		It("updates the progress of the campaign", func() {
+			campaign, err := repo.Create(conn, campaign)
+			if err != nil {
+				panic(err)
+			}
+
+			campaign.ExpandedCount = 250
+			campaign.QueuedCount = 100
+			campaign.AudienceCursor = "/v2/users?page=3"
+			campaign.Status = models.CampaignStatusExpanded
+			campaign, err = repo.Update(conn, campaign)
+			Expect(err).ToNot(HaveOccurred())
+
+			campaignFound, err := repo.Find(conn, campaign.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(campaignFound.ExpandedCount).To(Equal(250))
+			Expect(campaignFound.QueuedCount).To(Equal(100))
+			Expect(campaignFound.AudienceCursor).To(Equal("/v2/users?page=3"))
+			Expect(campaignFound.Status).To(Equal(models.CampaignStatusExpanded))
+		})
+
+		It("leaves the delivered count alone", func() {
+			campaign, err := repo.Create(conn, campaign)
+			if err != nil {
+				panic(err)
+			}
+
+			err = repo.IncrementDeliveredCount(conn, campaign.ID)
+			if err != nil {
+				panic(err)
+			}
+
+			campaign.QueuedCount = 100
+			_, err = repo.Update(conn, campaign)
+			Expect(err).ToNot(HaveOccurred())
+
+			campaignFound, err := repo.Find(conn, campaign.ID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(campaignFound.QueuedCount).To(Equal(100))
+			Expect(campaignFound.DeliveredCount).To(Equal(1))
+		})
+	})
+})
@@ -84,10 +84,18 @@ func (database DB) migrate(migrationsPath string) {
 	database.connection.AddTableWithName(GlobalUnsubscribe{}, "global_unsubscribes").SetKeys(true, "Primary").ColMap("UserID").SetUnique(true)
 	database.connection.AddTableWithName(Template{}, "templates").SetKeys(true, "Primary").ColMap("Name").SetUnique(true)
 	database.connection.AddTableWithName(Message{}, "messages").SetKeys(false, "ID")
+	database.connection.AddTableWithName(Campaign{}, "campaigns").SetKeys(false, "ID")
+	database.connection.AddTableWithName(Suppression{}, "suppressions").SetKeys(true, "Primary").ColMap("Email").SetUnique(true)
+	database.connection.AddTableWithName(TemplateVersion{}, "template_versions").SetKeys(true, "Primary").SetUniqueTogether("template_id", "version")
 }
 
+// SeedAuthor is recorded as the author of the default template versions
+// that are read from the default template file.
+const SeedAuthor = "notifications"
+
 func (database DB) Seed() {
 	repo := NewTemplatesRepo()
+	versionsRepo := NewTemplateVersionsRepo()
 	bytes, err := ioutil.ReadFile(database.config.DefaultTemplatePath)
 	if err != nil {
 		panic(err)
@@ -113,7 +121,7 @@ func (database DB) Seed() {
 			panic(err)
 		}
 
-		_, err = repo.create(conn, Template{
+		created, err := repo.create(conn, Template{
 			ID:       DefaultTemplateID,
 			Name:     template.Name,
 			Subject:  template.Subject,
@@ -125,20 +133,42 @@ func (database DB) Seed() {
 			panic(err)
 		}
 
+		_, err = versionsRepo.Create(conn, NewTemplateVersion(created, SeedAuthor))
+		if err != nil {
+			panic(err)
+		}
+
 		return
 	}
 
 	if !existingTemplate.Overridden {
+		changed := existingTemplate.Name != template.Name ||
+			existingTemplate.Subject != template.Subject ||
+			existingTemplate.HTML != template.HTML ||
+			existingTemplate.Text != template.Text ||
+			existingTemplate.Metadata != string(template.Metadata)
+
 		existingTemplate.Name = template.Name
 		existingTemplate.Subject = template.Subject
 		existingTemplate.HTML = template.HTML
 		existingTemplate.Text = template.Text
 		existingTemplate.Metadata = string(template.Metadata)
 		existingTemplate.UpdatedAt = time.Now().Truncate(1 * time.Second).UTC()
+		if changed {
+			existingTemplate.Version++
+		}
+
 		_, err = conn.Update(&existingTemplate)
 		if err != nil {
 			panic(err)
 		}
+
+		if changed {
+			_, err = versionsRepo.Create(conn, NewTemplateVersion(existingTemplate, SeedAuthor))
+			if err != nil {
+				panic(err)
+			}
+		}
 	}
 }
 
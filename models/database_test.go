@@ -74,6 +74,10 @@ var _ = Describe("Database", func() {
 			Expect(tables).To(ContainElement("unsubscribes"))
 			Expect(tables).To(ContainElement("global_unsubscribes"))
 			Expect(tables).To(ContainElement("templates"))
+			Expect(tables).To(ContainElement("messages"))
+			Expect(tables).To(ContainElement("campaigns"))
+			Expect(tables).To(ContainElement("suppressions"))
+			Expect(tables).To(ContainElement("template_versions"))
 		})
 	})
 
@@ -96,6 +100,14 @@ var _ = Describe("Database", func() {
 			Expect(template.HTML).To(Equal("<p>{{.Endorsement}}</p>{{.HTML}}"))
 			Expect(template.Text).To(Equal("{{.Endorsement}}\n{{.Text}}"))
 			Expect(template.Metadata).To(Equal("{}"))
+			Expect(template.Version).To(Equal(1))
+
+			versions, err := models.NewTemplateVersionsRepo().List(connection, models.DefaultTemplateID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions).To(HaveLen(1))
+			Expect(versions[0].Version).To(Equal(1))
+			Expect(versions[0].Author).To(Equal(models.SeedAuthor))
+			Expect(versions[0].Text).To(Equal("{{.Endorsement}}\n{{.Text}}"))
 		})
 
 		It("can be called multiple times without panicking", func() {
@@ -132,6 +144,13 @@ var _ = Describe("Database", func() {
 				Expect(template.Text).To(Equal("{{.Endorsement}}\n{{.Text}}"))
 				Expect(template.Metadata).To(Equal("{}"))
 				Expect(template.Overridden).To(BeFalse())
+				Expect(template.Version).To(Equal(2))
+
+				versions, err := models.NewTemplateVersionsRepo().List(connection, models.DefaultTemplateID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versions).To(HaveLen(2))
+				Expect(versions[0].Version).To(Equal(2))
+				Expect(versions[0].Name).To(Equal("Default Template"))
 			})
 		})
 
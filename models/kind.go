@@ -11,6 +11,22 @@ type Kind struct {
 	CreatedAt   time.Time `db:"created_at"`
 	UpdatedAt   time.Time `db:"updated_at"`
 	TemplateID  string    `db:"template_id"`
+	RetryPolicy
+}
+
+// RetryPolicy controls how the delivery of a notification is retried when it
+// fails. Delays are in seconds and Jitter is the fraction of each delay that
+// is randomized. Fields that are left at zero fall back to the default policy.
+type RetryPolicy struct {
+	MaxAttempts        int     `db:"retry_max_attempts"  json:"max_attempts,omitempty"`
+	BaseDelaySeconds   int     `db:"retry_base_delay"    json:"base_delay_seconds,omitempty"`
+	MaxDelaySeconds    int     `db:"retry_max_delay"     json:"max_delay_seconds,omitempty"`
+	Jitter             float64 `db:"retry_jitter"        json:"jitter,omitempty"`
+	GiveUpAfterSeconds int     `db:"retry_give_up_after" json:"give_up_after_seconds,omitempty"`
+}
+
+func (policy RetryPolicy) IsSet() bool {
+	return policy != RetryPolicy{}
 }
 
 func (k Kind) TemplateToUse() string {
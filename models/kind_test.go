@@ -31,4 +31,15 @@ var _ = Describe("Kind", func() {
 			})
 		})
 	})
+
+	Describe("RetryPolicy", func() {
+		It("is not set by default", func() {
+			Expect(models.Kind{}.RetryPolicy.IsSet()).To(BeFalse())
+		})
+
+		It("is set when any of its fields are set", func() {
+			kind.RetryPolicy = models.RetryPolicy{Jitter: 0.5}
+			Expect(kind.RetryPolicy.IsSet()).To(BeTrue())
+		})
+	})
 })
@@ -181,6 +181,39 @@ var _ = Describe("KindsRepo", func() {
 	})
 
 	Describe("Update", func() {
+		It("stores the retry policy of the kind", func() {
+			kind, err := repo.Create(conn, models.Kind{
+				ID:       "my-kind",
+				ClientID: "my-client",
+			})
+			if err != nil {
+				panic(err)
+			}
+
+			kind.RetryPolicy = models.RetryPolicy{
+				MaxAttempts:        5,
+				BaseDelaySeconds:   30,
+				MaxDelaySeconds:    600,
+				Jitter:             0.25,
+				GiveUpAfterSeconds: 3600,
+			}
+
+			_, err = repo.Update(conn, kind)
+			if err != nil {
+				panic(err)
+			}
+
+			kind, err = repo.Find(conn, "my-kind", "my-client")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(kind.RetryPolicy).To(Equal(models.RetryPolicy{
+				MaxAttempts:        5,
+				BaseDelaySeconds:   30,
+				MaxDelaySeconds:    600,
+				Jitter:             0.25,
+				GiveUpAfterSeconds: 3600,
+			}))
+		})
+
 		Context("when the template id is meant to be set", func() {
 			It("updates the record in the database", func() {
 				kind := models.Kind{
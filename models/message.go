@@ -2,8 +2,20 @@ package models
 
 import "time"
 
+const (
+	StatusQueued    = "queued"
+	StatusScheduled = "scheduled"
+	StatusCanceled  = "canceled"
+	StatusDelivered = "delivered"
+	StatusBounced   = "bounced"
+)
+
 type Message struct {
-	ID        string    `db:"id"`
-	Status    string    `db:"status"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID              string    `db:"id"`
+	Status          string    `db:"status"`
+	CampaignID      string    `db:"campaign_id"`
+	Relay           string    `db:"relay"`
+	TemplateID      string    `db:"template_id"`
+	TemplateVersion int       `db:"template_version"`
+	UpdatedAt       time.Time `db:"updated_at"`
 }
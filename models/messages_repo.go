@@ -56,8 +56,10 @@ func (repo MessagesRepo) Upsert(conn ConnectionInterface, message Message) (Mess
 	}
 }
 
+// DeleteBefore removes stale messages. Scheduled messages are kept until they
+// are sent, however far in the future that is.
 func (repo MessagesRepo) DeleteBefore(conn ConnectionInterface, threshold time.Time) (int, error) {
-	result, err := conn.Exec("DELETE FROM `messages` WHERE `updated_at` < ?", threshold.UTC())
+	result, err := conn.Exec("DELETE FROM `messages` WHERE `updated_at` < ? AND `status` != ?", threshold.UTC(), StatusScheduled)
 	if err != nil {
 		return 0, err
 	}
@@ -124,5 +124,20 @@ var _ = Describe("MessagesRepo", func() {
 			_, err = repo.FindByID(conn, message.ID)
 			Expect(err).ToNot(HaveOccurred())
 		})
+
+		It("Does not delete scheduled messages", func() {
+			message.Status = postal.StatusScheduled
+			_, err := repo.Create(conn, message)
+			if err != nil {
+				panic(err)
+			}
+
+			itemsDeleted, err := repo.DeleteBefore(conn, time.Now().Add(1*time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(itemsDeleted).To(Equal(0))
+
+			_, err = repo.FindByID(conn, message.ID)
+			Expect(err).ToNot(HaveOccurred())
+		})
 	})
 })
@@ -0,0 +1,18 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS `campaigns` (
+      `id` varchar(255) NOT NULL,
+      `client_id` varchar(255) NOT NULL,
+      `audience` varchar(255) NOT NULL,
+      `audience_guid` varchar(255) NOT NULL DEFAULT '',
+      `status` varchar(255) NOT NULL,
+      `expanded_count` int(11) NOT NULL DEFAULT 0,
+      `queued_count` int(11) NOT NULL DEFAULT 0,
+      `created_at` datetime NOT NULL,
+      `updated_at` datetime NOT NULL,
+      PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE campaigns;
@@ -0,0 +1,9 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+ALTER TABLE `messages` ADD `campaign_id` varchar(255) NOT NULL DEFAULT '';
+CREATE INDEX `messages_campaign_id` ON `messages` (`campaign_id`);
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP INDEX `messages_campaign_id` ON `messages`;
+ALTER TABLE `messages` DROP COLUMN `campaign_id`;
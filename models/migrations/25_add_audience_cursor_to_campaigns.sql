@@ -0,0 +1,7 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+ALTER TABLE `campaigns` ADD `audience_cursor` varchar(2048) NOT NULL DEFAULT '';
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+ALTER TABLE `campaigns` DROP COLUMN `audience_cursor`;
@@ -0,0 +1,7 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+ALTER TABLE `campaigns` ADD `delivered_count` int(11) NOT NULL DEFAULT 0;
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+ALTER TABLE `campaigns` DROP COLUMN `delivered_count`;
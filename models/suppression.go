@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type Suppression struct {
+	Primary   int       `db:"primary"`
+	Email     string    `db:"email"`
+	Reason    string    `db:"reason"`
+	CreatedAt time.Time `db:"created_at"`
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+)
+
+type SuppressionsRepoInterface interface {
+	Upsert(ConnectionInterface, Suppression) (Suppression, error)
+	Find(ConnectionInterface, string) (Suppression, error)
+	FindAll(ConnectionInterface) ([]Suppression, error)
+	Delete(ConnectionInterface, string) error
+}
+
+type SuppressionsRepo struct{}
+
+func NewSuppressionsRepo() SuppressionsRepo {
+	return SuppressionsRepo{}
+}
+
+func (repo SuppressionsRepo) Upsert(conn ConnectionInterface, suppression Suppression) (Suppression, error) {
+	suppression.Email = normalizeEmail(suppression.Email)
+
+	existing, err := repo.Find(conn, suppression.Email)
+	switch err.(type) {
+	case nil:
+		existing.Reason = suppression.Reason
+		_, err = conn.Update(&existing)
+		if err != nil {
+			return existing, err
+		}
+		return existing, nil
+	case RecordNotFoundError:
+		suppression.CreatedAt = time.Now().Truncate(1 * time.Second).UTC()
+		err = conn.Insert(&suppression)
+		if err != nil {
+			return suppression, err
+		}
+		return suppression, nil
+	default:
+		return suppression, err
+	}
+}
+
+func (repo SuppressionsRepo) Find(conn ConnectionInterface, email string) (Suppression, error) {
+	suppression := Suppression{}
+	err := conn.SelectOne(&suppression, "SELECT * FROM `suppressions` WHERE `email` = ?", normalizeEmail(email))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Suppression{}, NewRecordNotFoundError("Suppression for %q could not be found", email)
+		}
+		return Suppression{}, err
+	}
+
+	return suppression, nil
+}
+
+func (repo SuppressionsRepo) FindAll(conn ConnectionInterface) ([]Suppression, error) {
+	suppressions := []Suppression{}
+	_, err := conn.Select(&suppressions, "SELECT * FROM `suppressions` ORDER BY `email`")
+	if err != nil {
+		return []Suppression{}, err
+	}
+
+	return suppressions, nil
+}
+
+func (repo SuppressionsRepo) Delete(conn ConnectionInterface, email string) error {
+	suppression, err := repo.Find(conn, email)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Delete(&suppression)
+	return err
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
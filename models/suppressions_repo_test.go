@@ -0,0 +1,103 @@
+package models_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/application"
+	"github.com/cloudfoundry-incubator/notifications/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SuppressionsRepo", func() {
+	var repo models.SuppressionsRepo
+	var conn models.ConnectionInterface
+
+	BeforeEach(func() {
+		TruncateTables()
+		repo = models.NewSuppressionsRepo()
+		env := application.NewEnvironment()
+		conn = models.NewDatabase(models.Config{
+			DatabaseURL:    env.DatabaseURL,
+			MigrationsPath: env.ModelMigrationsDir,
+		}).Connection()
+	})
+
+	Describe("Upsert", func() {
+		It("inserts a suppression for the normalized address", func() {
+			suppression, err := repo.Upsert(conn, models.Suppression{
+				Email:  " Bounced@Example.com ",
+				Reason: "550 mailbox does not exist",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suppression.Email).To(Equal("bounced@example.com"))
+			Expect(suppression.CreatedAt).To(BeTemporally("~", time.Now(), 2*time.Second))
+
+			found, err := repo.Find(conn, "BOUNCED@example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(Equal(suppression))
+		})
+
+		It("updates the reason of an existing suppression", func() {
+			original, err := repo.Upsert(conn, models.Suppression{Email: "bounced@example.com", Reason: "first"})
+			if err != nil {
+				panic(err)
+			}
+
+			_, err = repo.Upsert(conn, models.Suppression{Email: "bounced@example.com", Reason: "second"})
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := repo.Find(conn, "bounced@example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.Reason).To(Equal("second"))
+			Expect(found.CreatedAt).To(Equal(original.CreatedAt))
+		})
+	})
+
+	Describe("Find", func() {
+		It("returns a RecordNotFoundError when the address is not suppressed", func() {
+			_, err := repo.Find(conn, "nobody@example.com")
+			Expect(err).To(BeAssignableToTypeOf(models.RecordNotFoundError("")))
+		})
+	})
+
+	Describe("FindAll", func() {
+		It("returns every suppression ordered by address", func() {
+			_, err := repo.Upsert(conn, models.Suppression{Email: "zed@example.com"})
+			if err != nil {
+				panic(err)
+			}
+			_, err = repo.Upsert(conn, models.Suppression{Email: "amy@example.com"})
+			if err != nil {
+				panic(err)
+			}
+
+			suppressions, err := repo.FindAll(conn)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suppressions).To(HaveLen(2))
+			Expect(suppressions[0].Email).To(Equal("amy@example.com"))
+			Expect(suppressions[1].Email).To(Equal("zed@example.com"))
+		})
+	})
+
+	Describe("Delete", func() {
+		It("removes the suppression", func() {
+			_, err := repo.Upsert(conn, models.Suppression{Email: "bounced@example.com"})
+			if err != nil {
+				panic(err)
+			}
+
+			err = repo.Delete(conn, "Bounced@example.com")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = repo.Find(conn, "bounced@example.com")
+			Expect(err).To(BeAssignableToTypeOf(models.RecordNotFoundError("")))
+		})
+
+		It("returns a RecordNotFoundError when the address is not suppressed", func() {
+			err := repo.Delete(conn, "nobody@example.com")
+			Expect(err).To(BeAssignableToTypeOf(models.RecordNotFoundError("")))
+		})
+	})
+})
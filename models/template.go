@@ -18,4 +18,5 @@ type Template struct {
 	CreatedAt  time.Time `db:"created_at"`
 	UpdatedAt  time.Time `db:"updated_at"`
 	Overridden bool      `db:"overridden"`
+	Version    int       `db:"version"`
 }
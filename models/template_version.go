@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// TemplateVersion is the content of a template as it was after one of its
+// changes. Versions are only ever added, never updated or deleted.
+type TemplateVersion struct {
+	Primary    int       `db:"primary"`
+	TemplateID string    `db:"template_id"`
+	Version    int       `db:"version"`
+	Name       string    `db:"name"`
+	Subject    string    `db:"subject"`
+	Text       string    `db:"text"`
+	HTML       string    `db:"html"`
+	Metadata   string    `db:"metadata"`
+	Author     string    `db:"author"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+func NewTemplateVersion(template Template, author string) TemplateVersion {
+	return TemplateVersion{
+		TemplateID: template.ID,
+		Version:    template.Version,
+		Name:       template.Name,
+		Subject:    template.Subject,
+		Text:       template.Text,
+		HTML:       template.HTML,
+		Metadata:   template.Metadata,
+		Author:     author,
+	}
+}
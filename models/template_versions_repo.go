@@ -0,0 +1,52 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type TemplateVersionsRepoInterface interface {
+	Create(ConnectionInterface, TemplateVersion) (TemplateVersion, error)
+	Find(ConnectionInterface, string, int) (TemplateVersion, error)
+	List(ConnectionInterface, string) ([]TemplateVersion, error)
+}
+
+type TemplateVersionsRepo struct{}
+
+func NewTemplateVersionsRepo() TemplateVersionsRepo {
+	return TemplateVersionsRepo{}
+}
+
+func (repo TemplateVersionsRepo) Create(conn ConnectionInterface, version TemplateVersion) (TemplateVersion, error) {
+	version.CreatedAt = time.Now().Truncate(1 * time.Second).UTC()
+	err := conn.Insert(&version)
+	if err != nil {
+		return TemplateVersion{}, err
+	}
+
+	return version, nil
+}
+
+func (repo TemplateVersionsRepo) Find(conn ConnectionInterface, templateID string, number int) (TemplateVersion, error) {
+	version := TemplateVersion{}
+	err := conn.SelectOne(&version, "SELECT * FROM `template_versions` WHERE `template_id` = ? AND `version` = ?", templateID, number)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return TemplateVersion{}, NewRecordNotFoundError("Version %d of template %q could not be found", number, templateID)
+		}
+		return TemplateVersion{}, err
+	}
+
+	return version, nil
+}
+
+// List returns the versions of a template, newest first.
+func (repo TemplateVersionsRepo) List(conn ConnectionInterface, templateID string) ([]TemplateVersion, error) {
+	versions := []TemplateVersion{}
+	_, err := conn.Select(&versions, "SELECT * FROM `template_versions` WHERE `template_id` = ? ORDER BY `version` DESC", templateID)
+	if err != nil {
+		return []TemplateVersion{}, err
+	}
+
+	return versions, nil
+}
@@ -0,0 +1,107 @@
+package models_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/application"
+	"github.com/cloudfoundry-incubator/notifications/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TemplateVersionsRepo", func() {
+	var repo models.TemplateVersionsRepo
+	var conn models.ConnectionInterface
+
+	BeforeEach(func() {
+		TruncateTables()
+		repo = models.NewTemplateVersionsRepo()
+		env := application.NewEnvironment()
+		db := models.NewDatabase(models.Config{
+			DatabaseURL:    env.DatabaseURL,
+			MigrationsPath: env.ModelMigrationsDir,
+		})
+		conn = db.Connection()
+	})
+
+	create := func(templateID string, number int, text string) models.TemplateVersion {
+		version, err := repo.Create(conn, models.TemplateVersion{
+			TemplateID: templateID,
+			Version:    number,
+			Name:       "Raptors",
+			Subject:    "Raptor Alert",
+			Text:       text,
+			HTML:       "<p>" + text + "</p>",
+			Metadata:   "{}",
+			Author:     "owen-grady",
+		})
+		if err != nil {
+			panic(err)
+		}
+
+		return version
+	}
+
+	Describe("Create", func() {
+		It("inserts the version with a creation time", func() {
+			version := create("raptor-template", 1, "run")
+
+			Expect(version.Primary).NotTo(BeZero())
+			Expect(version.CreatedAt).To(BeTemporally("~", time.Now(), 2*time.Second))
+		})
+
+		It("does not allow a version number to be reused", func() {
+			create("raptor-template", 1, "run")
+
+			_, err := repo.Create(conn, models.TemplateVersion{
+				TemplateID: "raptor-template",
+				Version:    1,
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Find", func() {
+		It("finds the version of the template", func() {
+			create("raptor-template", 1, "run")
+			create("raptor-template", 2, "hide")
+			create("trex-template", 2, "stand still")
+
+			version, err := repo.Find(conn, "raptor-template", 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version.TemplateID).To(Equal("raptor-template"))
+			Expect(version.Version).To(Equal(2))
+			Expect(version.Text).To(Equal("hide"))
+			Expect(version.HTML).To(Equal("<p>hide</p>"))
+			Expect(version.Author).To(Equal("owen-grady"))
+		})
+
+		It("returns a record not found error when the version does not exist", func() {
+			create("raptor-template", 1, "run")
+
+			_, err := repo.Find(conn, "raptor-template", 2)
+			Expect(err).To(BeAssignableToTypeOf(models.RecordNotFoundError("")))
+		})
+	})
+
+	Describe("List", func() {
+		It("returns the versions of the template, newest first", func() {
+			create("raptor-template", 1, "run")
+			create("trex-template", 1, "stand still")
+			create("raptor-template", 2, "hide")
+
+			versions, err := repo.List(conn, "raptor-template")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions).To(HaveLen(2))
+			Expect(versions[0].Text).To(Equal("hide"))
+			Expect(versions[1].Text).To(Equal("run"))
+		})
+
+		It("returns an empty list when the template has no versions", func() {
+			versions, err := repo.List(conn, "raptor-template")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions).To(BeEmpty())
+		})
+	})
+})
@@ -47,6 +47,7 @@ func (repo TemplatesRepo) Update(conn ConnectionInterface, templateID string, te
 	template.CreatedAt = existingTemplate.CreatedAt
 	template.UpdatedAt = time.Now().Truncate(1 * time.Second).UTC()
 	template.Overridden = true
+	template.Version = existingTemplate.Version + 1
 
 	_, err = conn.Update(&template)
 	if err != nil {
@@ -73,6 +74,7 @@ func (repo TemplatesRepo) Create(conn ConnectionInterface, template Template) (T
 
 func (repo TemplatesRepo) create(conn ConnectionInterface, template Template) (Template, error) {
 	setTemplateTimestamps(&template)
+	template.Version = 1
 	err := conn.Insert(&template)
 	if err != nil {
 		return Template{}, err
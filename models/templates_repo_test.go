@@ -87,6 +87,7 @@ var _ = Describe("TemplatesRepo", func() {
 			Expect(foundTemplate.HTML).To(Equal(newTemplate.HTML))
 			Expect(foundTemplate.CreatedAt).To(Equal(createdAt))
 			Expect(foundTemplate.UpdatedAt).To(Equal(createdAt))
+			Expect(foundTemplate.Version).To(Equal(1))
 		})
 	})
 
@@ -105,8 +106,9 @@ var _ = Describe("TemplatesRepo", func() {
 
 		Context("the template exists in the database", func() {
 			It("updates a template currently in the database", func() {
-				_, err := repo.Update(conn, template.ID, aNewTemplate)
+				updatedTemplate, err := repo.Update(conn, template.ID, aNewTemplate)
 				Expect(err).ToNot(HaveOccurred())
+				Expect(updatedTemplate.Version).To(Equal(template.Version + 1))
 
 				foundTemplate, err := repo.FindByID(conn, template.ID)
 				if err != nil {
@@ -122,6 +124,7 @@ var _ = Describe("TemplatesRepo", func() {
 				Expect(foundTemplate.UpdatedAt).ToNot(Equal(createdAt))
 				Expect(foundTemplate.UpdatedAt).To(BeTemporally(">", createdAt))
 				Expect(foundTemplate.Overridden).To(BeTrue())
+				Expect(foundTemplate.Version).To(Equal(template.Version + 1))
 			})
 		})
 
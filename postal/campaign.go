@@ -0,0 +1,19 @@
+package postal
+
+const CampaignJobType = "campaign"
+
+// Campaign is the payload of a job that fans a single notification out to
+// every member of an audience (a space, an organization, a scope or every
+// user). The job is expanded by a worker into one delivery job per user.
+type Campaign struct {
+	JobType  string
+	ID       string
+	Audience string
+	GUID     string
+	ClientID string
+	Options  Options
+}
+
+type CampaignExpanderInterface interface {
+	Expand(Campaign) error
+}
@@ -2,7 +2,6 @@ package postal
 
 import (
 	"log"
-	"math"
 	"strings"
 	"time"
 
@@ -23,6 +22,7 @@ type Delivery struct {
 	ClientID     string
 	MessageID    string
 	Scope        string
+	QueuedAt     time.Time
 }
 
 type MessagesRepoInterface interface {
@@ -34,12 +34,15 @@ type DeliveryWorker struct {
 	mailClient             mail.ClientInterface
 	globalUnsubscribesRepo models.GlobalUnsubscribesRepoInterface
 	unsubscribesRepo       models.UnsubscribesRepoInterface
+	suppressionsRepo       models.SuppressionsRepoInterface
 	kindsRepo              models.KindsRepoInterface
 	userLoader             UserLoaderInterface
 	templatesLoader        TemplatesLoaderInterface
 	tokenLoader            TokenLoaderInterface
 	messagesRepo           MessagesRepoInterface
 	receiptsRepo           models.ReceiptsRepoInterface
+	campaignExpander       CampaignExpanderInterface
+	campaignsRepo          models.CampaignsRepoInterface
 	database               models.DatabaseInterface
 	sender                 string
 	encryptionKey          []byte
@@ -48,15 +51,17 @@ type DeliveryWorker struct {
 
 func NewDeliveryWorker(id int, logger *log.Logger, mailClient mail.ClientInterface, queue gobble.QueueInterface,
 	globalUnsubscribesRepo models.GlobalUnsubscribesRepoInterface, unsubscribesRepo models.UnsubscribesRepoInterface,
-	kindsRepo models.KindsRepoInterface, messagesRepo MessagesRepoInterface,
+	suppressionsRepo models.SuppressionsRepoInterface, kindsRepo models.KindsRepoInterface, messagesRepo MessagesRepoInterface,
 	database models.DatabaseInterface, sender string, encryptionKey []byte, userLoader UserLoaderInterface,
-	templatesLoader TemplatesLoaderInterface, receiptsRepo models.ReceiptsRepoInterface, tokenLoader TokenLoaderInterface) DeliveryWorker {
+	templatesLoader TemplatesLoaderInterface, receiptsRepo models.ReceiptsRepoInterface, tokenLoader TokenLoaderInterface,
+	campaignExpander CampaignExpanderInterface, campaignsRepo models.CampaignsRepoInterface) DeliveryWorker {
 
 	worker := DeliveryWorker{
 		logger:                 logger,
 		mailClient:             mailClient,
 		globalUnsubscribesRepo: globalUnsubscribesRepo,
 		unsubscribesRepo:       unsubscribesRepo,
+		suppressionsRepo:       suppressionsRepo,
 		kindsRepo:              kindsRepo,
 		messagesRepo:           messagesRepo,
 		database:               database,
@@ -66,6 +71,8 @@ func NewDeliveryWorker(id int, logger *log.Logger, mailClient mail.ClientInterfa
 		tokenLoader:            tokenLoader,
 		templatesLoader:        templatesLoader,
 		receiptsRepo:           receiptsRepo,
+		campaignExpander:       campaignExpander,
+		campaignsRepo:          campaignsRepo,
 	}
 	worker.Worker = gobble.NewWorker(id, queue, worker.Deliver)
 
@@ -73,34 +80,45 @@ func NewDeliveryWorker(id int, logger *log.Logger, mailClient mail.ClientInterfa
 }
 
 func (worker DeliveryWorker) Deliver(job *gobble.Job) {
-	var delivery Delivery
+	var campaign Campaign
+	err := job.Unmarshal(&campaign)
+	if err == nil && campaign.JobType == CampaignJobType {
+		worker.expand(job, campaign)
+		return
+	}
 
-	err := job.Unmarshal(&delivery)
+	var delivery Delivery
+	err = job.Unmarshal(&delivery)
 	if err != nil {
 		metrics.NewMetric("counter", map[string]interface{}{
 			"name": "notifications.worker.panic.json",
 		}).Log()
 
-		worker.retry(job)
+		worker.retry(job, Delivery{}, err)
 		return
 	}
 
 	err = worker.receiptsRepo.CreateReceipts(worker.database.Connection(), []string{delivery.UserGUID}, delivery.ClientID, delivery.Options.KindID)
 	if err != nil {
-		worker.retry(job)
+		worker.retry(job, delivery, err)
 		return
 	}
 
 	if delivery.Email == "" {
 		token, err := worker.tokenLoader.Load()
 		if err != nil {
-			worker.retry(job)
+			worker.retry(job, delivery, err)
 			return
 		}
 
 		users, err := worker.userLoader.Load([]string{delivery.UserGUID}, token)
-		if err != nil || len(users) < 1 {
-			worker.retry(job)
+		if err != nil {
+			worker.retry(job, delivery, err)
+			return
+		}
+
+		if len(users) < 1 {
+			worker.retry(job, delivery, UAAUserNotFoundError("UAA did not return user "+delivery.UserGUID))
 			return
 		}
 
@@ -111,15 +129,20 @@ func (worker DeliveryWorker) Deliver(job *gobble.Job) {
 	}
 
 	if worker.shouldDeliver(delivery) {
-		status := worker.deliver(delivery)
+		status, err := worker.deliver(delivery)
 
-		if status != StatusDelivered {
-			worker.retry(job)
-			return
-		} else {
+		switch status {
+		case StatusDelivered:
 			metrics.NewMetric("counter", map[string]interface{}{
 				"name": "notifications.worker.delivered",
 			}).Log()
+		case StatusUndeliverable:
+			worker.logger.Printf("Not retrying because the message was permanently rejected: %s", err.Error())
+			metrics.NewMetric("counter", map[string]interface{}{
+				"name": "notifications.worker.undeliverable",
+			}).Log()
+		default:
+			worker.retry(job, delivery, err)
 		}
 	} else {
 		metrics.NewMetric("counter", map[string]interface{}{
@@ -128,33 +151,75 @@ func (worker DeliveryWorker) Deliver(job *gobble.Job) {
 	}
 }
 
-func (worker DeliveryWorker) deliver(delivery Delivery) string {
-	message, err := worker.pack(delivery)
+func (worker DeliveryWorker) expand(job *gobble.Job, campaign Campaign) {
+	err := worker.campaignExpander.Expand(campaign)
+	if err != nil {
+		worker.logger.Printf("Failed to expand the audience of campaign %s: %s", campaign.ID, err.Error())
+		worker.retry(job, Delivery{}, err)
+		return
+	}
+
+	metrics.NewMetric("counter", map[string]interface{}{
+		"name": "notifications.worker.expanded",
+	}).Log()
+}
+
+func (worker DeliveryWorker) deliver(delivery Delivery) (string, error) {
+	message, templates, err := worker.pack(delivery)
 	if err != nil {
 		worker.logger.Printf("Not delivering because template failed to pack")
-		worker.updateMessageStatus(delivery.MessageID, StatusFailed)
-		return StatusFailed
+		worker.updateMessageStatus(delivery, StatusFailed)
+		return StatusFailed, err
 	}
 
-	status := worker.sendMail(message)
-	worker.updateMessageStatus(delivery.MessageID, status)
+	status, relay, err := worker.sendMail(message)
+	worker.updateMessage(delivery, status, relay, templates)
 
-	return status
+	return status, err
 }
 
-func (worker DeliveryWorker) updateMessageStatus(messageID, status string) {
-	_, err := worker.messagesRepo.Upsert(worker.database.Connection(), models.Message{ID: messageID, Status: status})
+func (worker DeliveryWorker) updateMessageStatus(delivery Delivery, status string) {
+	worker.updateMessage(delivery, status, "", Templates{})
+}
+
+// updateMessage records the status of the message, along with the template
+// version it was rendered from. Delivered messages are also counted against
+// their campaign, which outlives the message itself.
+func (worker DeliveryWorker) updateMessage(delivery Delivery, status, relay string, templates Templates) {
+	conn := worker.database.Connection()
+	_, err := worker.messagesRepo.Upsert(conn, models.Message{
+		ID:              delivery.MessageID,
+		Status:          status,
+		CampaignID:      delivery.Options.CampaignID,
+		Relay:           relay,
+		TemplateID:      templates.ID,
+		TemplateVersion: templates.Version,
+	})
 	if err != nil {
-		worker.logger.Printf("Failed to upsert status '%s' of notification %s. Error: %s", status, messageID, err.Error())
+		worker.logger.Printf("Failed to upsert status '%s' of notification %s. Error: %s", status, delivery.MessageID, err.Error())
+	}
+
+	if status == StatusDelivered && delivery.Options.CampaignID != "" {
+		err = worker.campaignsRepo.IncrementDeliveredCount(conn, delivery.Options.CampaignID)
+		if err != nil {
+			worker.logger.Printf("Failed to count notification %s as delivered by campaign %s. Error: %s", delivery.MessageID, delivery.Options.CampaignID, err.Error())
+		}
 	}
 }
 
-func (worker DeliveryWorker) retry(job *gobble.Job) {
-	if job.RetryCount < 10 {
-		duration := time.Duration(int64(math.Pow(2, float64(job.RetryCount))))
-		job.Retry(duration * time.Minute)
+func (worker DeliveryWorker) retry(job *gobble.Job, delivery Delivery, err error) {
+	duration, ok := RetryDelay(delivery.Options.RetryPolicy, job.RetryCount, delivery.QueuedAt, time.Now())
+	if ok {
+		job.Retry(duration)
 		layout := "Jan 2, 2006 at 3:04pm (MST)"
 		worker.logger.Printf("Message failed to send, retrying at: %s", job.ActiveAt.Format(layout))
+	} else {
+		job.Bury(err.Error())
+		worker.logger.Printf("Message failed to send after %d retries, moving it to the dead jobs queue", job.RetryCount)
+
+		metrics.NewMetric("counter", map[string]interface{}{
+			"name": "notifications.worker.dead",
+		}).Log()
 	}
 
 	metrics.NewMetric("counter", map[string]interface{}{
@@ -164,6 +229,12 @@ func (worker DeliveryWorker) retry(job *gobble.Job) {
 
 func (worker DeliveryWorker) shouldDeliver(delivery Delivery) bool {
 	conn := worker.database.Connection()
+	if worker.isSuppressed(conn, delivery.Email) {
+		worker.logger.Printf("Not delivering because %s is on the suppression list", delivery.Email)
+		worker.updateMessageStatus(delivery, StatusSuppressed)
+		return false
+	}
+
 	if worker.isCritical(conn, delivery.Options.KindID, delivery.ClientID) {
 		return true
 	}
@@ -198,6 +269,28 @@ func (worker DeliveryWorker) shouldDeliver(delivery Delivery) bool {
 	return false
 }
 
+func (worker DeliveryWorker) isSuppressed(conn models.ConnectionInterface, email string) bool {
+	if email == "" {
+		return false
+	}
+
+	_, err := worker.suppressionsRepo.Find(conn, email)
+	return err == nil
+}
+
+func (worker DeliveryWorker) suppress(email string, err mail.SMTPError) {
+	_, suppressErr := worker.suppressionsRepo.Upsert(worker.database.Connection(), models.Suppression{
+		Email:  email,
+		Reason: err.Error(),
+	})
+	if suppressErr != nil {
+		worker.logger.Printf("Failed to suppress %s. Error: %s", email, suppressErr.Error())
+		return
+	}
+
+	worker.logger.Printf("Added %s to the suppression list", email)
+}
+
 func (worker DeliveryWorker) isCritical(conn models.ConnectionInterface, kindID, clientID string) bool {
 	kind, err := worker.kindsRepo.Find(conn, kindID, clientID)
 	if _, ok := err.(models.RecordNotFoundError); ok {
@@ -207,7 +300,7 @@ func (worker DeliveryWorker) isCritical(conn models.ConnectionInterface, kindID,
 	return kind.Critical
 }
 
-func (worker DeliveryWorker) pack(delivery Delivery) (mail.Message, error) {
+func (worker DeliveryWorker) pack(delivery Delivery) (mail.Message, Templates, error) {
 	var message mail.Message
 
 	cloak, err := conceal.NewCloak([]byte(worker.encryptionKey))
@@ -217,7 +310,7 @@ func (worker DeliveryWorker) pack(delivery Delivery) (mail.Message, error) {
 
 	templates, err := worker.templatesLoader.LoadTemplates(delivery.ClientID, delivery.Options.KindID)
 	if err != nil {
-		return message, err
+		return message, templates, err
 	}
 
 	context := NewMessageContext(delivery, worker.sender, cloak, templates)
@@ -225,27 +318,42 @@ func (worker DeliveryWorker) pack(delivery Delivery) (mail.Message, error) {
 
 	message, err = packager.Pack(context)
 	if err != nil {
-		return message, err
+		return message, templates, err
 	}
 
-	return message, nil
+	return message, templates, nil
 }
 
-func (worker DeliveryWorker) sendMail(message mail.Message) string {
+func (worker DeliveryWorker) sendMail(message mail.Message) (string, string, error) {
 	err := worker.mailClient.Connect()
 	if err != nil {
 		worker.logger.Printf("Error Establishing SMTP Connection: %s", err.Error())
-		return StatusUnavailable
+		return StatusUnavailable, "", err
 	}
 
 	worker.logger.Printf("Attempting to deliver message to %s", message.To)
-	err = worker.mailClient.Send(message)
+	var relay string
+	if sender, ok := worker.mailClient.(mail.RelaySender); ok {
+		relay, err = sender.SendVia(message)
+	} else {
+		err = worker.mailClient.Send(message)
+	}
 	if err != nil {
 		worker.logger.Printf("Failed to deliver message due to SMTP error: %s", err.Error())
-		return StatusFailed
+		if smtpErr, ok := err.(mail.SMTPError); ok && smtpErr.Undeliverable() {
+			if smtpErr.Command == "RCPT" {
+				worker.suppress(message.To, smtpErr)
+			}
+			return StatusUndeliverable, relay, err
+		}
+		return StatusFailed, relay, err
 	}
 
-	worker.logger.Printf("Message was successfully sent to %s", message.To)
+	if relay != "" {
+		worker.logger.Printf("Message was successfully sent to %s via %s", message.To, relay)
+	} else {
+		worker.logger.Printf("Message was successfully sent to %s", message.To)
+	}
 
-	return StatusDelivered
+	return StatusDelivered, relay, nil
 }
@@ -39,6 +39,7 @@ var _ = Describe("DeliveryWorker", func() {
 	var queue *fakes.Queue
 	var unsubscribesRepo *fakes.UnsubscribesRepo
 	var globalUnsubscribesRepo *fakes.GlobalUnsubscribesRepo
+	var suppressionsRepo *fakes.SuppressionsRepo
 	var kindsRepo *fakes.KindsRepo
 	var messagesRepo *fakes.MessagesRepo
 	var database *fakes.Database
@@ -49,6 +50,8 @@ var _ = Describe("DeliveryWorker", func() {
 	var templateLoader *fakes.TemplatesLoader
 	var receiptsRepo *fakes.ReceiptsRepo
 	var tokenLoader *fakes.TokenLoader
+	var campaignExpander *fakes.CampaignExpander
+	var campaignsRepo *fakes.CampaignsRepo
 
 	BeforeEach(func() {
 		buffer = bytes.NewBuffer([]byte{})
@@ -58,6 +61,7 @@ var _ = Describe("DeliveryWorker", func() {
 		queue = fakes.NewQueue()
 		unsubscribesRepo = fakes.NewUnsubscribesRepo()
 		globalUnsubscribesRepo = fakes.NewGlobalUnsubscribesRepo()
+		suppressionsRepo = fakes.NewSuppressionsRepo()
 		kindsRepo = fakes.NewKindsRepo()
 		messagesRepo = fakes.NewMessagesRepo()
 		database = fakes.NewDatabase()
@@ -78,9 +82,11 @@ var _ = Describe("DeliveryWorker", func() {
 			Subject: "{{.Subject}}",
 		}
 		receiptsRepo = fakes.NewReceiptsRepo()
+		campaignExpander = fakes.NewCampaignExpander()
+		campaignsRepo = fakes.NewCampaignsRepo()
 
-		worker = postal.NewDeliveryWorker(id, logger, &mailClient, queue, globalUnsubscribesRepo, unsubscribesRepo, kindsRepo,
-			messagesRepo, database, sender, encryptionKey, userLoader, templateLoader, receiptsRepo, tokenLoader)
+		worker = postal.NewDeliveryWorker(id, logger, &mailClient, queue, globalUnsubscribesRepo, unsubscribesRepo, suppressionsRepo, kindsRepo,
+			messagesRepo, database, sender, encryptionKey, userLoader, templateLoader, receiptsRepo, tokenLoader, campaignExpander, campaignsRepo)
 
 		delivery = postal.Delivery{
 			ClientID: "some-client",
@@ -155,6 +161,33 @@ var _ = Describe("DeliveryWorker", func() {
 			Expect(message.Status).To(Equal(postal.StatusDelivered))
 		})
 
+		It("records the relay that accepted the message", func() {
+			mailClient.Relay = "smtp.example.com:587"
+			messageID := getMessageIDFromJob(job)
+			worker.Deliver(&job)
+
+			message, err := messagesRepo.FindByID(conn, messageID)
+			if err != nil {
+				panic(err)
+			}
+
+			Expect(message.Relay).To(Equal("smtp.example.com:587"))
+
+			results := strings.Split(buffer.String(), "\n")
+			Expect(results).To(ContainElement("Message was successfully sent to user-123@example.com via smtp.example.com:587"))
+		})
+
+		It("renders the free-form data carried in the job into the templates", func() {
+			templateLoader.Templates.Text = "Your app {{.Data.AppName}} crashed {{.Data.Crashes}} times"
+			delivery.Options.Data = map[string]interface{}{"AppName": "my-app", "Crashes": 3}
+			job = gobble.NewJob(delivery)
+
+			worker.Deliver(&job)
+
+			Expect(mailClient.Messages).To(HaveLen(1))
+			Expect(mailClient.Messages[0].Body[0].Content).To(Equal("Your app my-app crashed 3 times"))
+		})
+
 		It("creates a reciept for the delivery", func() {
 			worker.Deliver(&job)
 
@@ -204,6 +237,7 @@ var _ = Describe("DeliveryWorker", func() {
 			worker.Deliver(&job)
 
 			Expect(mailClient.Messages).To(ContainElement(mail.Message{
+				ID:      "randomly-generated-guid",
 				From:    "from@email.com",
 				ReplyTo: "thesender@example.com",
 				To:      fakeUserEmail,
@@ -262,6 +296,96 @@ var _ = Describe("DeliveryWorker", func() {
 				})
 			})
 
+			Context("because the recipient was permanently rejected", func() {
+				BeforeEach(func() {
+					mailClient.SendError = mail.SMTPError{
+						Command: "RCPT",
+						Code:    550,
+						Message: "5.1.1 mailbox does not exist",
+					}
+				})
+
+				It("does not retry the job", func() {
+					worker.Deliver(&job)
+
+					Expect(job.ShouldRetry).To(BeFalse())
+					Expect(job.ShouldBury).To(BeFalse())
+					Expect(job.RetryCount).To(Equal(0))
+				})
+
+				It("upserts the StatusUndeliverable to the database", func() {
+					messageID := getMessageIDFromJob(job)
+					worker.Deliver(&job)
+
+					message, err := messagesRepo.FindByID(conn, messageID)
+					if err != nil {
+						panic(err)
+					}
+
+					Expect(message.Status).To(Equal(postal.StatusUndeliverable))
+				})
+
+				It("logs that the message was rejected", func() {
+					worker.Deliver(&job)
+
+					Expect(buffer.String()).To(ContainSubstring("Not retrying because the message was permanently rejected: RCPT failed with 550 5.1.1 mailbox does not exist"))
+				})
+
+				It("adds the recipient to the suppression list", func() {
+					worker.Deliver(&job)
+
+					suppression, err := suppressionsRepo.Find(conn, fakeUserEmail)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(suppression.Reason).To(Equal("RCPT failed with 550 5.1.1 mailbox does not exist"))
+				})
+
+				It("does not suppress the recipient when the message itself was rejected", func() {
+					mailClient.SendError = mail.SMTPError{
+						Command: "DATA",
+						Code:    554,
+						Message: "5.7.1 message content rejected",
+					}
+
+					worker.Deliver(&job)
+
+					Expect(suppressionsRepo.Suppressions).To(BeEmpty())
+				})
+			})
+
+			Context("because the server replied with a transient error", func() {
+				BeforeEach(func() {
+					mailClient.SendError = mail.SMTPError{
+						Command: "RCPT",
+						Code:    451,
+						Message: "4.3.0 try again later",
+					}
+				})
+
+				It("marks the job for retry", func() {
+					worker.Deliver(&job)
+
+					Expect(job.ShouldRetry).To(BeTrue())
+				})
+
+				It("upserts the StatusFailed to the database", func() {
+					messageID := getMessageIDFromJob(job)
+					worker.Deliver(&job)
+
+					message, err := messagesRepo.FindByID(conn, messageID)
+					if err != nil {
+						panic(err)
+					}
+
+					Expect(message.Status).To(Equal(postal.StatusFailed))
+				})
+
+				It("does not suppress the recipient", func() {
+					worker.Deliver(&job)
+
+					Expect(suppressionsRepo.Suppressions).To(BeEmpty())
+				})
+			})
+
 			Context("and the error is a connect error", func() {
 				It("logs an SMTP timeout error", func() {
 					mailClient.ConnectError = errors.New("server timeout")
@@ -344,7 +468,88 @@ var _ = Describe("DeliveryWorker", func() {
 					job.ShouldRetry = false
 					worker.Deliver(&job)
 					Expect(job.ShouldRetry).To(BeFalse())
+					Expect(job.ShouldBury).To(BeTrue())
+				})
+
+				Context("when the kind has a retry policy", func() {
+					BeforeEach(func() {
+						mailClient.ConnectError = errors.New("BOOM!")
+						delivery.Options.RetryPolicy = models.RetryPolicy{
+							MaxAttempts:      2,
+							BaseDelaySeconds: 30,
+						}
+						job = gobble.NewJob(delivery)
+					})
+
+					It("retries using the policy and buries the job once the attempts run out", func() {
+						worker.Deliver(&job)
+						Expect(job.ActiveAt).To(BeTemporally("~", time.Now().Add(30*time.Second), 10*time.Second))
+						Expect(job.RetryCount).To(Equal(1))
+
+						job.ShouldRetry = false
+						worker.Deliver(&job)
+						Expect(job.ShouldRetry).To(BeFalse())
+						Expect(job.ShouldBury).To(BeTrue())
+					})
+				})
+
+				Context("when the job has exhausted its retries", func() {
+					BeforeEach(func() {
+						mailClient.ConnectError = errors.New("BOOM!")
+						job.RetryCount = 10
+					})
+
+					It("marks the job to be buried with the last error", func() {
+						worker.Deliver(&job)
+
+						Expect(job.ShouldRetry).To(BeFalse())
+						Expect(job.ShouldBury).To(BeTrue())
+						Expect(job.LastError).To(Equal("BOOM!"))
+					})
+
+					It("logs that the job is being moved to the dead jobs queue", func() {
+						worker.Deliver(&job)
+
+						Expect(buffer.String()).To(ContainSubstring("Message failed to send after 10 retries, moving it to the dead jobs queue"))
+					})
+				})
+			})
+		})
+
+		Context("when the recipient's address is suppressed", func() {
+			BeforeEach(func() {
+				_, err := suppressionsRepo.Upsert(conn, models.Suppression{Email: fakeUserEmail})
+				if err != nil {
+					panic(err)
+				}
+			})
+
+			It("does not send the notification, even when it is critical", func() {
+				_, err := kindsRepo.Create(conn, models.Kind{
+					ID:       "some-kind",
+					ClientID: "some-client",
+					Critical: true,
 				})
+				if err != nil {
+					panic(err)
+				}
+
+				worker.Deliver(&job)
+
+				Expect(mailClient.Messages).To(HaveLen(0))
+				Expect(buffer.String()).To(ContainSubstring("Not delivering because user-123@example.com is on the suppression list"))
+			})
+
+			It("upserts the StatusSuppressed to the database", func() {
+				messageID := getMessageIDFromJob(job)
+				worker.Deliver(&job)
+
+				message, err := messagesRepo.FindByID(conn, messageID)
+				if err != nil {
+					panic(err)
+				}
+
+				Expect(message.Status).To(Equal(postal.StatusSuppressed))
 			})
 		})
 
@@ -503,6 +708,98 @@ var _ = Describe("DeliveryWorker", func() {
 			})
 		})
 
+		Context("when the job is a campaign", func() {
+			var campaign postal.Campaign
+
+			BeforeEach(func() {
+				campaign = postal.Campaign{
+					JobType:  postal.CampaignJobType,
+					ID:       "campaign-123",
+					Audience: "spaces",
+					GUID:     "space-001",
+					ClientID: "some-client",
+					Options:  delivery.Options,
+				}
+				job = gobble.NewJob(campaign)
+			})
+
+			It("expands the audience of the campaign instead of sending an email", func() {
+				worker.Deliver(&job)
+
+				Expect(campaignExpander.ExpandedCampaigns).To(Equal([]postal.Campaign{campaign}))
+				Expect(mailClient.Messages).To(BeEmpty())
+				Expect(receiptsRepo.CreateUserGUIDs).To(BeEmpty())
+			})
+
+			It("marks the job for retry later when the expansion fails", func() {
+				campaignExpander.ExpandError = errors.New("UAA is down")
+
+				worker.Deliver(&job)
+
+				Expect(job.ActiveAt).To(BeTemporally("~", time.Now().Add(1*time.Minute), 10*time.Second))
+				Expect(job.RetryCount).To(Equal(1))
+				Expect(buffer.String()).To(ContainSubstring("Failed to expand the audience of campaign campaign-123: UAA is down"))
+			})
+		})
+
+		Context("when the delivery belongs to a campaign", func() {
+			It("records the campaign along with the message status", func() {
+				delivery.Options.CampaignID = "campaign-123"
+				job = gobble.NewJob(delivery)
+
+				worker.Deliver(&job)
+
+				Expect(messagesRepo.Messages["randomly-generated-guid"]).To(Equal(models.Message{
+					ID:         "randomly-generated-guid",
+					Status:     postal.StatusDelivered,
+					CampaignID: "campaign-123",
+				}))
+			})
+
+			It("counts the delivery against the campaign", func() {
+				delivery.Options.CampaignID = "campaign-123"
+				job = gobble.NewJob(delivery)
+
+				worker.Deliver(&job)
+
+				Expect(campaignsRepo.Campaigns["campaign-123"].DeliveredCount).To(Equal(1))
+			})
+
+			It("does not count a delivery that failed", func() {
+				mailClient.SendError = errors.New("Error sending message!!!")
+				delivery.Options.CampaignID = "campaign-123"
+				job = gobble.NewJob(delivery)
+
+				worker.Deliver(&job)
+
+				Expect(campaignsRepo.Campaigns["campaign-123"].DeliveredCount).To(Equal(0))
+			})
+
+			It("logs when the delivery cannot be counted", func() {
+				campaignsRepo.IncrementError = errors.New("BOOM!")
+				delivery.Options.CampaignID = "campaign-123"
+				job = gobble.NewJob(delivery)
+
+				worker.Deliver(&job)
+
+				Expect(buffer.String()).To(ContainSubstring("Failed to count notification randomly-generated-guid as delivered by campaign campaign-123. Error: BOOM!"))
+			})
+		})
+
+		It("records the template version the message was rendered from", func() {
+			templateLoader.Templates.ID = "some-template"
+			templateLoader.Templates.Version = 4
+
+			worker.Deliver(&job)
+
+			Expect(messagesRepo.Messages["randomly-generated-guid"]).To(Equal(models.Message{
+				ID:              "randomly-generated-guid",
+				Status:          postal.StatusDelivered,
+				TemplateID:      "some-template",
+				TemplateVersion: 4,
+			}))
+		})
+
 		Context("when the job contains malformed JSON", func() {
 			BeforeEach(func() {
 				job.Payload = `{"Space":"my-space","Options":{"HTML":"<p>some text that just abruptly ends`
@@ -537,6 +834,7 @@ var _ = Describe("DeliveryWorker", func() {
 				worker.Deliver(&job)
 
 				Expect(mailClient.Messages).To(ContainElement(mail.Message{
+					ID:      "randomly-generated-guid",
 					From:    "from@email.com",
 					ReplyTo: "thesender@example.com",
 					To:      fakeUserEmail,
@@ -28,6 +28,12 @@ func (err TemplateLoadError) Error() string {
 	return string(err)
 }
 
+type TemplateRenderError string
+
+func (err TemplateRenderError) Error() string {
+	return string(err)
+}
+
 type CriticalNotificationError struct {
 	kindID string
 }
@@ -1,8 +1,7 @@
 package postal
 
 import (
-	"html"
-
+	"github.com/cloudfoundry-incubator/notifications/mail"
 	"github.com/pivotal-golang/conceal"
 )
 
@@ -30,6 +29,8 @@ type MessageContext struct {
 	Scope             string
 	Endorsement       string
 	OrganizationRole  string
+	Attachments       []mail.Attachment
+	Data              map[string]interface{}
 }
 
 func NewMessageContext(delivery Delivery, sender string, cloak conceal.CloakInterface, templates Templates) MessageContext {
@@ -72,6 +73,8 @@ func NewMessageContext(delivery Delivery, sender string, cloak conceal.CloakInte
 		Scope:             delivery.Scope,
 		Endorsement:       options.Endorsement,
 		OrganizationRole:  options.Role,
+		Attachments:       options.Attachments,
+		Data:              options.Data,
 	}
 
 	if messageContext.Subject == "" {
@@ -86,18 +89,3 @@ func NewMessageContext(delivery Delivery, sender string, cloak conceal.CloakInte
 	messageContext.UnsubscribeID = string(unsubscribeID)
 	return messageContext
 }
-
-func (context *MessageContext) Escape() {
-	context.From = html.EscapeString(context.From)
-	context.To = html.EscapeString(context.To)
-	context.ReplyTo = html.EscapeString(context.ReplyTo)
-	context.Subject = html.EscapeString(context.Subject)
-	context.Text = html.EscapeString(context.Text)
-	context.KindDescription = html.EscapeString(context.KindDescription)
-	context.SourceDescription = html.EscapeString(context.SourceDescription)
-	context.ClientID = html.EscapeString(context.ClientID)
-	context.MessageID = html.EscapeString(context.MessageID)
-	context.Space = html.EscapeString(context.Space)
-	context.Organization = html.EscapeString(context.Organization)
-	context.Endorsement = html.EscapeString(context.Endorsement)
-}
@@ -3,6 +3,7 @@ package postal_test
 import (
 	"github.com/cloudfoundry-incubator/notifications/cf"
 	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/mail"
 	"github.com/cloudfoundry-incubator/notifications/postal"
 
 	. "github.com/onsi/ginkgo"
@@ -41,6 +42,10 @@ var _ = Describe("MessageContext", func() {
 			KindID:            "the-kind-id",
 			Endorsement:       "this is the endorsement",
 			Role:              "OrgRole",
+			Attachments: []mail.Attachment{
+				{Filename: "invoice.pdf", ContentType: "application/pdf", Content: []byte("%PDF-1.4")},
+			},
+			Data: map[string]interface{}{"AppName": "my-app"},
 		}
 
 		delivery = postal.Delivery{
@@ -93,6 +98,8 @@ var _ = Describe("MessageContext", func() {
 			Expect(cloak.DataToEncrypt).To(Equal([]byte("the-user|the-client-id|the-kind-id")))
 			Expect(context.Endorsement).To(Equal("this is the endorsement"))
 			Expect(context.OrganizationRole).To(Equal("OrgRole"))
+			Expect(context.Attachments).To(Equal(options.Attachments))
+			Expect(context.Data).To(Equal(options.Data))
 		})
 
 		It("falls back to Kind if KindDescription is missing", func() {
@@ -115,52 +122,4 @@ var _ = Describe("MessageContext", func() {
 			Expect(context.Subject).To(Equal("[no subject]"))
 		})
 	})
-
-	Describe("Escape", func() {
-		BeforeEach(func() {
-			options = postal.Options{
-				ReplyTo:           "awesomeness",
-				Subject:           "the & subject",
-				KindDescription:   "the & kind description",
-				SourceDescription: "the & source description",
-				Text:              "user & supplied email text",
-				HTML:              postal.HTML{BodyContent: "user & supplied html"},
-				KindID:            "the & kind",
-				Endorsement:       "this & is the endorsement",
-				Role:              "OrgRole",
-			}
-
-			delivery.Options = options
-			delivery.ClientID = "the\"client id"
-			delivery.MessageID = "some>id"
-			delivery.Space.Name = "the<space"
-			delivery.Organization.Name = "the>org"
-			delivery.Scope = ""
-		})
-
-		It("html escapes various fields on the message context", func() {
-			context := postal.NewMessageContext(delivery, sender, cloak, templates)
-			context.Escape()
-
-			Expect(context.From).To(Equal("no-reply@notifications.example.com"))
-			Expect(context.ReplyTo).To(Equal("awesomeness"))
-			Expect(context.To).To(Equal("bounce@example.com"))
-			Expect(context.Subject).To(Equal("the &amp; subject"))
-			Expect(context.Text).To(Equal("user &amp; supplied email text"))
-			Expect(context.HTML).To(Equal("user & supplied html"))
-			Expect(context.TextTemplate).To(Equal("the plainText email < template"))
-			Expect(context.HTMLTemplate).To(Equal("the html <h1> email < template</h1>"))
-			Expect(context.SubjectTemplate).To(Equal("the subject < template"))
-			Expect(context.KindDescription).To(Equal("the &amp; kind description"))
-			Expect(context.SourceDescription).To(Equal("the &amp; source description"))
-			Expect(context.UserGUID).To(Equal("the-user"))
-			Expect(context.ClientID).To(Equal("the&#34;client id"))
-			Expect(context.MessageID).To(Equal("some&gt;id"))
-			Expect(context.Space).To(Equal("the&lt;space"))
-			Expect(context.Organization).To(Equal("the&gt;org"))
-			Expect(context.Scope).To(Equal(""))
-			Expect(context.Endorsement).To(Equal("this &amp; is the endorsement"))
-			Expect(context.OrganizationRole).To(Equal("OrgRole"))
-		})
-	})
 })
@@ -1,15 +1,35 @@
 package postal
 
-import "github.com/nu7hatch/gouuid"
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/nu7hatch/gouuid"
+)
+
+const (
+	StatusUnavailable   = "unavailable"
+	StatusFailed        = "failed"
+	StatusUndeliverable = "undeliverable"
+	StatusSuppressed    = "suppressed"
+	StatusDelivered     = models.StatusDelivered
+	StatusBounced       = models.StatusBounced
+	StatusQueued        = models.StatusQueued
+	StatusScheduled     = models.StatusScheduled
+	StatusCanceled      = models.StatusCanceled
+)
 
 const (
-	StatusUnavailable = "unavailable"
-	StatusFailed      = "failed"
-	StatusDelivered   = "delivered"
-	StatusQueued      = "queued"
+	PriorityNormal   = 0
+	PriorityCritical = 10
 )
 
+// Templates is the content a message is rendered from. ID and Version
+// identify the stored template version it was loaded from, if any.
 type Templates struct {
+	ID      string
+	Version int
 	Name    string
 	Subject string
 	Text    string
@@ -36,4 +56,11 @@ type Options struct {
 	To                string
 	Role              string
 	Endorsement       string
+	Critical          bool
+	Priority          int
+	SendAt            time.Time
+	CampaignID        string
+	RetryPolicy       models.RetryPolicy
+	Attachments       []mail.Attachment
+	Data              map[string]interface{}
 }
@@ -3,6 +3,8 @@ package postal
 import (
 	"bytes"
 	"fmt"
+	htmltemplate "html/template"
+	"io/ioutil"
 	"strings"
 	"text/template"
 
@@ -29,17 +31,19 @@ func (packager Packager) Pack(context MessageContext) (mail.Message, error) {
 		return mail.Message{}, err
 	}
 
-	compiledSubject, err := packager.compileTemplate(context, context.SubjectTemplate, false)
+	compiledSubject, err := packager.compileTemplate(context, context.SubjectTemplate)
 	if err != nil {
 		return mail.Message{}, err
 	}
 
 	return mail.Message{
-		From:    context.From,
-		ReplyTo: context.ReplyTo,
-		To:      context.To,
-		Subject: compiledSubject,
-		Body:    parts,
+		ID:          context.MessageID,
+		From:        context.From,
+		ReplyTo:     context.ReplyTo,
+		To:          context.To,
+		Subject:     compiledSubject,
+		Body:        parts,
+		Attachments: context.Attachments,
 		Headers: []string{
 			fmt.Sprintf("X-CF-Client-ID: %s", context.ClientID),
 			fmt.Sprintf("X-CF-Notification-ID: %s", context.MessageID),
@@ -51,13 +55,13 @@ func (packager Packager) CompileParts(context MessageContext) ([]mail.Part, erro
 	var parts []mail.Part
 	var err error
 
-	context.Endorsement, err = packager.compileTemplate(context, context.Endorsement, false)
+	context.Endorsement, err = packager.compileTemplate(context, context.Endorsement)
 	if err != nil {
 		return parts, err
 	}
 
 	if context.Text != "" {
-		plainText, err := packager.compileTemplate(context, context.TextTemplate, false)
+		plainText, err := packager.compileTemplate(context, context.TextTemplate)
 		if err != nil {
 			return parts, err
 		}
@@ -70,14 +74,15 @@ func (packager Packager) CompileParts(context MessageContext) ([]mail.Part, erro
 	}
 
 	if context.HTML != "" {
-		var err error
+		data := newHTMLContext(context)
 
-		context.HTMLComponents.BodyContent, err = packager.compileTemplate(context, context.HTMLTemplate, true)
+		bodyContent, err := packager.compileHTMLTemplate(data, context.HTMLTemplate)
 		if err != nil {
 			return parts, err
 		}
+		data.HTMLComponents.BodyContent = htmltemplate.HTML(bodyContent)
 
-		htmlPart, err := packager.compileTemplate(context, HTMLWrapperTemplate, true)
+		htmlPart, err := packager.compileHTMLTemplate(data, HTMLWrapperTemplate)
 		if err != nil {
 			return parts, err
 		}
@@ -91,20 +96,85 @@ func (packager Packager) CompileParts(context MessageContext) ([]mail.Part, erro
 	return parts, nil
 }
 
-func (packager Packager) compileTemplate(context MessageContext, theTemplate string, escapeContext bool) (string, error) {
+func (packager Packager) compileTemplate(context MessageContext, theTemplate string) (string, error) {
 	buffer := bytes.NewBuffer([]byte{})
 
-	source, err := template.New("compileTemplate").Parse(theTemplate)
+	source, err := template.New("compileTemplate").Funcs(TemplateHelpers()).Parse(theTemplate)
 	if err != nil {
 		return "", err
 	}
 
-	if escapeContext {
-		context.Escape()
+	err = source.Execute(buffer, context)
+	if err != nil {
+		return "", err
 	}
+	compiledTemplate := strings.TrimSuffix(buffer.String(), "\n")
+
+	return compiledTemplate, nil
+}
+
+// compileHTMLTemplate renders with html/template, which escapes every value
+// for the context it appears in.
+func (packager Packager) compileHTMLTemplate(data htmlContext, theTemplate string) (string, error) {
+	buffer := bytes.NewBuffer([]byte{})
 
-	source.Execute(buffer, context)
+	source, err := htmltemplate.New("compileHTMLTemplate").Funcs(htmltemplate.FuncMap(TemplateHelpers())).Parse(theTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	err = source.Execute(buffer, data)
+	if err != nil {
+		return "", err
+	}
 	compiledTemplate := strings.TrimSuffix(buffer.String(), "\n")
 
 	return compiledTemplate, nil
 }
+
+// ValidateHTMLTemplate checks that an HTML template parses and that
+// html/template can escape it, by rendering it once against an empty context.
+// Errors that come from the values themselves are not reported, since the
+// values are only known when a message is sent.
+func ValidateHTMLTemplate(theTemplate string) error {
+	source, err := htmltemplate.New("validateHTMLTemplate").Funcs(htmltemplate.FuncMap(TemplateHelpers())).Parse(theTemplate)
+	if err != nil {
+		return err
+	}
+
+	err = source.Execute(ioutil.Discard, newHTMLContext(MessageContext{}))
+	if _, ok := err.(*htmltemplate.Error); ok {
+		return err
+	}
+
+	return nil
+}
+
+// htmlContext is the MessageContext as seen by HTML templates. The HTML
+// supplied by the caller is trusted and written as it is; every other field
+// is escaped.
+type htmlContext struct {
+	MessageContext
+	HTML           htmltemplate.HTML
+	HTMLComponents htmlComponents
+}
+
+type htmlComponents struct {
+	Doctype        htmltemplate.HTML
+	Head           htmltemplate.HTML
+	BodyAttributes htmltemplate.HTMLAttr
+	BodyContent    htmltemplate.HTML
+}
+
+func newHTMLContext(context MessageContext) htmlContext {
+	return htmlContext{
+		MessageContext: context,
+		HTML:           htmltemplate.HTML(context.HTML),
+		HTMLComponents: htmlComponents{
+			Doctype:        htmltemplate.HTML(context.HTMLComponents.Doctype),
+			Head:           htmltemplate.HTML(context.HTMLComponents.Head),
+			BodyAttributes: htmltemplate.HTMLAttr(context.HTMLComponents.BodyAttributes),
+			BodyContent:    htmltemplate.HTML(context.HTMLComponents.BodyContent),
+		},
+	}
+}
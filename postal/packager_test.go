@@ -1,6 +1,8 @@
 package postal_test
 
 import (
+	"strings"
+
 	"github.com/cloudfoundry-incubator/notifications/mail"
 	"github.com/cloudfoundry-incubator/notifications/postal"
 
@@ -118,5 +120,135 @@ Banana preamble <p>user supplied banana html</p>  3&amp;3 4&#39;4 user-123
 				}))
 			})
 		})
+		It("makes the template helpers available to every template", func() {
+			context.SubjectTemplate = "{{upper .Subject}}"
+			context.TextTemplate = `{{truncate 8 .Text}}`
+			context.HTMLTemplate = `<a href="{{url "https://example.com/spaces" .Space}}">{{.Organization | default "your org"}}</a>`
+			context.Space = "dev space"
+			context.Organization = ""
+
+			message, err := packager.Pack(context)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(message.Subject).To(Equal("WE WILL BE EATEN"))
+			Expect(message.Body[0].Content).To(Equal("User ..."))
+			Expect(message.Body[1].Content).To(ContainSubstring(`<a href="https://example.com/spaces/dev%20space">your org</a>`))
+		})
+
+		It("makes the free-form data available to every template", func() {
+			context.Data = map[string]interface{}{
+				"AppName": `<b>my-app</b>`,
+				"Crashes": float64(3),
+			}
+			context.SubjectTemplate = `{{.Data.AppName}} crashed`
+			context.TextTemplate = `Your app {{.Data.AppName}} crashed {{.Data.Crashes}} {{pluralize .Data.Crashes "time" "times"}}`
+			context.HTMLTemplate = `<p title="{{.Data.AppName}}">Your app {{.Data.AppName}} crashed</p>`
+
+			message, err := packager.Pack(context)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(message.Subject).To(Equal("<b>my-app</b> crashed"))
+			Expect(message.Body[0].Content).To(Equal("Your app <b>my-app</b> crashed 3 times"))
+			Expect(message.Body[1].Content).To(ContainSubstring(`<p title="&lt;b&gt;my-app&lt;/b&gt;">Your app &lt;b&gt;my-app&lt;/b&gt; crashed</p>`))
+		})
+
+		It("returns the errors from executing the templates", func() {
+			context.TextTemplate = "{{.Nope}}"
+
+			_, err := packager.CompileParts(context)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("can't evaluate field Nope"))
+
+			context.TextTemplate = "{{.Text}}"
+			context.HTMLTemplate = "<p>{{.Nope}}</p>"
+
+			_, err = packager.CompileParts(context)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("can't evaluate field Nope"))
+		})
+
+		Context("when context fields contain markup", func() {
+			const injected = `<script>alert("pwned")</script>`
+
+			BeforeEach(func() {
+				context.Text = "some text"
+				context.HTMLTemplate = "{{.Endorsement}}|{{.From}}|{{.ReplyTo}}|{{.To}}|{{.Subject}}|{{.Text}}|" +
+					"{{.KindDescription}}|{{.SourceDescription}}|{{.UserGUID}}|{{.ClientID}}|{{.MessageID}}|" +
+					"{{.Space}}|{{.SpaceGUID}}|{{.Organization}}|{{.OrganizationGUID}}|{{.UnsubscribeID}}|" +
+					"{{.Scope}}|{{.OrganizationRole}}|{{.HTML}}"
+			})
+
+			It("escapes every field in the html part", func() {
+				context.Endorsement = injected
+				context.From = injected
+				context.ReplyTo = injected
+				context.To = injected
+				context.Subject = injected
+				context.Text = injected
+				context.KindDescription = injected
+				context.SourceDescription = injected
+				context.UserGUID = injected
+				context.ClientID = injected
+				context.MessageID = injected
+				context.Space = injected
+				context.SpaceGUID = injected
+				context.Organization = injected
+				context.OrganizationGUID = injected
+				context.UnsubscribeID = injected
+				context.Scope = injected
+				context.OrganizationRole = injected
+
+				parts, err := packager.CompileParts(context)
+				Expect(err).NotTo(HaveOccurred())
+
+				html := parts[1].Content
+				Expect(html).NotTo(ContainSubstring("<script>"))
+				Expect(strings.Count(html, "&lt;script&gt;alert(&#34;pwned&#34;)&lt;/script&gt;")).To(Equal(18))
+				Expect(html).To(ContainSubstring("<p>user supplied banana html</p>"))
+			})
+
+			It("escapes values for the attribute and url contexts they appear in", func() {
+				context.HTMLTemplate = `<a title="{{.Organization}}" href="https://example.com/unsubscribe/{{.UnsubscribeID}}">{{.Space}}</a>` +
+					`<a href="{{.Space}}">space</a><script>var role = {{.OrganizationRole}};</script>`
+				context.Organization = `" onmouseover="alert(1)`
+				context.UnsubscribeID = `a b"><script>`
+				context.Space = `javascript:alert(1)`
+				context.OrganizationRole = `"; alert(1); "`
+
+				parts, err := packager.CompileParts(context)
+				Expect(err).NotTo(HaveOccurred())
+
+				html := parts[1].Content
+				Expect(html).To(ContainSubstring(`title="&#34; onmouseover=&#34;alert(1)"`))
+				Expect(html).To(ContainSubstring(`href="https://example.com/unsubscribe/a%20b%22%3e%3cscript%3e"`))
+				Expect(html).To(ContainSubstring(`<a href="#ZgotmplZ">space</a>`))
+				Expect(html).To(ContainSubstring(`var role = "\"; alert(1); \"";`))
+			})
+
+			It("leaves the text part and the subject unescaped", func() {
+				context.Subject = injected
+				context.Text = injected
+
+				message, err := packager.Pack(context)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(message.Subject).To(Equal("The Subject: " + injected))
+				Expect(message.Body[0].Content).To(ContainSubstring(injected))
+			})
+		})
+	})
+
+	Describe("Pack", func() {
+		It("carries the attachments over to the message", func() {
+			context.Attachments = []mail.Attachment{
+				{Filename: "invoice.pdf", ContentType: "application/pdf", Content: []byte("%PDF-1.4")},
+			}
+
+			message, err := packager.Pack(context)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(message.ID).To(Equal("4'4"))
+			Expect(message.Attachments).To(Equal(context.Attachments))
+		})
 	})
 })
@@ -0,0 +1,56 @@
+package postal
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/models"
+)
+
+const (
+	DefaultRetryMaxAttempts = 11
+	DefaultRetryBaseDelay   = 1 * time.Minute
+)
+
+// RetryDelay returns how long to wait before making the next attempt at a
+// delivery that has already been retried retryCount times. It returns false
+// when the delivery should be given up on instead. Without a policy, a
+// delivery is retried 10 times, waiting 2^n minutes between attempts.
+func RetryDelay(policy models.RetryPolicy, retryCount int, queuedAt, now time.Time) (time.Duration, bool) {
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = DefaultRetryMaxAttempts
+	}
+
+	if retryCount+1 >= maxAttempts {
+		return 0, false
+	}
+
+	baseDelay := time.Duration(policy.BaseDelaySeconds) * time.Second
+	if baseDelay == 0 {
+		baseDelay = DefaultRetryBaseDelay
+	}
+
+	delay := time.Duration(math.MaxInt64)
+	backoff := float64(baseDelay) * math.Pow(2, float64(retryCount))
+	if backoff < float64(math.MaxInt64) {
+		delay = time.Duration(backoff)
+	}
+
+	maxDelay := time.Duration(policy.MaxDelaySeconds) * time.Second
+	if maxDelay > 0 && delay > maxDelay {
+		delay = maxDelay
+	}
+
+	if policy.Jitter > 0 {
+		delay -= time.Duration(rand.Float64() * policy.Jitter * float64(delay))
+	}
+
+	giveUpAfter := time.Duration(policy.GiveUpAfterSeconds) * time.Second
+	if giveUpAfter > 0 && !queuedAt.IsZero() && now.Add(delay).After(queuedAt.Add(giveUpAfter)) {
+		return 0, false
+	}
+
+	return delay, true
+}
@@ -0,0 +1,105 @@
+package postal_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/postal"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RetryDelay", func() {
+	var now time.Time
+
+	BeforeEach(func() {
+		now = time.Now()
+	})
+
+	Context("when no policy is set", func() {
+		It("backs off exponentially starting at one minute", func() {
+			delay, ok := postal.RetryDelay(models.RetryPolicy{}, 0, time.Time{}, now)
+			Expect(ok).To(BeTrue())
+			Expect(delay).To(Equal(1 * time.Minute))
+
+			delay, ok = postal.RetryDelay(models.RetryPolicy{}, 9, time.Time{}, now)
+			Expect(ok).To(BeTrue())
+			Expect(delay).To(Equal(512 * time.Minute))
+		})
+
+		It("gives up after 10 retries", func() {
+			_, ok := postal.RetryDelay(models.RetryPolicy{}, 10, time.Time{}, now)
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	It("uses the base delay of the policy", func() {
+		policy := models.RetryPolicy{BaseDelaySeconds: 10}
+
+		delay, ok := postal.RetryDelay(policy, 2, time.Time{}, now)
+		Expect(ok).To(BeTrue())
+		Expect(delay).To(Equal(40 * time.Second))
+	})
+
+	It("gives up once the maximum number of attempts has been made", func() {
+		policy := models.RetryPolicy{MaxAttempts: 3}
+
+		_, ok := postal.RetryDelay(policy, 1, time.Time{}, now)
+		Expect(ok).To(BeTrue())
+
+		_, ok = postal.RetryDelay(policy, 2, time.Time{}, now)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("caps the delay at the maximum delay", func() {
+		policy := models.RetryPolicy{BaseDelaySeconds: 60, MaxDelaySeconds: 300}
+
+		delay, ok := postal.RetryDelay(policy, 8, time.Time{}, now)
+		Expect(ok).To(BeTrue())
+		Expect(delay).To(Equal(5 * time.Minute))
+	})
+
+	It("does not overflow when the backoff grows very large", func() {
+		policy := models.RetryPolicy{MaxAttempts: 1000, MaxDelaySeconds: 3600}
+
+		delay, ok := postal.RetryDelay(policy, 500, time.Time{}, now)
+		Expect(ok).To(BeTrue())
+		Expect(delay).To(Equal(1 * time.Hour))
+	})
+
+	It("shortens the delay by up to the jitter fraction", func() {
+		policy := models.RetryPolicy{BaseDelaySeconds: 100, Jitter: 0.5}
+
+		for i := 0; i < 50; i++ {
+			delay, ok := postal.RetryDelay(policy, 0, time.Time{}, now)
+			Expect(ok).To(BeTrue())
+			Expect(delay).To(BeNumerically(">=", 50*time.Second))
+			Expect(delay).To(BeNumerically("<=", 100*time.Second))
+		}
+	})
+
+	Context("when the policy has a give up after duration", func() {
+		var policy models.RetryPolicy
+
+		BeforeEach(func() {
+			policy = models.RetryPolicy{BaseDelaySeconds: 60, GiveUpAfterSeconds: 600}
+		})
+
+		It("retries while the next attempt falls within the duration", func() {
+			delay, ok := postal.RetryDelay(policy, 2, now.Add(-5*time.Minute), now)
+			Expect(ok).To(BeTrue())
+			Expect(delay).To(Equal(4 * time.Minute))
+		})
+
+		It("gives up when the next attempt would fall outside the duration", func() {
+			_, ok := postal.RetryDelay(policy, 3, now.Add(-5*time.Minute), now)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("ignores the duration when the queued time is unknown", func() {
+			_, ok := postal.RetryDelay(policy, 3, time.Time{}, now)
+			Expect(ok).To(BeTrue())
+		})
+	})
+})
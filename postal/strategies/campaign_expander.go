@@ -0,0 +1,125 @@
+package strategies
+
+import (
+	"fmt"
+
+	"github.com/cloudfoundry-incubator/notifications/cf"
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/postal"
+)
+
+type Audience struct {
+	Space        cf.CloudControllerSpace
+	Organization cf.CloudControllerOrganization
+	Scope        string
+}
+
+// AudiencePage is a single page of the members of an audience. Next is the
+// cursor for the following page, and is empty on the last page.
+type AudiencePage struct {
+	Users []User
+	Next  string
+}
+
+type AudienceLoaderInterface interface {
+	Audience(postal.Campaign) (Audience, error)
+	AudiencePage(postal.Campaign, string) (AudiencePage, error)
+}
+
+type UnknownAudienceError string
+
+func (err UnknownAudienceError) Error() string {
+	return fmt.Sprintf("Campaign audience %q is not known", string(err))
+}
+
+type CampaignExpansionError string
+
+func (err CampaignExpansionError) Error() string {
+	return string(err)
+}
+
+type CampaignExpander struct {
+	audiences     map[string]AudienceLoaderInterface
+	mailer        MailerInterface
+	campaignsRepo models.CampaignsRepoInterface
+	database      models.DatabaseInterface
+}
+
+func NewCampaignExpander(audiences map[string]AudienceLoaderInterface, mailer MailerInterface,
+	campaignsRepo models.CampaignsRepoInterface, database models.DatabaseInterface) CampaignExpander {
+
+	return CampaignExpander{
+		audiences:     audiences,
+		mailer:        mailer,
+		campaignsRepo: campaignsRepo,
+		database:      database,
+	}
+}
+
+// Expand enqueues a delivery for every member of the campaign's audience,
+// looking the audience up one page at a time. Each page is enqueued in the
+// same transaction that saves the cursor of the next page, so a campaign job
+// that is retried resumes from the first page that was not enqueued, and no
+// page is enqueued twice.
+func (expander CampaignExpander) Expand(campaign postal.Campaign) error {
+	loader, ok := expander.audiences[campaign.Audience]
+	if !ok {
+		return UnknownAudienceError(campaign.Audience)
+	}
+
+	conn := expander.database.Connection()
+	record, err := expander.campaignsRepo.Find(conn, campaign.ID)
+	if err != nil {
+		return err
+	}
+
+	if record.Status == models.CampaignStatusExpanded {
+		return nil
+	}
+
+	audience, err := loader.Audience(campaign)
+	if err != nil {
+		return err
+	}
+
+	options := campaign.Options
+	options.CampaignID = campaign.ID
+
+	for record.Status != models.CampaignStatusExpanded {
+		page, err := loader.AudiencePage(campaign, record.AudienceCursor)
+		if err != nil {
+			return err
+		}
+
+		transaction := conn.Transaction()
+		transaction.Begin()
+
+		if len(page.Users) > 0 {
+			_, err := expander.mailer.Enqueue(transaction, page.Users, options, audience.Space, audience.Organization, campaign.ClientID, audience.Scope)
+			if err != nil {
+				transaction.Rollback()
+				return CampaignExpansionError(fmt.Sprintf("Failed to enqueue deliveries %d through %d of campaign %s: %s", record.QueuedCount+1, record.QueuedCount+len(page.Users), campaign.ID, err))
+			}
+		}
+
+		record.ExpandedCount += len(page.Users)
+		record.QueuedCount += len(page.Users)
+		record.AudienceCursor = page.Next
+		if page.Next == "" {
+			record.Status = models.CampaignStatusExpanded
+		}
+
+		record, err = expander.campaignsRepo.Update(transaction, record)
+		if err != nil {
+			transaction.Rollback()
+			return err
+		}
+
+		err = transaction.Commit()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
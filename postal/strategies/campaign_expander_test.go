@@ -0,0 +1,235 @@
+package strategies_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/cf"
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/postal"
+	"github.com/cloudfoundry-incubator/notifications/postal/strategies"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CampaignExpander", func() {
+	var expander strategies.CampaignExpander
+	var audienceLoader *fakes.AudienceLoader
+	var mailer *fakes.Mailer
+	var campaignsRepo *fakes.CampaignsRepo
+	var database *fakes.Database
+	var campaign postal.Campaign
+
+	BeforeEach(func() {
+		audienceLoader = fakes.NewAudienceLoader()
+		audienceLoader.LoadedAudience = strategies.Audience{
+			Space: cf.CloudControllerSpace{GUID: "space-001", Name: "production"},
+			Organization: cf.CloudControllerOrganization{
+				GUID: "org-001",
+				Name: "the-org",
+			},
+		}
+		audienceLoader.Pages[""] = strategies.AudiencePage{
+			Users: []strategies.User{{GUID: "user-1"}, {GUID: "user-2"}},
+			Next:  "page-2",
+		}
+		audienceLoader.Pages["page-2"] = strategies.AudiencePage{
+			Users: []strategies.User{{GUID: "user-3"}, {GUID: "user-4"}},
+			Next:  "page-3",
+		}
+		audienceLoader.Pages["page-3"] = strategies.AudiencePage{
+			Users: []strategies.User{{GUID: "user-5"}},
+		}
+
+		mailer = fakes.NewMailer()
+		mailer.Responses = []strategies.Response{{Status: postal.StatusQueued}}
+
+		campaignsRepo = fakes.NewCampaignsRepo()
+		campaignsRepo.Campaigns["campaign-123"] = models.Campaign{
+			ID:     "campaign-123",
+			Status: models.CampaignStatusExpanding,
+		}
+
+		database = fakes.NewDatabase()
+
+		expander = strategies.NewCampaignExpander(map[string]strategies.AudienceLoaderInterface{
+			strategies.SpaceAudience: audienceLoader,
+		}, mailer, campaignsRepo, database)
+
+		campaign = postal.Campaign{
+			JobType:  postal.CampaignJobType,
+			ID:       "campaign-123",
+			Audience: strategies.SpaceAudience,
+			GUID:     "space-001",
+			ClientID: "the-client",
+			Options:  postal.Options{KindID: "the-kind"},
+		}
+	})
+
+	Describe("Expand", func() {
+		It("delivers to the audience one page at a time", func() {
+			err := expander.Expand(campaign)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(audienceLoader.Campaigns).To(Equal([]postal.Campaign{campaign}))
+			Expect(audienceLoader.Cursors).To(Equal([]string{"", "page-2", "page-3"}))
+			Expect(mailer.EnqueuedUsers).To(Equal([][]strategies.User{
+				{{GUID: "user-1"}, {GUID: "user-2"}},
+				{{GUID: "user-3"}, {GUID: "user-4"}},
+				{{GUID: "user-5"}},
+			}))
+
+			options := campaign.Options
+			options.CampaignID = "campaign-123"
+			Expect(mailer.DeliverArguments).To(Equal(map[string]interface{}{
+				"connection": database.Conn,
+				"users":      []strategies.User{{GUID: "user-5"}},
+				"options":    options,
+				"space":      audienceLoader.LoadedAudience.Space,
+				"org":        audienceLoader.LoadedAudience.Organization,
+				"client":     "the-client",
+				"scope":      "",
+			}))
+		})
+
+		It("records the progress of the campaign", func() {
+			err := expander.Expand(campaign)
+			Expect(err).NotTo(HaveOccurred())
+
+			var progress []int
+			var cursors []string
+			for _, update := range campaignsRepo.Updates {
+				Expect(update.ExpandedCount).To(Equal(update.QueuedCount))
+				progress = append(progress, update.QueuedCount)
+				cursors = append(cursors, update.AudienceCursor)
+			}
+			Expect(progress).To(Equal([]int{2, 4, 5}))
+			Expect(cursors).To(Equal([]string{"page-2", "page-3", ""}))
+
+			Expect(campaignsRepo.Campaigns["campaign-123"].Status).To(Equal(models.CampaignStatusExpanded))
+		})
+
+		It("resumes from the saved cursor", func() {
+			record := campaignsRepo.Campaigns["campaign-123"]
+			record.ExpandedCount = 2
+			record.QueuedCount = 2
+			record.AudienceCursor = "page-2"
+			campaignsRepo.Campaigns["campaign-123"] = record
+
+			err := expander.Expand(campaign)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(audienceLoader.Cursors).To(Equal([]string{"page-2", "page-3"}))
+			Expect(mailer.EnqueuedUsers).To(Equal([][]strategies.User{
+				{{GUID: "user-3"}, {GUID: "user-4"}},
+				{{GUID: "user-5"}},
+			}))
+			Expect(campaignsRepo.Campaigns["campaign-123"].QueuedCount).To(Equal(5))
+		})
+
+		It("skips delivery for an empty page", func() {
+			audienceLoader.Pages[""] = strategies.AudiencePage{}
+
+			err := expander.Expand(campaign)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(mailer.EnqueuedUsers).To(BeEmpty())
+			Expect(campaignsRepo.Campaigns["campaign-123"].Status).To(Equal(models.CampaignStatusExpanded))
+		})
+
+		It("does nothing when the campaign has already been expanded", func() {
+			record := campaignsRepo.Campaigns["campaign-123"]
+			record.Status = models.CampaignStatusExpanded
+			campaignsRepo.Campaigns["campaign-123"] = record
+
+			err := expander.Expand(campaign)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(audienceLoader.Campaigns).To(BeEmpty())
+			Expect(audienceLoader.Cursors).To(BeEmpty())
+			Expect(mailer.EnqueuedUsers).To(BeEmpty())
+		})
+
+		It("enqueues each page in the transaction that saves its progress", func() {
+			err := expander.Expand(campaign)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(database.Conn.BeginWasCalled).To(BeTrue())
+			Expect(mailer.EnqueueTransaction).To(Equal(database.Conn))
+			Expect(database.Conn.CommitWasCalled).To(BeTrue())
+			Expect(database.Conn.RollbackWasCalled).To(BeFalse())
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the audience is not known", func() {
+				campaign.Audience = "banana"
+
+				err := expander.Expand(campaign)
+				Expect(err).To(Equal(strategies.UnknownAudienceError("banana")))
+			})
+
+			It("returns an error when the campaign cannot be found", func() {
+				campaignsRepo.FindError = errors.New("BOOM!")
+
+				err := expander.Expand(campaign)
+				Expect(err).To(MatchError(campaignsRepo.FindError))
+			})
+
+			It("returns an error when the audience cannot be loaded", func() {
+				audienceLoader.AudienceError = errors.New("BOOM!")
+
+				err := expander.Expand(campaign)
+				Expect(err).To(MatchError(audienceLoader.AudienceError))
+			})
+
+			It("returns an error when a page of the audience cannot be loaded", func() {
+				audienceLoader.AudiencePageError = errors.New("BOOM!")
+
+				err := expander.Expand(campaign)
+				Expect(err).To(MatchError(audienceLoader.AudiencePageError))
+				Expect(campaignsRepo.Campaigns["campaign-123"].AudienceCursor).To(BeEmpty())
+			})
+
+			It("returns an error when a page cannot be delivered", func() {
+				mailer.EnqueueError = errors.New("BOOM!")
+
+				err := expander.Expand(campaign)
+				Expect(err).To(BeAssignableToTypeOf(strategies.CampaignExpansionError("")))
+				Expect(database.Conn.RollbackWasCalled).To(BeTrue())
+				Expect(database.Conn.CommitWasCalled).To(BeFalse())
+				Expect(campaignsRepo.Campaigns["campaign-123"].QueuedCount).To(Equal(0))
+				Expect(campaignsRepo.Campaigns["campaign-123"].AudienceCursor).To(BeEmpty())
+				Expect(campaignsRepo.Campaigns["campaign-123"].Status).To(Equal(models.CampaignStatusExpanding))
+			})
+
+			It("rolls back the page when its progress cannot be saved, so a retry enqueues it once", func() {
+				campaignsRepo.UpdateError = errors.New("BOOM!")
+
+				err := expander.Expand(campaign)
+				Expect(err).To(MatchError(campaignsRepo.UpdateError))
+				Expect(mailer.EnqueuedUsers).To(Equal([][]strategies.User{
+					{{GUID: "user-1"}, {GUID: "user-2"}},
+				}))
+				Expect(database.Conn.RollbackWasCalled).To(BeTrue())
+				Expect(database.Conn.CommitWasCalled).To(BeFalse())
+
+				// The rollback discards the jobs the failed attempt enqueued.
+				mailer.EnqueuedUsers = nil
+				campaignsRepo.UpdateError = nil
+				audienceLoader.Cursors = nil
+
+				err = expander.Expand(campaign)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(audienceLoader.Cursors).To(Equal([]string{"", "page-2", "page-3"}))
+				Expect(mailer.EnqueuedUsers).To(Equal([][]strategies.User{
+					{{GUID: "user-1"}, {GUID: "user-2"}},
+					{{GUID: "user-3"}, {GUID: "user-4"}},
+					{{GUID: "user-5"}},
+				}))
+				Expect(campaignsRepo.Campaigns["campaign-123"].QueuedCount).To(Equal(5))
+			})
+		})
+	})
+})
@@ -0,0 +1,86 @@
+package strategies
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/postal"
+)
+
+const (
+	SpaceAudience        = "spaces"
+	OrganizationAudience = "organizations"
+	ScopeAudience        = "scopes"
+	EveryoneAudience     = "everyone"
+)
+
+type CampaignStarterInterface interface {
+	Start(models.ConnectionInterface, postal.Campaign) (Response, error)
+}
+
+// CampaignStarter records a campaign and enqueues the single job that will
+// later expand its audience, so that a request addressed to a large audience
+// can be answered without waiting on Cloud Controller or UAA.
+type CampaignStarter struct {
+	queue         gobble.QueueInterface
+	guidGenerator postal.GUIDGenerationFunc
+	campaignsRepo models.CampaignsRepoInterface
+}
+
+func NewCampaignStarter(queue gobble.QueueInterface, guidGenerator postal.GUIDGenerationFunc, campaignsRepo models.CampaignsRepoInterface) CampaignStarter {
+	return CampaignStarter{
+		queue:         queue,
+		guidGenerator: guidGenerator,
+		campaignsRepo: campaignsRepo,
+	}
+}
+
+func (starter CampaignStarter) Start(conn models.ConnectionInterface, campaign postal.Campaign) (Response, error) {
+	guid, err := starter.guidGenerator()
+	if err != nil {
+		return Response{}, err
+	}
+	campaign.ID = guid.String()
+	campaign.JobType = postal.CampaignJobType
+
+	status := postal.StatusQueued
+	if campaign.Options.SendAt.After(time.Now()) {
+		status = postal.StatusScheduled
+	}
+
+	transaction := conn.Transaction()
+	transaction.Begin()
+
+	_, err = starter.campaignsRepo.Create(transaction, models.Campaign{
+		ID:           campaign.ID,
+		ClientID:     campaign.ClientID,
+		Audience:     campaign.Audience,
+		AudienceGUID: campaign.GUID,
+	})
+	if err != nil {
+		transaction.Rollback()
+		return Response{}, err
+	}
+
+	job := gobble.NewJob(campaign)
+	job.Priority = jobPriority(campaign.Options)
+	job.ActiveAt = campaign.Options.SendAt
+
+	_, err = starter.queue.EnqueueWithTransaction(job, transaction)
+	if err != nil {
+		transaction.Rollback()
+		return Response{}, err
+	}
+
+	err = transaction.Commit()
+	if err != nil {
+		return Response{}, err
+	}
+
+	return Response{
+		Status:     status,
+		Recipient:  campaign.GUID,
+		CampaignID: campaign.ID,
+	}, nil
+}
@@ -0,0 +1,130 @@
+package strategies_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/postal"
+	"github.com/cloudfoundry-incubator/notifications/postal/strategies"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CampaignStarter", func() {
+	var starter strategies.CampaignStarter
+	var queue *fakes.Queue
+	var conn *fakes.DBConn
+	var campaignsRepo *fakes.CampaignsRepo
+	var campaign postal.Campaign
+
+	BeforeEach(func() {
+		queue = fakes.NewQueue()
+		conn = fakes.NewDBConn()
+		campaignsRepo = fakes.NewCampaignsRepo()
+		starter = strategies.NewCampaignStarter(queue, fakes.NewIncrementingGUIDGenerator().Generate, campaignsRepo)
+
+		campaign = postal.Campaign{
+			Audience: strategies.SpaceAudience,
+			GUID:     "space-001",
+			ClientID: "the-client",
+			Options:  postal.Options{KindID: "the-kind", Priority: 3},
+		}
+	})
+
+	Describe("Start", func() {
+		It("records the campaign and responds with its ID", func() {
+			response, err := starter.Start(conn, campaign)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(response).To(Equal(strategies.Response{
+				Status:     postal.StatusQueued,
+				Recipient:  "space-001",
+				CampaignID: "deadbeef-aabb-ccdd-eeff-001122334455",
+			}))
+
+			Expect(campaignsRepo.Campaigns).To(HaveKeyWithValue("deadbeef-aabb-ccdd-eeff-001122334455", models.Campaign{
+				ID:           "deadbeef-aabb-ccdd-eeff-001122334455",
+				ClientID:     "the-client",
+				Audience:     strategies.SpaceAudience,
+				AudienceGUID: "space-001",
+				Status:       models.CampaignStatusExpanding,
+			}))
+		})
+
+		It("enqueues a single job to expand the audience", func() {
+			_, err := starter.Start(conn, campaign)
+			Expect(err).NotTo(HaveOccurred())
+
+			job := <-queue.Reserve("me")
+			Expect(job.Priority).To(Equal(3))
+
+			var payload postal.Campaign
+			err = job.Unmarshal(&payload)
+			if err != nil {
+				panic(err)
+			}
+
+			campaign.ID = "deadbeef-aabb-ccdd-eeff-001122334455"
+			campaign.JobType = postal.CampaignJobType
+			Expect(payload).To(Equal(campaign))
+		})
+
+		Context("when the notification is scheduled for later", func() {
+			It("enqueues a job that becomes active at the scheduled time", func() {
+				sendAt := time.Now().Add(1 * time.Hour).Truncate(time.Second)
+				campaign.Options.SendAt = sendAt
+
+				response, err := starter.Start(conn, campaign)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Status).To(Equal(postal.StatusScheduled))
+
+				job := <-queue.Reserve("me")
+				Expect(job.ActiveAt).To(Equal(sendAt))
+			})
+		})
+
+		Context("using a transaction", func() {
+			It("commits the transaction when everything goes well", func() {
+				_, err := starter.Start(conn, campaign)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(conn.BeginWasCalled).To(BeTrue())
+				Expect(conn.CommitWasCalled).To(BeTrue())
+				Expect(conn.RollbackWasCalled).To(BeFalse())
+			})
+
+			It("enqueues the job as part of the transaction that records the campaign", func() {
+				_, err := starter.Start(conn, campaign)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(queue.EnqueueTransaction).To(Equal(conn))
+			})
+
+			It("rolls back the transaction when the campaign cannot be recorded", func() {
+				campaignsRepo.CreateError = errors.New("BOOM!")
+
+				_, err := starter.Start(conn, campaign)
+				Expect(err).To(MatchError(campaignsRepo.CreateError))
+				Expect(conn.RollbackWasCalled).To(BeTrue())
+			})
+
+			It("rolls back the transaction when the job cannot be enqueued", func() {
+				queue.EnqueueError = errors.New("BOOM!")
+
+				_, err := starter.Start(conn, campaign)
+				Expect(err).To(MatchError(queue.EnqueueError))
+				Expect(conn.RollbackWasCalled).To(BeTrue())
+			})
+
+			It("returns an error when the commit fails", func() {
+				conn.CommitError = "the commit blew up"
+
+				_, err := starter.Start(conn, campaign)
+				Expect(err).To(MatchError("the commit blew up"))
+			})
+		})
+	})
+})
@@ -1,7 +1,6 @@
 package strategies
 
 import (
-	"github.com/cloudfoundry-incubator/notifications/cf"
 	"github.com/cloudfoundry-incubator/notifications/models"
 	"github.com/cloudfoundry-incubator/notifications/postal"
 	"github.com/cloudfoundry-incubator/notifications/postal/utilities"
@@ -12,14 +11,14 @@ const EveryoneEndorsement = "This message was sent to everyone."
 type EveryoneStrategy struct {
 	tokenLoader postal.TokenLoaderInterface
 	allUsers    utilities.AllUsersInterface
-	mailer      MailerInterface
+	campaigns   CampaignStarterInterface
 }
 
-func NewEveryoneStrategy(tokenLoader postal.TokenLoaderInterface, allUsers utilities.AllUsersInterface, mailer MailerInterface) EveryoneStrategy {
+func NewEveryoneStrategy(tokenLoader postal.TokenLoaderInterface, allUsers utilities.AllUsersInterface, campaigns CampaignStarterInterface) EveryoneStrategy {
 	return EveryoneStrategy{
 		tokenLoader: tokenLoader,
 		allUsers:    allUsers,
-		mailer:      mailer,
+		campaigns:   campaigns,
 	}
 }
 
@@ -32,18 +31,35 @@ func (strategy EveryoneStrategy) Dispatch(clientID, guid string, options postal.
 		return responses, err
 	}
 
-	// split this up so that it only loads user guids
-	userGUIDs, err := strategy.allUsers.AllUserGUIDs()
+	response, err := strategy.campaigns.Start(conn, postal.Campaign{
+		Audience: EveryoneAudience,
+		ClientID: clientID,
+		Options:  options,
+	})
 	if err != nil {
 		return responses, err
 	}
 
-	var users []User
-	for _, guid := range userGUIDs {
-		users = append(users, User{GUID: guid})
+	return append(responses, response), nil
+}
+
+func (strategy EveryoneStrategy) Audience(campaign postal.Campaign) (Audience, error) {
+	return Audience{}, nil
+}
+
+func (strategy EveryoneStrategy) AudiencePage(campaign postal.Campaign, cursor string) (AudiencePage, error) {
+	_, err := strategy.tokenLoader.Load()
+	if err != nil {
+		return AudiencePage{}, err
 	}
 
-	responses = strategy.mailer.Deliver(conn, users, options, cf.CloudControllerSpace{}, cf.CloudControllerOrganization{}, clientID, "")
+	page, err := strategy.allUsers.AllUserGUIDs(cursor)
+	if err != nil {
+		return AudiencePage{}, err
+	}
 
-	return responses, nil
+	return AudiencePage{
+		Users: usersFromGUIDs(page.GUIDs),
+		Next:  page.Next,
+	}, nil
 }
@@ -3,10 +3,10 @@ package strategies_test
 import (
 	"errors"
 
-	"github.com/cloudfoundry-incubator/notifications/cf"
 	"github.com/cloudfoundry-incubator/notifications/fakes"
 	"github.com/cloudfoundry-incubator/notifications/postal"
 	"github.com/cloudfoundry-incubator/notifications/postal/strategies"
+	"github.com/cloudfoundry-incubator/notifications/postal/utilities"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -17,7 +17,7 @@ var _ = Describe("Everyone Strategy", func() {
 	var options postal.Options
 	var tokenLoader *fakes.TokenLoader
 	var allUsers *fakes.AllUsers
-	var mailer *fakes.Mailer
+	var campaignStarter *fakes.CampaignStarter
 	var clientID string
 	var conn *fakes.DBConn
 
@@ -37,63 +37,85 @@ var _ = Describe("Everyone Strategy", func() {
 		tokenLoader = fakes.NewTokenLoader()
 		tokenLoader.Token = fakes.BuildToken(tokenHeader, tokenClaims)
 
-		mailer = fakes.NewMailer()
+		campaignStarter = fakes.NewCampaignStarter()
+		campaignStarter.Response = strategies.Response{
+			Status:     "queued",
+			CampaignID: "campaign-123",
+		}
 		allUsers = fakes.NewAllUsers()
-		allUsers.GUIDs = []string{"user-380", "user-319"}
+		allUsers.Pages[""] = utilities.UserGUIDsPage{
+			GUIDs: []string{"user-380", "user-319"},
+			Next:  "3",
+		}
 
-		strategy = strategies.NewEveryoneStrategy(tokenLoader, allUsers, mailer)
+		options = postal.Options{
+			KindID:            "welcome_user",
+			KindDescription:   "Your Official Welcome",
+			SourceDescription: "Welcome system",
+			Text:              "Welcome to the system, now get off my lawn.",
+			HTML:              postal.HTML{BodyContent: "<p>Welcome to the system, now get off my lawn.</p>"},
+		}
+
+		strategy = strategies.NewEveryoneStrategy(tokenLoader, allUsers, campaignStarter)
 	})
 
 	Describe("Dispatch", func() {
-		BeforeEach(func() {
-			options = postal.Options{
-				KindID:            "welcome_user",
-				KindDescription:   "Your Official Welcome",
-				SourceDescription: "Welcome system",
-				Text:              "Welcome to the system, now get off my lawn.",
-				HTML:              postal.HTML{BodyContent: "<p>Welcome to the system, now get off my lawn.</p>"},
-			}
-		})
-
-		It("call mailer.Deliver with the correct arguments for an organization", func() {
+		It("starts a campaign for every user", func() {
 			Expect(options.Endorsement).To(BeEmpty())
-			_, err := strategy.Dispatch(clientID, "", options, conn)
+			responses, err := strategy.Dispatch(clientID, "", options, conn)
 			if err != nil {
 				panic(err)
 			}
 
 			options.Endorsement = strategies.EveryoneEndorsement
-			var users []strategies.User
-			for _, guid := range allUsers.GUIDs {
-				users = append(users, strategies.User{GUID: guid})
-			}
-
-			Expect(mailer.DeliverArguments).To(Equal(map[string]interface{}{
+			Expect(campaignStarter.StartArguments).To(Equal(map[string]interface{}{
 				"connection": conn,
-				"users":      users,
-				"options":    options,
-				"space":      cf.CloudControllerSpace{},
-				"org":        cf.CloudControllerOrganization{},
-				"client":     clientID,
-				"scope":      "",
+				"campaign": postal.Campaign{
+					Audience: strategies.EveryoneAudience,
+					ClientID: clientID,
+					Options:  options,
+				},
 			}))
+			Expect(responses).To(Equal([]strategies.Response{campaignStarter.Response}))
 		})
-	})
 
-	Context("failure cases", func() {
-		Context("when token loader fails to return a token", func() {
-			It("returns an error", func() {
-				tokenLoader.LoadError = errors.New("BOOM!")
-				_, err := strategy.Dispatch(clientID, "", options, conn)
+		Context("failure cases", func() {
+			Context("when token loader fails to return a token", func() {
+				It("returns an error", func() {
+					tokenLoader.LoadError = errors.New("BOOM!")
+					_, err := strategy.Dispatch(clientID, "", options, conn)
 
-				Expect(err).To(Equal(errors.New("BOOM!")))
+					Expect(err).To(Equal(errors.New("BOOM!")))
+				})
 			})
+
+			Context("when the campaign cannot be started", func() {
+				It("returns an error", func() {
+					campaignStarter.StartError = errors.New("BOOM!")
+					_, err := strategy.Dispatch(clientID, "", options, conn)
+
+					Expect(err).To(Equal(errors.New("BOOM!")))
+				})
+			})
+		})
+	})
+
+	Describe("AudiencePage", func() {
+		It("returns a page of every user", func() {
+			page, err := strategy.AudiencePage(postal.Campaign{Audience: strategies.EveryoneAudience}, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(page).To(Equal(strategies.AudiencePage{
+				Users: []strategies.User{{GUID: "user-380"}, {GUID: "user-319"}},
+				Next:  "3",
+			}))
+			Expect(allUsers.Cursors).To(Equal([]string{""}))
 		})
 
 		Context("when allUsers fails to load users", func() {
 			It("returns the error", func() {
 				allUsers.LoadError = errors.New("BOOM!")
-				_, err := strategy.Dispatch(clientID, "", options, conn)
+				_, err := strategy.AudiencePage(postal.Campaign{Audience: strategies.EveryoneAudience}, "")
 
 				Expect(err).To(Equal(errors.New("BOOM!")))
 			})
@@ -1,6 +1,8 @@
 package strategies
 
 import (
+	"time"
+
 	"github.com/cloudfoundry-incubator/notifications/cf"
 	"github.com/cloudfoundry-incubator/notifications/gobble"
 	"github.com/cloudfoundry-incubator/notifications/models"
@@ -9,6 +11,7 @@ import (
 
 type MailerInterface interface {
 	Deliver(models.ConnectionInterface, []User, postal.Options, cf.CloudControllerSpace, cf.CloudControllerOrganization, string, string) []Response
+	Enqueue(models.TransactionInterface, []User, postal.Options, cf.CloudControllerSpace, cf.CloudControllerOrganization, string, string) ([]Response, error)
 }
 
 type Mailer struct {
@@ -33,6 +36,36 @@ func (mailer Mailer) Deliver(conn models.ConnectionInterface, users []User,
 	options postal.Options, space cf.CloudControllerSpace,
 	organization cf.CloudControllerOrganization, clientID, scope string) []Response {
 
+	transaction := conn.Transaction()
+	transaction.Begin()
+
+	responses, err := mailer.Enqueue(transaction, users, options, space, organization, clientID, scope)
+	if err != nil {
+		transaction.Rollback()
+		return []Response{}
+	}
+
+	err = transaction.Commit()
+	if err != nil {
+		return []Response{}
+	}
+
+	return responses
+}
+
+// Enqueue queues a delivery for each of the users within a transaction that
+// the caller has begun, and leaves committing or rolling it back to them.
+func (mailer Mailer) Enqueue(transaction models.TransactionInterface, users []User,
+	options postal.Options, space cf.CloudControllerSpace,
+	organization cf.CloudControllerOrganization, clientID, scope string) ([]Response, error) {
+
+	queuedAt := time.Now()
+	status := postal.StatusQueued
+	if options.SendAt.After(queuedAt) {
+		status = postal.StatusScheduled
+		queuedAt = options.SendAt
+	}
+
 	responses := []Response{}
 	jobsByMessageID := map[string]gobble.Job{}
 	for _, user := range users {
@@ -42,7 +75,7 @@ func (mailer Mailer) Deliver(conn models.ConnectionInterface, users []User,
 		}
 		messageID := guid.String()
 
-		jobsByMessageID[messageID] = gobble.NewJob(postal.Delivery{
+		job := gobble.NewJob(postal.Delivery{
 			Options:      options,
 			UserGUID:     user.GUID,
 			Email:        user.Email,
@@ -51,7 +84,13 @@ func (mailer Mailer) Deliver(conn models.ConnectionInterface, users []User,
 			ClientID:     clientID,
 			MessageID:    messageID,
 			Scope:        scope,
+			QueuedAt:     queuedAt,
 		})
+		job.Priority = jobPriority(options)
+		job.ActiveAt = options.SendAt
+		job.Reference = messageID
+
+		jobsByMessageID[messageID] = job
 
 		recipient := user.Email
 		if recipient == "" {
@@ -59,33 +98,36 @@ func (mailer Mailer) Deliver(conn models.ConnectionInterface, users []User,
 		}
 
 		responses = append(responses, Response{
-			Status:         postal.StatusQueued,
+			Status:         status,
 			NotificationID: messageID,
 			Recipient:      recipient,
 		})
 	}
 
-	transaction := conn.Transaction()
-	transaction.Begin()
 	for messageID, job := range jobsByMessageID {
-		_, err := mailer.queue.Enqueue(job)
+		_, err := mailer.queue.EnqueueWithTransaction(job, transaction)
 		if err != nil {
-			transaction.Rollback()
-			return []Response{}
+			return []Response{}, err
 		}
 		_, err = mailer.messagesRepo.Upsert(transaction, models.Message{
-			ID:     messageID,
-			Status: postal.StatusQueued,
+			ID:         messageID,
+			Status:     status,
+			CampaignID: options.CampaignID,
 		})
 		if err != nil {
-			transaction.Rollback()
-			return []Response{}
+			return []Response{}, err
 		}
 	}
-	err := transaction.Commit()
-	if err != nil {
-		return []Response{}
+
+	return responses, nil
+}
+
+// Critical notifications always jump the queue, regardless of the priority
+// that was requested, so that they are not stuck behind a large fan-out.
+func jobPriority(options postal.Options) int {
+	if options.Critical {
+		return postal.PriorityCritical
 	}
 
-	return responses
+	return options.Priority
 }
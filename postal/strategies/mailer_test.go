@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"log"
+	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/cf"
 	"github.com/cloudfoundry-incubator/notifications/fakes"
@@ -77,6 +78,10 @@ var _ = Describe("Mailer", func() {
 				if err != nil {
 					panic(err)
 				}
+
+				Expect(delivery.QueuedAt).To(BeTemporally("~", time.Now(), 2*time.Second))
+				delivery.QueuedAt = time.Time{}
+
 				deliveries = append(deliveries, delivery)
 			}
 
@@ -121,6 +126,65 @@ var _ = Describe("Mailer", func() {
 			}))
 		})
 
+		Describe("job priority", func() {
+			It("enqueues jobs with the requested priority", func() {
+				mailer.Deliver(conn, []strategies.User{{GUID: "user-1"}}, postal.Options{Priority: 3}, space, org, "the-client", "my.scope")
+
+				job := <-queue.Reserve("me")
+				Expect(job.Priority).To(Equal(3))
+			})
+
+			It("enqueues jobs for critical kinds with the critical priority", func() {
+				mailer.Deliver(conn, []strategies.User{{GUID: "user-1"}}, postal.Options{Critical: true, Priority: 3}, space, org, "the-client", "my.scope")
+
+				job := <-queue.Reserve("me")
+				Expect(job.Priority).To(Equal(postal.PriorityCritical))
+			})
+		})
+
+		Context("when the notification is scheduled for later", func() {
+			var sendAt time.Time
+
+			BeforeEach(func() {
+				sendAt = time.Now().Add(1 * time.Hour).Truncate(time.Second)
+			})
+
+			It("enqueues jobs that become active at the scheduled time", func() {
+				mailer.Deliver(conn, []strategies.User{{GUID: "user-1"}}, postal.Options{SendAt: sendAt}, space, org, "the-client", "my.scope")
+
+				job := <-queue.Reserve("me")
+				Expect(job.ActiveAt).To(Equal(sendAt))
+				Expect(job.Reference).To(Equal("deadbeef-aabb-ccdd-eeff-001122334455"))
+
+				var delivery postal.Delivery
+				err := job.Unmarshal(&delivery)
+				if err != nil {
+					panic(err)
+				}
+				Expect(delivery.QueuedAt).To(BeTemporally("==", sendAt))
+			})
+
+			It("responds and records the messages with a scheduled status", func() {
+				responses := mailer.Deliver(conn, []strategies.User{{GUID: "user-1"}}, postal.Options{SendAt: sendAt}, space, org, "the-client", "my.scope")
+
+				Expect(responses).To(HaveLen(1))
+				Expect(responses[0].Status).To(Equal(postal.StatusScheduled))
+
+				message, err := messagesRepo.FindByID(conn, responses[0].NotificationID)
+				if err != nil {
+					panic(err)
+				}
+				Expect(message.Status).To(Equal(postal.StatusScheduled))
+			})
+
+			It("queues the notification right away when the scheduled time has passed", func() {
+				responses := mailer.Deliver(conn, []strategies.User{{GUID: "user-1"}}, postal.Options{SendAt: time.Now().Add(-1 * time.Hour)}, space, org, "the-client", "my.scope")
+
+				Expect(responses).To(HaveLen(1))
+				Expect(responses[0].Status).To(Equal(postal.StatusQueued))
+			})
+		})
+
 		It("Upserts a StatusQueued for each of the jobs", func() {
 			users := []strategies.User{{GUID: "user-1"}, {GUID: "user-2"}, {GUID: "user-3"}, {GUID: "user-4"}}
 			mailer.Deliver(conn, users, postal.Options{}, space, org, "the-client", "my.scope")
@@ -146,6 +210,17 @@ var _ = Describe("Mailer", func() {
 			Expect(statuses).To(ConsistOf([]string{postal.StatusQueued, postal.StatusQueued, postal.StatusQueued, postal.StatusQueued}))
 		})
 
+		It("records the campaign that each message belongs to", func() {
+			responses := mailer.Deliver(conn, []strategies.User{{GUID: "user-1"}}, postal.Options{CampaignID: "campaign-123"}, space, org, "the-client", "my.scope")
+
+			Expect(responses).To(HaveLen(1))
+			message, err := messagesRepo.FindByID(conn, responses[0].NotificationID)
+			if err != nil {
+				panic(err)
+			}
+			Expect(message.CampaignID).To(Equal("campaign-123"))
+		})
+
 		Context("using a transaction", func() {
 			It("commits the transaction when everything goes well", func() {
 				users := []strategies.User{{GUID: "user-1"}, {GUID: "user-2"}, {GUID: "user-3"}, {GUID: "user-4"}}
@@ -155,6 +230,7 @@ var _ = Describe("Mailer", func() {
 				Expect(conn.CommitWasCalled).To(BeTrue())
 				Expect(conn.RollbackWasCalled).To(BeFalse())
 				Expect(responses).ToNot(BeEmpty())
+				Expect(queue.EnqueueTransaction).To(Equal(conn))
 			})
 
 			It("rolls back the transaction when there is an error in queueing", func() {
@@ -189,4 +265,26 @@ var _ = Describe("Mailer", func() {
 			})
 		})
 	})
+
+	Describe("Enqueue", func() {
+		It("enqueues within the given transaction and leaves it open", func() {
+			users := []strategies.User{{GUID: "user-1"}, {GUID: "user-2"}}
+			responses, err := mailer.Enqueue(conn, users, postal.Options{}, space, org, "the-client", "my.scope")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(responses).To(HaveLen(2))
+			Expect(queue.EnqueueTransaction).To(Equal(conn))
+			Expect(conn.BeginWasCalled).To(BeFalse())
+			Expect(conn.CommitWasCalled).To(BeFalse())
+			Expect(conn.RollbackWasCalled).To(BeFalse())
+		})
+
+		It("returns the error without rolling back when a job cannot be queued", func() {
+			queue.EnqueueError = errors.New("BOOM!")
+			_, err := mailer.Enqueue(conn, []strategies.User{{GUID: "user-1"}}, postal.Options{}, space, org, "the-client", "my.scope")
+
+			Expect(err).To(MatchError(queue.EnqueueError))
+			Expect(conn.RollbackWasCalled).To(BeFalse())
+		})
+	})
 })
@@ -1,7 +1,6 @@
 package strategies
 
 import (
-	"github.com/cloudfoundry-incubator/notifications/cf"
 	"github.com/cloudfoundry-incubator/notifications/models"
 	"github.com/cloudfoundry-incubator/notifications/postal"
 	"github.com/cloudfoundry-incubator/notifications/postal/utilities"
@@ -14,17 +13,17 @@ type OrganizationStrategy struct {
 	tokenLoader        postal.TokenLoaderInterface
 	organizationLoader utilities.OrganizationLoaderInterface
 	findsUserGUIDs     utilities.FindsUserGUIDsInterface
-	mailer             MailerInterface
+	campaigns          CampaignStarterInterface
 }
 
 func NewOrganizationStrategy(tokenLoader postal.TokenLoaderInterface, organizationLoader utilities.OrganizationLoaderInterface,
-	findsUserGUIDs utilities.FindsUserGUIDsInterface, mailer MailerInterface) OrganizationStrategy {
+	findsUserGUIDs utilities.FindsUserGUIDsInterface, campaigns CampaignStarterInterface) OrganizationStrategy {
 
 	return OrganizationStrategy{
 		tokenLoader:        tokenLoader,
 		organizationLoader: organizationLoader,
 		findsUserGUIDs:     findsUserGUIDs,
-		mailer:             mailer,
+		campaigns:          campaigns,
 	}
 }
 
@@ -41,22 +40,53 @@ func (strategy OrganizationStrategy) Dispatch(clientID, guid string, options pos
 		return responses, err
 	}
 
-	organization, err := strategy.organizationLoader.Load(guid, token)
+	_, err = strategy.organizationLoader.Load(guid, token)
 	if err != nil {
 		return responses, err
 	}
 
-	userGUIDs, err := strategy.findsUserGUIDs.UserGUIDsBelongingToOrganization(guid, options.Role, token)
+	response, err := strategy.campaigns.Start(conn, postal.Campaign{
+		Audience: OrganizationAudience,
+		GUID:     guid,
+		ClientID: clientID,
+		Options:  options,
+	})
 	if err != nil {
 		return responses, err
 	}
 
-	var users []User
-	for _, guid := range userGUIDs {
-		users = append(users, User{GUID: guid})
+	return append(responses, response), nil
+}
+
+func (strategy OrganizationStrategy) Audience(campaign postal.Campaign) (Audience, error) {
+	token, err := strategy.tokenLoader.Load()
+	if err != nil {
+		return Audience{}, err
 	}
 
-	responses = strategy.mailer.Deliver(conn, users, options, cf.CloudControllerSpace{}, organization, clientID, "")
+	organization, err := strategy.organizationLoader.Load(campaign.GUID, token)
+	if err != nil {
+		return Audience{}, err
+	}
+
+	return Audience{
+		Organization: organization,
+	}, nil
+}
+
+func (strategy OrganizationStrategy) AudiencePage(campaign postal.Campaign, cursor string) (AudiencePage, error) {
+	token, err := strategy.tokenLoader.Load()
+	if err != nil {
+		return AudiencePage{}, err
+	}
+
+	page, err := strategy.findsUserGUIDs.UserGUIDsBelongingToOrganization(campaign.GUID, campaign.Options.Role, token, cursor)
+	if err != nil {
+		return AudiencePage{}, err
+	}
 
-	return responses, nil
+	return AudiencePage{
+		Users: usersFromGUIDs(page.GUIDs),
+		Next:  page.Next,
+	}, nil
 }
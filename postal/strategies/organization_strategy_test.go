@@ -17,7 +17,7 @@ var _ = Describe("Organization Strategy", func() {
 	var options postal.Options
 	var tokenLoader *fakes.TokenLoader
 	var organizationLoader *fakes.OrganizationLoader
-	var mailer *fakes.Mailer
+	var campaignStarter *fakes.CampaignStarter
 	var clientID string
 	var conn *fakes.DBConn
 	var findsUserGUIDs *fakes.FindsUserGUIDs
@@ -38,7 +38,12 @@ var _ = Describe("Organization Strategy", func() {
 		tokenLoader = fakes.NewTokenLoader()
 		tokenLoader.Token = fakes.BuildToken(tokenHeader, tokenClaims)
 
-		mailer = fakes.NewMailer()
+		campaignStarter = fakes.NewCampaignStarter()
+		campaignStarter.Response = strategies.Response{
+			Status:     "queued",
+			Recipient:  "org-001",
+			CampaignID: "campaign-123",
+		}
 
 		findsUserGUIDs = fakes.NewFindsUserGUIDs()
 		findsUserGUIDs.OrganizationGuids["org-001"] = []string{"user-123", "user-456"}
@@ -49,60 +54,51 @@ var _ = Describe("Organization Strategy", func() {
 			GUID: "org-001",
 		}
 
-		strategy = strategies.NewOrganizationStrategy(tokenLoader, organizationLoader, findsUserGUIDs, mailer)
+		options = postal.Options{
+			KindID:            "forgot_password",
+			KindDescription:   "Password reminder",
+			SourceDescription: "Login system",
+			Text:              "Please reset your password by clicking on this link...",
+			HTML:              postal.HTML{BodyContent: "<p>Please reset your password by clicking on this link...</p>"},
+		}
+
+		strategy = strategies.NewOrganizationStrategy(tokenLoader, organizationLoader, findsUserGUIDs, campaignStarter)
 	})
 
 	Describe("Dispatch", func() {
 		Context("when the request is valid", func() {
-			BeforeEach(func() {
-				options = postal.Options{
-					KindID:            "forgot_password",
-					KindDescription:   "Password reminder",
-					SourceDescription: "Login system",
-					Text:              "Please reset your password by clicking on this link...",
-					HTML:              postal.HTML{BodyContent: "<p>Please reset your password by clicking on this link...</p>"},
-				}
-			})
-
-			It("call mailer.Deliver with the correct arguments for an organization", func() {
+			It("starts a campaign for the organization", func() {
 				Expect(options.Endorsement).To(BeEmpty())
 
-				_, err := strategy.Dispatch(clientID, "org-001", options, conn)
+				responses, err := strategy.Dispatch(clientID, "org-001", options, conn)
 				if err != nil {
 					panic(err)
 				}
 
 				options.Endorsement = strategies.OrganizationEndorsement
-				users := []strategies.User{{GUID: "user-123"}, {GUID: "user-456"}}
-
-				Expect(mailer.DeliverArguments).To(Equal(map[string]interface{}{
+				Expect(campaignStarter.StartArguments).To(Equal(map[string]interface{}{
 					"connection": conn,
-					"users":      users,
-					"options":    options,
-					"space":      cf.CloudControllerSpace{},
-					"org": cf.CloudControllerOrganization{
-						Name: "my-org",
-						GUID: "org-001",
+					"campaign": postal.Campaign{
+						Audience: strategies.OrganizationAudience,
+						GUID:     "org-001",
+						ClientID: clientID,
+						Options:  options,
 					},
-					"client": clientID,
-					"scope":  "",
 				}))
+				Expect(responses).To(Equal([]strategies.Response{campaignStarter.Response}))
 			})
 
 			Context("when the org role field is set", func() {
-				It("calls mailer.Deliver with the correct arguments", func() {
+				It("uses the role endorsement", func() {
 					options.Role = "OrgManager"
 
-					Expect(options.Endorsement).To(BeEmpty())
-
 					_, err := strategy.Dispatch(clientID, "org-001", options, conn)
 					if err != nil {
 						panic(err)
 					}
 
-					options.Endorsement = strategies.OrganizationRoleEndorsement
-
-					Expect(mailer.DeliverArguments).To(ContainElement(options))
+					campaign := campaignStarter.StartArguments["campaign"].(postal.Campaign)
+					Expect(campaign.Options.Endorsement).To(Equal(strategies.OrganizationRoleEndorsement))
 				})
 			})
 		})
@@ -118,22 +114,83 @@ var _ = Describe("Organization Strategy", func() {
 			})
 
 			Context("when organizationLoader fails to load an organization", func() {
-				It("returns the error", func() {
+				It("returns the error without starting a campaign", func() {
 					organizationLoader.LoadError = errors.New("BOOM!")
 					_, err := strategy.Dispatch(clientID, "org-009", options, conn)
 
 					Expect(err).To(Equal(errors.New("BOOM!")))
+					Expect(campaignStarter.StartArguments).To(BeNil())
 				})
 			})
 
-			Context("when finds user GUIDs returns an error", func() {
+			Context("when the campaign cannot be started", func() {
 				It("returns an error", func() {
-					findsUserGUIDs.UserGUIDsBelongingToOrganizationError = errors.New("BOOM!")
-
+					campaignStarter.StartError = errors.New("BOOM!")
 					_, err := strategy.Dispatch(clientID, "org-001", options, conn)
-					Expect(err).ToNot(BeNil())
+
+					Expect(err).To(Equal(errors.New("BOOM!")))
 				})
 			})
 		})
 	})
+
+	Describe("Audience", func() {
+		var campaign postal.Campaign
+
+		BeforeEach(func() {
+			campaign = postal.Campaign{
+				Audience: strategies.OrganizationAudience,
+				GUID:     "org-001",
+				ClientID: clientID,
+				Options:  options,
+			}
+		})
+
+		It("returns the organization", func() {
+			audience, err := strategy.Audience(campaign)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(audience).To(Equal(strategies.Audience{
+				Organization: cf.CloudControllerOrganization{
+					Name: "my-org",
+					GUID: "org-001",
+				},
+			}))
+		})
+	})
+
+	Describe("AudiencePage", func() {
+		var campaign postal.Campaign
+
+		BeforeEach(func() {
+			campaign = postal.Campaign{
+				Audience: strategies.OrganizationAudience,
+				GUID:     "org-001",
+				ClientID: clientID,
+				Options:  options,
+			}
+		})
+
+		It("returns a page of the members of the organization", func() {
+			findsUserGUIDs.NextCursor = "page-2"
+
+			page, err := strategy.AudiencePage(campaign, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(page).To(Equal(strategies.AudiencePage{
+				Users: []strategies.User{{GUID: "user-123"}, {GUID: "user-456"}},
+				Next:  "page-2",
+			}))
+			Expect(findsUserGUIDs.Cursors).To(Equal([]string{""}))
+		})
+
+		Context("when finds user GUIDs returns an error", func() {
+			It("returns an error", func() {
+				findsUserGUIDs.UserGUIDsBelongingToOrganizationError = errors.New("BOOM!")
+
+				_, err := strategy.AudiencePage(campaign, "")
+				Expect(err).To(Equal(findsUserGUIDs.UserGUIDsBelongingToOrganizationError))
+			})
+		})
+	})
 })
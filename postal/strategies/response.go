@@ -3,5 +3,6 @@ package strategies
 type Response struct {
 	Status         string `json:"status"`
 	Recipient      string `json:"recipient"`
-	NotificationID string `json:"notification_id"`
+	NotificationID string `json:"notification_id,omitempty"`
+	CampaignID     string `json:"campaign_id,omitempty"`
 }
@@ -13,18 +13,18 @@ type SpaceStrategy struct {
 	spaceLoader        utilities.SpaceLoaderInterface
 	organizationLoader utilities.OrganizationLoaderInterface
 	findsUserGUIDs     utilities.FindsUserGUIDsInterface
-	mailer             MailerInterface
+	campaigns          CampaignStarterInterface
 }
 
 func NewSpaceStrategy(tokenLoader postal.TokenLoaderInterface, spaceLoader utilities.SpaceLoaderInterface, organizationLoader utilities.OrganizationLoaderInterface,
-	findsUserGUIDs utilities.FindsUserGUIDsInterface, mailer MailerInterface) SpaceStrategy {
+	findsUserGUIDs utilities.FindsUserGUIDsInterface, campaigns CampaignStarterInterface) SpaceStrategy {
 
 	return SpaceStrategy{
 		tokenLoader:        tokenLoader,
 		spaceLoader:        spaceLoader,
 		organizationLoader: organizationLoader,
 		findsUserGUIDs:     findsUserGUIDs,
-		mailer:             mailer,
+		campaigns:          campaigns,
 	}
 }
 
@@ -37,27 +37,59 @@ func (strategy SpaceStrategy) Dispatch(clientID, guid string, options postal.Opt
 		return responses, err
 	}
 
-	userGUIDs, err := strategy.findsUserGUIDs.UserGUIDsBelongingToSpace(guid, token)
+	_, err = strategy.spaceLoader.Load(guid, token)
 	if err != nil {
 		return responses, err
 	}
 
-	var users []User
-	for _, guid := range userGUIDs {
-		users = append(users, User{GUID: guid})
+	response, err := strategy.campaigns.Start(conn, postal.Campaign{
+		Audience: SpaceAudience,
+		GUID:     guid,
+		ClientID: clientID,
+		Options:  options,
+	})
+	if err != nil {
+		return responses, err
 	}
 
-	space, err := strategy.spaceLoader.Load(guid, token)
+	return append(responses, response), nil
+}
+
+func (strategy SpaceStrategy) Audience(campaign postal.Campaign) (Audience, error) {
+	token, err := strategy.tokenLoader.Load()
 	if err != nil {
-		return responses, err
+		return Audience{}, err
+	}
+
+	space, err := strategy.spaceLoader.Load(campaign.GUID, token)
+	if err != nil {
+		return Audience{}, err
 	}
 
 	org, err := strategy.organizationLoader.Load(space.OrganizationGUID, token)
 	if err != nil {
-		return responses, err
+		return Audience{}, err
 	}
 
-	responses = strategy.mailer.Deliver(conn, users, options, space, org, clientID, "")
+	return Audience{
+		Space:        space,
+		Organization: org,
+	}, nil
+}
+
+func (strategy SpaceStrategy) AudiencePage(campaign postal.Campaign, cursor string) (AudiencePage, error) {
+	token, err := strategy.tokenLoader.Load()
+	if err != nil {
+		return AudiencePage{}, err
+	}
+
+	page, err := strategy.findsUserGUIDs.UserGUIDsBelongingToSpace(campaign.GUID, token, cursor)
+	if err != nil {
+		return AudiencePage{}, err
+	}
 
-	return responses, nil
+	return AudiencePage{
+		Users: usersFromGUIDs(page.GUIDs),
+		Next:  page.Next,
+	}, nil
 }
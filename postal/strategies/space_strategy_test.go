@@ -18,7 +18,7 @@ var _ = Describe("Space Strategy", func() {
 	var tokenLoader *fakes.TokenLoader
 	var spaceLoader *fakes.SpaceLoader
 	var organizationLoader *fakes.OrganizationLoader
-	var mailer *fakes.Mailer
+	var campaignStarter *fakes.CampaignStarter
 	var clientID string
 	var conn *fakes.DBConn
 	var findsUserGUIDs *fakes.FindsUserGUIDs
@@ -39,7 +39,12 @@ var _ = Describe("Space Strategy", func() {
 		tokenLoader = fakes.NewTokenLoader()
 		tokenLoader.Token = fakes.BuildToken(tokenHeader, tokenClaims)
 
-		mailer = fakes.NewMailer()
+		campaignStarter = fakes.NewCampaignStarter()
+		campaignStarter.Response = strategies.Response{
+			Status:     "queued",
+			Recipient:  "space-001",
+			CampaignID: "campaign-123",
+		}
 
 		findsUserGUIDs = fakes.NewFindsUserGUIDs()
 		findsUserGUIDs.SpaceGuids["space-001"] = []string{"user-123", "user-456"}
@@ -57,48 +62,45 @@ var _ = Describe("Space Strategy", func() {
 			GUID: "org-001",
 		}
 
-		strategy = strategies.NewSpaceStrategy(tokenLoader, spaceLoader, organizationLoader, findsUserGUIDs, mailer)
+		options = postal.Options{
+			KindID:            "forgot_password",
+			KindDescription:   "Password reminder",
+			SourceDescription: "Login system",
+			Text:              "Please reset your password by clicking on this link...",
+			HTML:              postal.HTML{BodyContent: "<p>Please reset your password by clicking on this link...</p>"},
+		}
+
+		strategy = strategies.NewSpaceStrategy(tokenLoader, spaceLoader, organizationLoader, findsUserGUIDs, campaignStarter)
 	})
 
 	Describe("Dispatch", func() {
 		Context("when the request is valid", func() {
-			BeforeEach(func() {
-				options = postal.Options{
-					KindID:            "forgot_password",
-					KindDescription:   "Password reminder",
-					SourceDescription: "Login system",
-					Text:              "Please reset your password by clicking on this link...",
-					HTML:              postal.HTML{BodyContent: "<p>Please reset your password by clicking on this link...</p>"},
-				}
-			})
-
-			It("calls mailer.Deliver with the correct arguments for a space", func() {
+			It("starts a campaign for the space", func() {
 				Expect(options.Endorsement).To(BeEmpty())
 
-				_, err := strategy.Dispatch(clientID, "space-001", options, conn)
+				responses, err := strategy.Dispatch(clientID, "space-001", options, conn)
 				if err != nil {
 					panic(err)
 				}
 
-				users := []strategies.User{{GUID: "user-123"}, {GUID: "user-456"}}
-
 				options.Endorsement = strategies.SpaceEndorsement
-				Expect(mailer.DeliverArguments).To(Equal(map[string]interface{}{
+				Expect(campaignStarter.StartArguments).To(Equal(map[string]interface{}{
 					"connection": conn,
-					"users":      users,
-					"options":    options,
-					"space": cf.CloudControllerSpace{
-						GUID:             "space-001",
-						Name:             "production",
-						OrganizationGUID: "org-001",
+					"campaign": postal.Campaign{
+						Audience: strategies.SpaceAudience,
+						GUID:     "space-001",
+						ClientID: clientID,
+						Options:  options,
 					},
-					"org": cf.CloudControllerOrganization{
-						Name: "the-org",
-						GUID: "org-001",
-					},
-					"client": clientID,
-					"scope":  "",
 				}))
+				Expect(responses).To(Equal([]strategies.Response{campaignStarter.Response}))
+			})
+
+			It("does not look up the members of the space", func() {
+				findsUserGUIDs.UserGUIDsBelongingToSpaceError = errors.New("BOOM!")
+
+				_, err := strategy.Dispatch(clientID, "space-001", options, conn)
+				Expect(err).NotTo(HaveOccurred())
 			})
 		})
 
@@ -113,22 +115,98 @@ var _ = Describe("Space Strategy", func() {
 			})
 
 			Context("when spaceLoader fails to load a space", func() {
-				It("returns an error", func() {
+				It("returns an error without starting a campaign", func() {
 					spaceLoader.LoadError = errors.New("BOOM!")
 					_, err := strategy.Dispatch(clientID, "space-000", options, conn)
 
 					Expect(err).To(Equal(errors.New("BOOM!")))
+					Expect(campaignStarter.StartArguments).To(BeNil())
 				})
 			})
 
-			Context("when findsUserGUIDs returns an err", func() {
+			Context("when the campaign cannot be started", func() {
 				It("returns an error", func() {
-					findsUserGUIDs.UserGUIDsBelongingToSpaceError = errors.New("BOOM!")
-
+					campaignStarter.StartError = errors.New("BOOM!")
 					_, err := strategy.Dispatch(clientID, "space-001", options, conn)
-					Expect(err).To(Equal(findsUserGUIDs.UserGUIDsBelongingToSpaceError))
+
+					Expect(err).To(Equal(errors.New("BOOM!")))
 				})
 			})
 		})
 	})
+
+	Describe("Audience", func() {
+		var campaign postal.Campaign
+
+		BeforeEach(func() {
+			campaign = postal.Campaign{
+				Audience: strategies.SpaceAudience,
+				GUID:     "space-001",
+				ClientID: clientID,
+				Options:  options,
+			}
+		})
+
+		It("returns the space along with its organization", func() {
+			audience, err := strategy.Audience(campaign)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(audience).To(Equal(strategies.Audience{
+				Space: cf.CloudControllerSpace{
+					GUID:             "space-001",
+					Name:             "production",
+					OrganizationGUID: "org-001",
+				},
+				Organization: cf.CloudControllerOrganization{
+					Name: "the-org",
+					GUID: "org-001",
+				},
+			}))
+		})
+
+		Context("when organizationLoader fails to load an organization", func() {
+			It("returns an error", func() {
+				organizationLoader.LoadError = errors.New("BOOM!")
+
+				_, err := strategy.Audience(campaign)
+				Expect(err).To(Equal(errors.New("BOOM!")))
+			})
+		})
+	})
+
+	Describe("AudiencePage", func() {
+		var campaign postal.Campaign
+
+		BeforeEach(func() {
+			campaign = postal.Campaign{
+				Audience: strategies.SpaceAudience,
+				GUID:     "space-001",
+				ClientID: clientID,
+				Options:  options,
+			}
+		})
+
+		It("returns the page of the members of the space that the cursor points at", func() {
+			findsUserGUIDs.SpaceGuids["space-001page-2"] = []string{"user-789"}
+			findsUserGUIDs.NextCursor = "page-3"
+
+			page, err := strategy.AudiencePage(campaign, "page-2")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(page).To(Equal(strategies.AudiencePage{
+				Users: []strategies.User{{GUID: "user-789"}},
+				Next:  "page-3",
+			}))
+			Expect(findsUserGUIDs.Cursors).To(Equal([]string{"page-2"}))
+		})
+
+		Context("when findsUserGUIDs returns an err", func() {
+			It("returns an error", func() {
+				findsUserGUIDs.UserGUIDsBelongingToSpaceError = errors.New("BOOM!")
+
+				_, err := strategy.AudiencePage(campaign, "")
+				Expect(err).To(Equal(findsUserGUIDs.UserGUIDsBelongingToSpaceError))
+			})
+		})
+	})
 })
@@ -1,7 +1,6 @@
 package strategies
 
 import (
-	"github.com/cloudfoundry-incubator/notifications/cf"
 	"github.com/cloudfoundry-incubator/notifications/models"
 	"github.com/cloudfoundry-incubator/notifications/postal"
 	"github.com/cloudfoundry-incubator/notifications/postal/utilities"
@@ -12,7 +11,7 @@ const ScopeEndorsement = "You received this message because you have the {{.Scop
 type UAAScopeStrategy struct {
 	findsUserGUIDs utilities.FindsUserGUIDsInterface
 	tokenLoader    postal.TokenLoaderInterface
-	mailer         MailerInterface
+	campaigns      CampaignStarterInterface
 }
 
 type DefaultScopeError struct{}
@@ -22,12 +21,12 @@ func (d DefaultScopeError) Error() string {
 }
 
 func NewUAAScopeStrategy(tokenLoader postal.TokenLoaderInterface, findsUserGUIDs utilities.FindsUserGUIDsInterface,
-	mailer MailerInterface) UAAScopeStrategy {
+	campaigns CampaignStarterInterface) UAAScopeStrategy {
 
 	return UAAScopeStrategy{
 		findsUserGUIDs: findsUserGUIDs,
 		tokenLoader:    tokenLoader,
-		mailer:         mailer,
+		campaigns:      campaigns,
 	}
 }
 
@@ -39,24 +38,46 @@ func (strategy UAAScopeStrategy) Dispatch(clientID, scope string, options postal
 		return responses, DefaultScopeError{}
 	}
 
-	_, err := strategy.tokenLoader.Load() // TODO: (rm) this triggers a weird side-effect that is required
+	_, err := strategy.tokenLoader.Load()
 	if err != nil {
 		return responses, err
 	}
 
-	userGUIDs, err := strategy.findsUserGUIDs.UserGUIDsBelongingToScope(scope)
+	response, err := strategy.campaigns.Start(conn, postal.Campaign{
+		Audience: ScopeAudience,
+		GUID:     scope,
+		ClientID: clientID,
+		Options:  options,
+	})
 	if err != nil {
 		return responses, err
 	}
 
-	var users []User
-	for _, guid := range userGUIDs {
-		users = append(users, User{GUID: guid})
+	return append(responses, response), nil
+}
+
+func (strategy UAAScopeStrategy) Audience(campaign postal.Campaign) (Audience, error) {
+	return Audience{
+		Scope: campaign.GUID,
+	}, nil
+}
+
+// AudiencePage returns the members of the scope as a single page, since UAA
+// lists the members of a group in one response.
+func (strategy UAAScopeStrategy) AudiencePage(campaign postal.Campaign, cursor string) (AudiencePage, error) {
+	_, err := strategy.tokenLoader.Load() // TODO: (rm) this triggers a weird side-effect that is required
+	if err != nil {
+		return AudiencePage{}, err
 	}
 
-	responses = strategy.mailer.Deliver(conn, users, options, cf.CloudControllerSpace{}, cf.CloudControllerOrganization{}, clientID, scope)
+	userGUIDs, err := strategy.findsUserGUIDs.UserGUIDsBelongingToScope(campaign.GUID)
+	if err != nil {
+		return AudiencePage{}, err
+	}
 
-	return responses, nil
+	return AudiencePage{
+		Users: usersFromGUIDs(userGUIDs),
+	}, nil
 }
 
 func (strategy UAAScopeStrategy) scopeIsDefault(scope string) bool {
@@ -3,7 +3,6 @@ package strategies_test
 import (
 	"errors"
 
-	"github.com/cloudfoundry-incubator/notifications/cf"
 	"github.com/cloudfoundry-incubator/notifications/fakes"
 	"github.com/cloudfoundry-incubator/notifications/postal"
 	"github.com/cloudfoundry-incubator/notifications/postal/strategies"
@@ -16,7 +15,7 @@ var _ = Describe("UAA Scope Strategy", func() {
 	var strategy strategies.UAAScopeStrategy
 	var options postal.Options
 	var tokenLoader *fakes.TokenLoader
-	var mailer *fakes.Mailer
+	var campaignStarter *fakes.CampaignStarter
 	var clientID string
 	var conn *fakes.DBConn
 	var findsUserGUIDs *fakes.FindsUserGUIDs
@@ -39,46 +38,48 @@ var _ = Describe("UAA Scope Strategy", func() {
 		tokenLoader = fakes.NewTokenLoader()
 		tokenLoader.Token = fakes.BuildToken(tokenHeader, tokenClaims)
 
-		mailer = fakes.NewMailer()
+		campaignStarter = fakes.NewCampaignStarter()
+		campaignStarter.Response = strategies.Response{
+			Status:     "queued",
+			Recipient:  scope,
+			CampaignID: "campaign-123",
+		}
 
 		findsUserGUIDs = fakes.NewFindsUserGUIDs()
 		findsUserGUIDs.GUIDsWithScopes[scope] = []string{"user-311"}
 
-		strategy = strategies.NewUAAScopeStrategy(tokenLoader, findsUserGUIDs, mailer)
+		options = postal.Options{
+			KindID:            "forgot_waterbottle",
+			KindDescription:   "Water Bottle Reminder",
+			SourceDescription: "The Water Bottle System",
+			Text:              "Please make sure to leave your bottle in a place that is safe and dry",
+			HTML:              postal.HTML{BodyContent: "<p>The water bottle needs to be safe and dry</p>"},
+		}
+
+		strategy = strategies.NewUAAScopeStrategy(tokenLoader, findsUserGUIDs, campaignStarter)
 	})
 
 	Describe("Dispatch", func() {
 		Context("when the request is valid", func() {
-			BeforeEach(func() {
-				options = postal.Options{
-					KindID:            "forgot_waterbottle",
-					KindDescription:   "Water Bottle Reminder",
-					SourceDescription: "The Water Bottle System",
-					Text:              "Please make sure to leave your bottle in a place that is safe and dry",
-					HTML:              postal.HTML{BodyContent: "<p>The water bottle needs to be safe and dry</p>"},
-				}
-			})
-
-			It("call mailer.Deliver with the correct arguments for an UAA Scope", func() {
+			It("starts a campaign for the UAA scope", func() {
 				Expect(options.Endorsement).To(BeEmpty())
 
-				_, err := strategy.Dispatch(clientID, "great.scope", options, conn)
+				responses, err := strategy.Dispatch(clientID, "great.scope", options, conn)
 				if err != nil {
 					panic(err)
 				}
 
 				options.Endorsement = strategies.ScopeEndorsement
-				users := []strategies.User{{GUID: "user-311"}}
-
-				Expect(mailer.DeliverArguments).To(Equal(map[string]interface{}{
+				Expect(campaignStarter.StartArguments).To(Equal(map[string]interface{}{
 					"connection": conn,
-					"users":      users,
-					"options":    options,
-					"space":      cf.CloudControllerSpace{},
-					"org":        cf.CloudControllerOrganization{},
-					"client":     clientID,
-					"scope":      scope,
+					"campaign": postal.Campaign{
+						Audience: strategies.ScopeAudience,
+						GUID:     scope,
+						ClientID: clientID,
+						Options:  options,
+					},
 				}))
+				Expect(responses).To(Equal([]strategies.Response{campaignStarter.Response}))
 			})
 		})
 
@@ -92,12 +93,12 @@ var _ = Describe("UAA Scope Strategy", func() {
 				})
 			})
 
-			Context("when finds user GUIDs returns an error", func() {
+			Context("when the campaign cannot be started", func() {
 				It("returns an error", func() {
-					findsUserGUIDs.UserGUIDsBelongingToScopeError = errors.New("BOOM!")
-
+					campaignStarter.StartError = errors.New("BOOM!")
 					_, err := strategy.Dispatch(clientID, "great.scope", options, conn)
-					Expect(err).To(HaveOccurred())
+
+					Expect(err).To(Equal(errors.New("BOOM!")))
 				})
 			})
 
@@ -114,4 +115,44 @@ var _ = Describe("UAA Scope Strategy", func() {
 			})
 		})
 	})
+
+	Describe("Audience", func() {
+		var campaign postal.Campaign
+
+		BeforeEach(func() {
+			campaign = postal.Campaign{
+				Audience: strategies.ScopeAudience,
+				GUID:     scope,
+				ClientID: clientID,
+				Options:  options,
+			}
+		})
+
+		It("returns the scope", func() {
+			audience, err := strategy.Audience(campaign)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(audience).To(Equal(strategies.Audience{
+				Scope: scope,
+			}))
+		})
+
+		It("returns the users that have the scope as a single page", func() {
+			page, err := strategy.AudiencePage(campaign, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(page).To(Equal(strategies.AudiencePage{
+				Users: []strategies.User{{GUID: "user-311"}},
+			}))
+		})
+
+		Context("when finds user GUIDs returns an error", func() {
+			It("returns an error", func() {
+				findsUserGUIDs.UserGUIDsBelongingToScopeError = errors.New("BOOM!")
+
+				_, err := strategy.AudiencePage(campaign, "")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
 })
@@ -4,3 +4,12 @@ type User struct {
 	GUID  string
 	Email string
 }
+
+func usersFromGUIDs(guids []string) []User {
+	var users []User
+	for _, guid := range guids {
+		users = append(users, User{GUID: guid})
+	}
+
+	return users
+}
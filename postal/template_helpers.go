@@ -0,0 +1,171 @@
+package postal
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// TemplateHelpersVersion is incremented whenever a helper is added or changes
+// behavior, so that template authors know which helpers they can rely on.
+const TemplateHelpersVersion = 1
+
+// TemplateHelpers returns the functions available to subject, text and HTML
+// templates, both when templates are validated and when they are rendered.
+func TemplateHelpers() template.FuncMap {
+	return template.FuncMap{
+		"formatDate": formatDate,
+		"pluralize":  pluralize,
+		"truncate":   truncate,
+		"upper":      upper,
+		"lower":      lower,
+		"url":        buildURL,
+		"default":    defaultValue,
+	}
+}
+
+// formatDate formats a time, or an RFC 3339 timestamp, with a Go layout:
+// {{formatDate "Jan 2, 2006" .Data.sent_at}}
+func formatDate(layout string, value interface{}) (string, error) {
+	switch date := value.(type) {
+	case time.Time:
+		return date.Format(layout), nil
+	case *time.Time:
+		if date == nil {
+			return "", nil
+		}
+		return date.Format(layout), nil
+	case string:
+		if date == "" {
+			return "", nil
+		}
+
+		parsed, err := time.Parse(time.RFC3339, date)
+		if err != nil {
+			return "", fmt.Errorf("formatDate: %q is not an RFC 3339 timestamp", date)
+		}
+		return parsed.Format(layout), nil
+	default:
+		return "", fmt.Errorf("formatDate: cannot format a %T", value)
+	}
+}
+
+// pluralize picks the singular or plural form for a count:
+// {{.Data.space_count}} {{pluralize .Data.space_count "space" "spaces"}}
+func pluralize(count interface{}, singular, plural string) (string, error) {
+	number, err := toNumber(count)
+	if err != nil {
+		return "", fmt.Errorf("pluralize: %s", err)
+	}
+
+	if number == 1 {
+		return singular, nil
+	}
+
+	return plural, nil
+}
+
+// truncate shortens text to at most length characters, ending in "..." when
+// anything was cut off: {{truncate 40 .Subject}}
+func truncate(length int, value interface{}) string {
+	const ellipsis = "..."
+
+	text := toText(value)
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+
+	if length <= len(ellipsis) {
+		return string(runes[:length])
+	}
+
+	return string(runes[:length-len(ellipsis)]) + ellipsis
+}
+
+// upper upper-cases any value, such as a field of Data: {{upper .Data.env}}
+func upper(value interface{}) string {
+	return strings.ToUpper(toText(value))
+}
+
+// lower lower-cases any value: {{lower .Space}}
+func lower(value interface{}) string {
+	return strings.ToLower(toText(value))
+}
+
+// buildURL appends path segments, escaped, to a base URL:
+// {{url "https://console.example.com/organizations" .OrganizationGUID "spaces" .SpaceGUID}}
+func buildURL(base string, segments ...interface{}) (string, error) {
+	parsed, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("url: %q is not a valid URL", base)
+	}
+
+	escapedPath := strings.TrimSuffix(parsed.EscapedPath(), "/")
+	for _, segment := range segments {
+		escapedPath += "/" + url.PathEscape(fmt.Sprint(segment))
+	}
+
+	parsed.Path, err = url.PathUnescape(escapedPath)
+	if err != nil {
+		return "", err
+	}
+	parsed.RawPath = escapedPath
+
+	return parsed.String(), nil
+}
+
+// defaultValue returns the fallback when value is empty:
+// {{.Space | default "your space"}}
+func defaultValue(fallback, value interface{}) interface{} {
+	reflected := reflect.ValueOf(value)
+	if !reflected.IsValid() {
+		return fallback
+	}
+
+	switch reflected.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		if reflected.Len() == 0 {
+			return fallback
+		}
+	default:
+		if reflected.IsZero() {
+			return fallback
+		}
+	}
+
+	return value
+}
+
+// toText formats a value the way the template would print it, except that a
+// missing value is empty rather than "<nil>".
+func toText(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+
+	return fmt.Sprint(value)
+}
+
+func toNumber(value interface{}) (float64, error) {
+	switch number := value.(type) {
+	case int:
+		return float64(number), nil
+	case int64:
+		return float64(number), nil
+	case float64:
+		return number, nil
+	case string:
+		parsed, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a number", number)
+		}
+		return parsed, nil
+	default:
+		return 0, fmt.Errorf("%v is not a number", value)
+	}
+}
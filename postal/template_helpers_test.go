@@ -0,0 +1,136 @@
+package postal_test
+
+import (
+	"bytes"
+	"text/template"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/postal"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TemplateHelpers", func() {
+	render := func(source string, data interface{}) (string, error) {
+		parsed, err := template.New("helpers").Funcs(postal.TemplateHelpers()).Parse(source)
+		if err != nil {
+			return "", err
+		}
+
+		buffer := bytes.NewBuffer([]byte{})
+		err = parsed.Execute(buffer, data)
+
+		return buffer.String(), err
+	}
+
+	Describe("formatDate", func() {
+		It("formats times and RFC 3339 timestamps with a Go layout", func() {
+			data := map[string]interface{}{
+				"Time":      time.Date(2015, time.February, 3, 10, 30, 0, 0, time.UTC),
+				"Timestamp": "2015-02-03T10:30:00Z",
+				"Empty":     "",
+			}
+
+			output, err := render(`{{formatDate "Jan 2, 2006" .Time}}|{{formatDate "2006-01-02 15:04" .Timestamp}}|{{formatDate "Jan 2" .Empty}}`, data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(Equal("Feb 3, 2015|2015-02-03 10:30|"))
+		})
+
+		It("returns an error for values that are not dates", func() {
+			_, err := render(`{{formatDate "Jan 2" .}}`, "next tuesday")
+			Expect(err).To(HaveOccurred())
+
+			_, err = render(`{{formatDate "Jan 2" .}}`, 42)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("pluralize", func() {
+		It("picks the singular form only for a count of one", func() {
+			output, err := render(`{{pluralize 0 "space" "spaces"}}|{{pluralize 1 "space" "spaces"}}|{{pluralize 2.0 "space" "spaces"}}|{{pluralize "1" "space" "spaces"}}`, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(Equal("spaces|space|spaces|space"))
+		})
+
+		It("returns an error when the count is not a number", func() {
+			_, err := render(`{{pluralize "many" "space" "spaces"}}`, nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("truncate", func() {
+		It("shortens long text, ending it in an ellipsis", func() {
+			output, err := render(`{{truncate 10 "short"}}|{{truncate 10 "a good deal longer"}}|{{truncate 2 "café"}}|{{truncate 4 "crème brûlée"}}`, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(Equal("short|a good ...|ca|c..."))
+		})
+
+		It("formats values that are not strings", func() {
+			data := map[string]interface{}{
+				"Count": 1234567,
+				"Data":  map[string]interface{}{},
+			}
+
+			output, err := render(`{{truncate 5 .Count}}|{{truncate 5 .Data.missing}}`, data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(Equal("12...|"))
+		})
+	})
+
+	Describe("upper and lower", func() {
+		It("changes the case of the text", func() {
+			output, err := render(`{{upper "Banana"}}|{{"Banana" | lower}}`, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(Equal("BANANA|banana"))
+		})
+
+		It("formats values that are not strings", func() {
+			data := map[string]interface{}{
+				"Data": map[string]interface{}{"env": "prod", "ready": true},
+			}
+
+			output, err := render(`{{upper .Data.env}}|{{upper .Data.ready}}|{{lower .Data.missing}}`, data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(Equal("PROD|TRUE|"))
+		})
+	})
+
+	Describe("url", func() {
+		It("appends escaped path segments to the base URL", func() {
+			output, err := render(`{{url "https://console.example.com/organizations/" "org guid" "spaces" "a/b" 3}}`, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(Equal("https://console.example.com/organizations/org%20guid/spaces/a%2Fb/3"))
+		})
+
+		It("keeps the query string of the base URL", func() {
+			output, err := render(`{{url "https://example.com/unsubscribe?source=email" "the-id"}}`, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(Equal("https://example.com/unsubscribe/the-id?source=email"))
+		})
+
+		It("returns an error when the base is not a URL", func() {
+			_, err := render(`{{url "%zz" "segment"}}`, nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("default", func() {
+		It("returns the fallback for empty values", func() {
+			data := map[string]interface{}{
+				"Empty":  "",
+				"Zero":   0,
+				"List":   []string{},
+				"Filled": "development",
+			}
+
+			output, err := render(`{{.Empty | default "none"}}|{{.Zero | default 5}}|{{.List | default "no items"}}|{{.Missing | default "missing"}}|{{.Filled | default "none"}}`, data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(Equal("none|5|no items|missing|development"))
+		})
+	})
+
+	It("is versioned", func() {
+		Expect(postal.TemplateHelpersVersion).To(Equal(1))
+	})
+})
@@ -58,6 +58,8 @@ func (loader TemplatesLoader) loadTemplate(conn models.ConnectionInterface, temp
 	}
 
 	return Templates{
+		ID:      template.ID,
+		Version: template.Version,
 		Subject: template.Subject,
 		Text:    template.Text,
 		HTML:    template.HTML,
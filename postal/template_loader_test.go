@@ -68,6 +68,7 @@ var _ = Describe("TemplateLoader", func() {
 			BeforeEach(func() {
 				template, err := templatesRepo.Create(conn, models.Template{
 					ID:      "my-kind-template",
+					Version: 3,
 					Name:    "my-kind-template",
 					HTML:    "<p>kind template</p>",
 					Text:    "some kind template text",
@@ -88,6 +89,8 @@ var _ = Describe("TemplateLoader", func() {
 				templates, err := loader.LoadTemplates("my-client-id", "my-kind-id")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(templates).To(Equal(postal.Templates{
+					ID:      "my-kind-template",
+					Version: 3,
 					HTML:    "<p>kind template</p>",
 					Text:    "some kind template text",
 					Subject: "kind subject",
@@ -119,6 +122,7 @@ var _ = Describe("TemplateLoader", func() {
 				templates, err := loader.LoadTemplates("my-client-id", "my-kind-id")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(templates).To(Equal(postal.Templates{
+					ID:      "my-client-template",
 					HTML:    "<p>client template</p>",
 					Text:    "some client template text",
 					Subject: "client subject",
@@ -131,6 +135,7 @@ var _ = Describe("TemplateLoader", func() {
 				templates, err := loader.LoadTemplates("my-client-id", "my-kind-id")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(templates).To(Equal(postal.Templates{
+					ID:      models.DefaultTemplateID,
 					HTML:    "<p>The default template</p>",
 					Text:    "The default template",
 					Subject: "default subject",
@@ -143,6 +148,7 @@ var _ = Describe("TemplateLoader", func() {
 				templates, err := loader.LoadTemplates("my-client-id", "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(templates).To(Equal(postal.Templates{
+					ID:      models.DefaultTemplateID,
 					HTML:    "<p>The default template</p>",
 					Text:    "The default template",
 					Subject: "default subject",
@@ -0,0 +1,40 @@
+package postal
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/pivotal-golang/conceal"
+)
+
+type TemplatePreviewerInterface interface {
+	Preview(Delivery, Templates) (mail.Message, error)
+}
+
+// TemplatePreviewer renders a delivery the same way the DeliveryWorker does,
+// without sending it.
+type TemplatePreviewer struct {
+	sender        string
+	encryptionKey []byte
+}
+
+func NewTemplatePreviewer(sender string, encryptionKey []byte) TemplatePreviewer {
+	return TemplatePreviewer{
+		sender:        sender,
+		encryptionKey: encryptionKey,
+	}
+}
+
+func (previewer TemplatePreviewer) Preview(delivery Delivery, templates Templates) (mail.Message, error) {
+	cloak, err := conceal.NewCloak(previewer.encryptionKey)
+	if err != nil {
+		return mail.Message{}, err
+	}
+
+	context := NewMessageContext(delivery, previewer.sender, cloak, templates)
+
+	message, err := NewPackager().Pack(context)
+	if err != nil {
+		return mail.Message{}, TemplateRenderError(err.Error())
+	}
+
+	return message, nil
+}
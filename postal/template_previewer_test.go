@@ -0,0 +1,62 @@
+package postal_test
+
+import (
+	"crypto/md5"
+
+	"github.com/cloudfoundry-incubator/notifications/cf"
+	"github.com/cloudfoundry-incubator/notifications/postal"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TemplatePreviewer", func() {
+	var previewer postal.TemplatePreviewer
+	var delivery postal.Delivery
+	var templates postal.Templates
+
+	BeforeEach(func() {
+		sum := md5.Sum([]byte("banana's are so very tasty"))
+		previewer = postal.NewTemplatePreviewer("no-reply@example.com", sum[:])
+
+		delivery = postal.Delivery{
+			ClientID: "banana-client",
+			UserGUID: "user-123",
+			Email:    "user@example.com",
+			Space:    cf.CloudControllerSpace{GUID: "space-123", Name: "development"},
+			Options: postal.Options{
+				Subject:     "Ripe",
+				Text:        "They are ripe",
+				HTML:        postal.HTML{BodyContent: "<b>They are ripe</b>"},
+				KindID:      "ripeness",
+				Endorsement: "You belong to the {{.Space}} space.",
+			},
+		}
+
+		templates = postal.Templates{
+			Subject: "Bananas: {{.Subject}}",
+			Text:    "{{.Endorsement}} {{.Text}}",
+			HTML:    "<p>{{.Endorsement}}</p>{{.HTML}}",
+		}
+	})
+
+	It("packs the message the way the delivery worker does", func() {
+		message, err := previewer.Preview(delivery, templates)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(message.From).To(Equal("no-reply@example.com"))
+		Expect(message.To).To(Equal("user@example.com"))
+		Expect(message.Subject).To(Equal("Bananas: Ripe"))
+		Expect(message.Body[0].Content).To(Equal("You belong to the development space. They are ripe"))
+		Expect(message.Body[1].Content).To(ContainSubstring("<p>You belong to the development space.</p><b>They are ripe</b>"))
+		Expect(message.Headers).To(ContainElement("X-CF-Client-ID: banana-client"))
+	})
+
+	It("returns a TemplateRenderError when a template cannot be executed", func() {
+		templates.Text = "{{.Nope}}"
+
+		_, err := previewer.Preview(delivery, templates)
+		Expect(err).To(BeAssignableToTypeOf(postal.TemplateRenderError("")))
+		Expect(err.Error()).To(ContainSubstring("can't evaluate field Nope"))
+	})
+})
@@ -1,30 +1,50 @@
 package utilities
 
+import "strconv"
+
 type AllUsersInterface interface {
-	AllUserGUIDs() ([]string, error)
+	AllUserGUIDs(string) (UserGUIDsPage, error)
 }
 
 type AllUsers struct {
-	uaa UAAInterface
+	pager UsersPagerInterface
 }
 
-func NewAllUsers(uaa UAAInterface) AllUsers {
+func NewAllUsers(pager UsersPagerInterface) AllUsers {
 	return AllUsers{
-		uaa: uaa,
+		pager: pager,
 	}
 }
 
-func (allUsers AllUsers) AllUserGUIDs() ([]string, error) {
-	var guids []string
+// AllUserGUIDs returns a page of every user in UAA. The cursor is the UAA
+// start index of the page, so an empty cursor starts from the first user.
+func (allUsers AllUsers) AllUserGUIDs(cursor string) (UserGUIDsPage, error) {
+	startIndex := 1
+	if cursor != "" {
+		var err error
+		startIndex, err = strconv.Atoi(cursor)
+		if err != nil {
+			return UserGUIDsPage{}, err
+		}
+	}
 
-	usersMap, err := allUsers.uaa.AllUsers()
+	users, totalResults, err := allUsers.pager.UsersPage(startIndex)
 	if err != nil {
-		return guids, err
+		return UserGUIDsPage{}, err
+	}
+
+	page := UserGUIDsPage{
+		GUIDs: []string{},
+	}
+
+	for _, user := range users {
+		page.GUIDs = append(page.GUIDs, user.ID)
 	}
 
-	for _, user := range usersMap {
-		guids = append(guids, user.ID)
+	nextStartIndex := startIndex + len(users)
+	if len(users) > 0 && nextStartIndex <= totalResults {
+		page.Next = strconv.Itoa(nextStartIndex)
 	}
 
-	return guids, nil
+	return page, nil
 }
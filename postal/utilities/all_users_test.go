@@ -39,19 +39,38 @@ var _ = Describe("AllUserGUIDs", func() {
 			}
 
 			uaaClient.AllUsersData = users
+			uaaClient.UsersPageSize = 2
 		})
 
-		It("returns the UAAUsers, UserGUIDs, and an error", func() {
-			guids, err := allUsers.AllUserGUIDs()
+		It("returns the first page of UserGUIDs along with a cursor for the next page", func() {
+			page, err := allUsers.AllUserGUIDs("")
 			Expect(err).NotTo(HaveOccurred())
-			Expect(guids).To(ConsistOf("user-456", "user-999", "user-123"))
+			Expect(page).To(Equal(utilities.UserGUIDsPage{
+				GUIDs: []string{"user-123", "user-456"},
+				Next:  "3",
+			}))
+			Expect(uaaClient.UsersPageStartIndexes).To(Equal([]int{1}))
+		})
+
+		It("returns the page the cursor points at", func() {
+			page, err := allUsers.AllUserGUIDs("3")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page).To(Equal(utilities.UserGUIDsPage{
+				GUIDs: []string{"user-999"},
+			}))
+			Expect(uaaClient.UsersPageStartIndexes).To(Equal([]int{3}))
+		})
+
+		It("returns an error when the cursor is not a start index", func() {
+			_, err := allUsers.AllUserGUIDs("banana")
+			Expect(err).To(HaveOccurred())
 		})
 	})
 
 	Context("when the request to UAA fails", func() {
 		It("bubbles up the error", func() {
 			uaaClient.AllUsersError = errors.New("BOOM!")
-			_, err := allUsers.AllUserGUIDs()
+			_, err := allUsers.AllUserGUIDs("")
 			Expect(err).To(HaveOccurred())
 			Expect(err).To(MatchError(errors.New("BOOM!")))
 		})
@@ -2,14 +2,21 @@ package utilities
 
 import "github.com/cloudfoundry-incubator/notifications/cf"
 
+// UserGUIDsPage is a single page of the GUIDs in an audience. Next is the
+// cursor for the following page, and is empty on the last page.
+type UserGUIDsPage struct {
+	GUIDs []string
+	Next  string
+}
+
 type FindsUserGUIDs struct {
 	cloudController cf.CloudControllerInterface
 	uaa             UAAInterface
 }
 
 type FindsUserGUIDsInterface interface {
-	UserGUIDsBelongingToSpace(string, string) ([]string, error)
-	UserGUIDsBelongingToOrganization(string, string, string) ([]string, error)
+	UserGUIDsBelongingToSpace(string, string, string) (UserGUIDsPage, error)
+	UserGUIDsBelongingToOrganization(string, string, string, string) (UserGUIDsPage, error)
 	UserGUIDsBelongingToScope(string) ([]string, error)
 }
 
@@ -20,46 +27,22 @@ func NewFindsUserGUIDs(cloudController cf.CloudControllerInterface, uaa UAAInter
 	}
 }
 
-func (finder FindsUserGUIDs) UserGUIDsBelongingToSpace(spaceGUID, token string) ([]string, error) {
-	var userGUIDs []string
-
-	users, err := finder.cloudController.GetUsersBySpaceGuid(spaceGUID, token)
+func (finder FindsUserGUIDs) UserGUIDsBelongingToSpace(spaceGUID, token, cursor string) (UserGUIDsPage, error) {
+	page, err := finder.cloudController.GetUsersPageBySpaceGuid(spaceGUID, token, cursor)
 	if err != nil {
-		return userGUIDs, err
+		return UserGUIDsPage{}, err
 	}
 
-	for _, user := range users {
-		userGUIDs = append(userGUIDs, user.GUID)
-	}
-
-	return userGUIDs, nil
+	return userGUIDsPageFromUsers(page), nil
 }
 
-func (finder FindsUserGUIDs) UserGUIDsBelongingToOrganization(orgGUID, role, token string) ([]string, error) {
-	var userGUIDs []string
-	var users []cf.CloudControllerUser
-	var err error
-
-	switch role {
-	case "OrgManager":
-		users, err = finder.cloudController.GetManagersByOrgGuid(orgGUID, token)
-	case "OrgAuditor":
-		users, err = finder.cloudController.GetAuditorsByOrgGuid(orgGUID, token)
-	case "BillingManager":
-		users, err = finder.cloudController.GetBillingManagersByOrgGuid(orgGUID, token)
-	default:
-		users, err = finder.cloudController.GetUsersByOrgGuid(orgGUID, token)
-	}
-
+func (finder FindsUserGUIDs) UserGUIDsBelongingToOrganization(orgGUID, role, token, cursor string) (UserGUIDsPage, error) {
+	page, err := finder.cloudController.GetUsersPageByOrgGuid(orgGUID, role, token, cursor)
 	if err != nil {
-		return userGUIDs, err
-	}
-
-	for _, user := range users {
-		userGUIDs = append(userGUIDs, user.GUID)
+		return UserGUIDsPage{}, err
 	}
 
-	return userGUIDs, nil
+	return userGUIDsPageFromUsers(page), nil
 }
 
 func (finder FindsUserGUIDs) UserGUIDsBelongingToScope(scope string) ([]string, error) {
@@ -72,3 +55,16 @@ func (finder FindsUserGUIDs) UserGUIDsBelongingToScope(scope string) ([]string,
 
 	return userGUIDs, nil
 }
+
+func userGUIDsPageFromUsers(page cf.CloudControllerUsersPage) UserGUIDsPage {
+	guids := UserGUIDsPage{
+		GUIDs: []string{},
+		Next:  page.Next,
+	}
+
+	for _, user := range page.Users {
+		guids.GUIDs = append(guids.GUIDs, user.GUID)
+	}
+
+	return guids
+}
@@ -46,104 +46,85 @@ var _ = Describe("FindsUserGUIDs", func() {
 
 	Context("when looking for GUIDs belonging to a space", func() {
 		BeforeEach(func() {
-			cc.UsersBySpaceGuid["space-001"] = []cf.CloudControllerUser{
-				cf.CloudControllerUser{GUID: "user-123"},
-				cf.CloudControllerUser{GUID: "user-789"},
+			cc.UsersPages["space-001"] = cf.CloudControllerUsersPage{
+				Users: []cf.CloudControllerUser{{GUID: "user-123"}, {GUID: "user-789"}},
+				Next:  "/v2/users?page=2",
+			}
+			cc.UsersPages["space-001/v2/users?page=2"] = cf.CloudControllerUsersPage{
+				Users: []cf.CloudControllerUser{{GUID: "user-456"}},
 			}
 		})
 
-		It("returns the user GUIDs for the space", func() {
-			guids, err := finder.UserGUIDsBelongingToSpace("space-001", "token")
+		It("returns a page of user GUIDs for the space", func() {
+			page, err := finder.UserGUIDsBelongingToSpace("space-001", "token", "")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(page).To(Equal(utilities.UserGUIDsPage{
+				GUIDs: []string{"user-123", "user-789"},
+				Next:  "/v2/users?page=2",
+			}))
+		})
 
-			Expect(guids).To(Equal([]string{"user-123", "user-789"}))
+		It("returns the page the cursor points at", func() {
+			page, err := finder.UserGUIDsBelongingToSpace("space-001", "token", "/v2/users?page=2")
 			Expect(err).NotTo(HaveOccurred())
+
+			Expect(page).To(Equal(utilities.UserGUIDsPage{
+				GUIDs: []string{"user-456"},
+			}))
 		})
 
 		Context("when CloudController causes an error", func() {
 			BeforeEach(func() {
-				cc.GetUsersBySpaceGuidError = errors.New("BOOM!")
+				cc.GetUsersPageError = errors.New("BOOM!")
 			})
 
 			It("returns the error", func() {
-				_, err := finder.UserGUIDsBelongingToSpace("space-001", "token")
+				_, err := finder.UserGUIDsBelongingToSpace("space-001", "token", "")
 
-				Expect(err).To(Equal(cc.GetUsersBySpaceGuidError))
+				Expect(err).To(Equal(cc.GetUsersPageError))
 			})
 		})
 	})
 
 	Context("when looking for GUIDs belonging to an organization", func() {
 		BeforeEach(func() {
-			cc.UsersByOrganizationGuid["org-001"] = []cf.CloudControllerUser{
-				cf.CloudControllerUser{GUID: "user-456"},
-				cf.CloudControllerUser{GUID: "user-001"},
+			cc.UsersPages["org-001"] = cf.CloudControllerUsersPage{
+				Users: []cf.CloudControllerUser{{GUID: "user-456"}, {GUID: "user-001"}},
+				Next:  "/v2/organizations/org-001/users?page=2",
+			}
+			cc.UsersPages["org-001OrgManager"] = cf.CloudControllerUsersPage{
+				Users: []cf.CloudControllerUser{{GUID: "user-678"}, {GUID: "user-xxx"}},
 			}
 		})
 
-		It("returns the user GUIDs for the organization", func() {
-			guids, err := finder.UserGUIDsBelongingToOrganization("org-001", "", "token")
-
-			Expect(guids).To(Equal([]string{"user-456", "user-001"}))
+		It("returns a page of user GUIDs for the organization", func() {
+			page, err := finder.UserGUIDsBelongingToOrganization("org-001", "", "token", "")
 			Expect(err).NotTo(HaveOccurred())
-		})
 
-		Context("when CloudController causes an error", func() {
-			BeforeEach(func() {
-				cc.GetUsersByOrganizationGuidError = errors.New("BOOM!")
-			})
-
-			It("returns the error", func() {
-				_, err := finder.UserGUIDsBelongingToOrganization("org-001", "", "token")
-
-				Expect(err).To(Equal(cc.GetUsersByOrganizationGuidError))
-			})
+			Expect(page).To(Equal(utilities.UserGUIDsPage{
+				GUIDs: []string{"user-456", "user-001"},
+				Next:  "/v2/organizations/org-001/users?page=2",
+			}))
 		})
 
-		Context("when the role is OrgManager", func() {
-			BeforeEach(func() {
-				cc.ManagersByOrganization["org-001"] = []cf.CloudControllerUser{
-					cf.CloudControllerUser{GUID: "user-678"},
-					cf.CloudControllerUser{GUID: "user-xxx"},
-				}
-			})
-
-			It("returns the organization managers for the organization", func() {
-				guids, err := finder.UserGUIDsBelongingToOrganization("org-001", "OrgManager", "token")
-
-				Expect(guids).To(Equal([]string{"user-678", "user-xxx"}))
-				Expect(err).NotTo(HaveOccurred())
-			})
-		})
-
-		Context("when the role is OrgAuditor", func() {
-			BeforeEach(func() {
-				cc.AuditorsByOrganization["org-001"] = []cf.CloudControllerUser{
-					cf.CloudControllerUser{GUID: "user-abc"},
-					cf.CloudControllerUser{GUID: "user-zzz"},
-				}
-			})
-
-			It("returns the organization auditors for the organization", func() {
-				guids, err := finder.UserGUIDsBelongingToOrganization("org-001", "OrgAuditor", "token")
+		It("returns the users holding the given role", func() {
+			page, err := finder.UserGUIDsBelongingToOrganization("org-001", "OrgManager", "token", "")
+			Expect(err).NotTo(HaveOccurred())
 
-				Expect(guids).To(Equal([]string{"user-abc", "user-zzz"}))
-				Expect(err).NotTo(HaveOccurred())
-			})
+			Expect(page.GUIDs).To(Equal([]string{"user-678", "user-xxx"}))
+			Expect(page.Next).To(BeEmpty())
 		})
 
-		Context("when the role is BillingManager", func() {
+		Context("when CloudController causes an error", func() {
 			BeforeEach(func() {
-				cc.BillingManagersByOrganization["org-001"] = []cf.CloudControllerUser{
-					cf.CloudControllerUser{GUID: "user-jkl"},
-					cf.CloudControllerUser{GUID: "user-aaa"},
-				}
+				cc.GetUsersPageError = errors.New("BOOM!")
 			})
 
-			It("returns the billing managers for the organization", func() {
-				guids, err := finder.UserGUIDsBelongingToOrganization("org-001", "BillingManager", "token")
+			It("returns the error", func() {
+				_, err := finder.UserGUIDsBelongingToOrganization("org-001", "", "token", "")
 
-				Expect(guids).To(Equal([]string{"user-jkl", "user-aaa"}))
-				Expect(err).NotTo(HaveOccurred())
+				Expect(err).To(Equal(cc.GetUsersPageError))
 			})
 		})
 	})
@@ -6,3 +6,25 @@ type UAAInterface interface {
 	uaa.UsersGUIDsByScopeInterface
 	uaa.AllUsersInterface
 }
+
+type UsersPagerInterface interface {
+	UsersPage(startIndex int) ([]uaa.User, int, error)
+}
+
+// UAAUsersPager reads the UAA user list one page at a time. It shares the
+// client so that it uses whatever token was last loaded onto it.
+type UAAUsersPager struct {
+	client *uaa.UAA
+	host   string
+}
+
+func NewUAAUsersPager(client *uaa.UAA, host string) UAAUsersPager {
+	return UAAUsersPager{
+		client: client,
+		host:   host,
+	}
+}
+
+func (pager UAAUsersPager) UsersPage(startIndex int) ([]uaa.User, int, error) {
+	return uaa.PaginatedUsersFromQuery(*pager.client, uaa.UsersQueryURIFromStartIndex(pager.host, startIndex))
+}
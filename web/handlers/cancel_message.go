@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+	"github.com/ryanmoran/stack"
+)
+
+type CancelMessage struct {
+	canceler    services.MessageCancelerInterface
+	errorWriter ErrorWriterInterface
+}
+
+func NewCancelMessage(canceler services.MessageCancelerInterface, errorWriter ErrorWriterInterface) CancelMessage {
+	return CancelMessage{
+		canceler:    canceler,
+		errorWriter: errorWriter,
+	}
+}
+
+func (handler CancelMessage) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	messageID := strings.Split(req.URL.Path, "/messages/")[1]
+
+	err := handler.canceler.Cancel(messageID)
+	if err != nil {
+		handler.errorWriter.Write(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
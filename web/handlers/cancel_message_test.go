@@ -0,0 +1,54 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/web/handlers"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CancelMessage", func() {
+	var handler handlers.CancelMessage
+	var canceler *fakes.MessageCanceler
+	var errorWriter *fakes.ErrorWriter
+	var writer *httptest.ResponseRecorder
+	var request *http.Request
+	var context stack.Context
+
+	BeforeEach(func() {
+		var err error
+
+		canceler = fakes.NewMessageCanceler()
+		errorWriter = fakes.NewErrorWriter()
+		handler = handlers.NewCancelMessage(canceler, errorWriter)
+		writer = httptest.NewRecorder()
+
+		request, err = http.NewRequest("DELETE", "/messages/message-123", nil)
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	It("cancels the message with the given id", func() {
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(canceler.CancelArgument).To(Equal("message-123"))
+		Expect(writer.Code).To(Equal(http.StatusNoContent))
+	})
+
+	Context("when the canceler errors", func() {
+		It("delegates to the error writer", func() {
+			canceler.CancelError = errors.New("BOOM!")
+
+			handler.ServeHTTP(writer, request, context)
+
+			Expect(errorWriter.Error).To(Equal(canceler.CancelError))
+		})
+	})
+})
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/notifications/web/params"
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+	"github.com/ryanmoran/stack"
+)
+
+type CreateSuppression struct {
+	updater     services.SuppressionsUpdaterInterface
+	errorWriter ErrorWriterInterface
+}
+
+func NewCreateSuppression(updater services.SuppressionsUpdaterInterface, errorWriter ErrorWriterInterface) CreateSuppression {
+	return CreateSuppression{
+		updater:     updater,
+		errorWriter: errorWriter,
+	}
+}
+
+func (handler CreateSuppression) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	suppressionParams, err := params.NewSuppressionParams(req.Body)
+	if err != nil {
+		handler.errorWriter.Write(w, err)
+		return
+	}
+
+	suppression, err := handler.updater.Add(suppressionParams.ToModel())
+	if err != nil {
+		handler.errorWriter.Write(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, suppression)
+}
@@ -0,0 +1,83 @@
+package handlers_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/web/handlers"
+	"github.com/cloudfoundry-incubator/notifications/web/params"
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CreateSuppression", func() {
+	var handler handlers.CreateSuppression
+	var updater *fakes.SuppressionsUpdater
+	var errorWriter *fakes.ErrorWriter
+	var writer *httptest.ResponseRecorder
+	var request *http.Request
+	var context stack.Context
+
+	BeforeEach(func() {
+		var err error
+
+		updater = fakes.NewSuppressionsUpdater()
+		updater.AddResult = services.Suppression{
+			Email:     "bounced@example.com",
+			Reason:    "asked us to stop",
+			CreatedAt: time.Date(2015, time.January, 20, 10, 0, 0, 0, time.UTC),
+		}
+		errorWriter = fakes.NewErrorWriter()
+		handler = handlers.NewCreateSuppression(updater, errorWriter)
+		writer = httptest.NewRecorder()
+
+		request, err = http.NewRequest("POST", "/suppressions", strings.NewReader(`{"email":"bounced@example.com","reason":"asked us to stop"}`))
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	It("suppresses the address and writes out the suppression", func() {
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(updater.AddArgument).To(Equal(models.Suppression{
+			Email:  "bounced@example.com",
+			Reason: "asked us to stop",
+		}))
+		Expect(writer.Code).To(Equal(http.StatusCreated))
+		Expect(writer.Body.Bytes()).To(MatchJSON(`{
+			"email": "bounced@example.com",
+			"reason": "asked us to stop",
+			"created_at": "2015-01-20T10:00:00Z"
+		}`))
+	})
+
+	Context("when the params are invalid", func() {
+		It("delegates to the error writer", func() {
+			request.Body = ioutil.NopCloser(strings.NewReader(`{"reason":"no email"}`))
+
+			handler.ServeHTTP(writer, request, context)
+
+			Expect(errorWriter.Error).To(BeAssignableToTypeOf(params.ValidationError{}))
+		})
+	})
+
+	Context("when the updater errors", func() {
+		It("delegates to the error writer", func() {
+			updater.AddError = errors.New("BOOM!")
+
+			handler.ServeHTTP(writer, request, context)
+
+			Expect(errorWriter.Error).To(Equal(updater.AddError))
+		})
+	})
+})
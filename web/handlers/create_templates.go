@@ -29,7 +29,7 @@ func (handler CreateTemplate) ServeHTTP(w http.ResponseWriter, req *http.Request
 
 	template := templateParams.ToModel()
 
-	templateID, err := handler.Creator.Create(template)
+	templateID, err := handler.Creator.Create(template, templateAuthor(context))
 	if err != nil {
 		handler.ErrorWriter.Write(w, params.TemplateCreateError{})
 		return
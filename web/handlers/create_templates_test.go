@@ -10,6 +10,7 @@ import (
 	"github.com/cloudfoundry-incubator/notifications/models"
 	"github.com/cloudfoundry-incubator/notifications/web/handlers"
 	"github.com/cloudfoundry-incubator/notifications/web/params"
+	"github.com/dgrijalva/jwt-go"
 	"github.com/ryanmoran/stack"
 
 	. "github.com/onsi/ginkgo"
@@ -53,6 +54,17 @@ var _ = Describe("CreateTemplate", func() {
 			Expect(body).To(Equal(`{"template_id":"guid"}`))
 		})
 
+		It("attributes the template to the user on whose behalf the token was issued", func() {
+			context = stack.NewContext()
+			context.Set("token", &jwt.Token{Claims: map[string]interface{}{
+				"client_id": "raptors",
+				"user_id":   "owen-grady",
+			}})
+
+			handler.ServeHTTP(writer, request, context)
+			Expect(creator.CreateAuthor).To(Equal("owen-grady"))
+		})
+
 		Context("when an errors occurs", func() {
 			It("Writes a validation error to the errorwriter when the request is missing the name field", func() {
 				body := []byte(`{"html": "<p>gobble</p>"}`)
@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/models"
+)
+
+func deadJobIDFromPath(path string) (int, error) {
+	parts := strings.Split(strings.Split(path, "/dead_jobs/")[1], "/")
+
+	id, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, models.NewRecordNotFoundError("Dead job with ID %q could not be found", parts[0])
+	}
+
+	return id, nil
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+	"github.com/ryanmoran/stack"
+)
+
+type DeleteDeadJob struct {
+	purger      services.DeadJobPurgerInterface
+	errorWriter ErrorWriterInterface
+}
+
+func NewDeleteDeadJob(purger services.DeadJobPurgerInterface, errorWriter ErrorWriterInterface) DeleteDeadJob {
+	return DeleteDeadJob{
+		purger:      purger,
+		errorWriter: errorWriter,
+	}
+}
+
+func (handler DeleteDeadJob) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	deadJobID, err := deadJobIDFromPath(req.URL.Path)
+	if err != nil {
+		handler.errorWriter.Write(w, err)
+		return
+	}
+
+	err = handler.purger.Purge(deadJobID)
+	if err != nil {
+		handler.errorWriter.Write(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+	"github.com/ryanmoran/stack"
+)
+
+type DeleteSuppression struct {
+	updater     services.SuppressionsUpdaterInterface
+	errorWriter ErrorWriterInterface
+}
+
+func NewDeleteSuppression(updater services.SuppressionsUpdaterInterface, errorWriter ErrorWriterInterface) DeleteSuppression {
+	return DeleteSuppression{
+		updater:     updater,
+		errorWriter: errorWriter,
+	}
+}
+
+func (handler DeleteSuppression) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	email := strings.Split(req.URL.Path, "/suppressions/")[1]
+
+	err := handler.updater.Remove(email)
+	if err != nil {
+		handler.errorWriter.Write(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
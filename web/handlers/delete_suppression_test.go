@@ -0,0 +1,54 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/web/handlers"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeleteSuppression", func() {
+	var handler handlers.DeleteSuppression
+	var updater *fakes.SuppressionsUpdater
+	var errorWriter *fakes.ErrorWriter
+	var writer *httptest.ResponseRecorder
+	var request *http.Request
+	var context stack.Context
+
+	BeforeEach(func() {
+		var err error
+
+		updater = fakes.NewSuppressionsUpdater()
+		errorWriter = fakes.NewErrorWriter()
+		handler = handlers.NewDeleteSuppression(updater, errorWriter)
+		writer = httptest.NewRecorder()
+
+		request, err = http.NewRequest("DELETE", "/suppressions/bounced@example.com", nil)
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	It("removes the suppression for the given address", func() {
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(updater.RemoveArgument).To(Equal("bounced@example.com"))
+		Expect(writer.Code).To(Equal(http.StatusNoContent))
+	})
+
+	Context("when the updater errors", func() {
+		It("delegates to the error writer", func() {
+			updater.RemoveError = errors.New("BOOM!")
+
+			handler.ServeHTTP(writer, request, context)
+
+			Expect(errorWriter.Error).To(Equal(updater.RemoveError))
+		})
+	})
+})
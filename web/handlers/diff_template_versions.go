@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/cloudfoundry-incubator/notifications/web/params"
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+	"github.com/ryanmoran/stack"
+)
+
+type DiffTemplateVersions struct {
+	differ      services.TemplateVersionDifferInterface
+	errorWriter ErrorWriterInterface
+}
+
+type TemplateDiffOutput struct {
+	From     int    `json:"from"`
+	To       int    `json:"to"`
+	Name     string `json:"name"`
+	Subject  string `json:"subject"`
+	Text     string `json:"text"`
+	HTML     string `json:"html"`
+	Metadata string `json:"metadata"`
+}
+
+func NewDiffTemplateVersions(differ services.TemplateVersionDifferInterface, errorWriter ErrorWriterInterface) DiffTemplateVersions {
+	return DiffTemplateVersions{
+		differ:      differ,
+		errorWriter: errorWriter,
+	}
+}
+
+func (handler DiffTemplateVersions) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	templateID := regexp.MustCompile(`\/templates\/(.*)\/diff`).FindStringSubmatch(req.URL.Path)[1]
+
+	query := req.URL.Query()
+	var errors []string
+	from, err := strconv.Atoi(query.Get("from"))
+	if err != nil {
+		errors = append(errors, `"from" must be a version number`)
+	}
+
+	to, err := strconv.Atoi(query.Get("to"))
+	if err != nil {
+		errors = append(errors, `"to" must be a version number`)
+	}
+
+	if len(errors) > 0 {
+		handler.errorWriter.Write(w, params.ValidationError(errors))
+		return
+	}
+
+	diff, err := handler.differ.Diff(templateID, from, to)
+	if err != nil {
+		handler.errorWriter.Write(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, TemplateDiffOutput{
+		From:     diff.From,
+		To:       diff.To,
+		Name:     diff.Name,
+		Subject:  diff.Subject,
+		Text:     diff.Text,
+		HTML:     diff.HTML,
+		Metadata: diff.Metadata,
+	})
+}
@@ -0,0 +1,80 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/web/handlers"
+	"github.com/cloudfoundry-incubator/notifications/web/params"
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DiffTemplateVersions", func() {
+	var handler handlers.DiffTemplateVersions
+	var writer *httptest.ResponseRecorder
+	var differ *fakes.TemplateVersionDiffer
+	var errorWriter *fakes.ErrorWriter
+
+	serve := func(path string) {
+		request, err := http.NewRequest("GET", path, nil)
+		if err != nil {
+			panic(err)
+		}
+
+		handler.ServeHTTP(writer, request, nil)
+	}
+
+	BeforeEach(func() {
+		differ = fakes.NewTemplateVersionDiffer()
+		errorWriter = fakes.NewErrorWriter()
+		handler = handlers.NewDiffTemplateVersions(differ, errorWriter)
+		writer = httptest.NewRecorder()
+	})
+
+	It("returns the diff between the two versions", func() {
+		differ.DiffResult = services.TemplateDiff{
+			From: 1,
+			To:   3,
+			Text: "-green\n+ripe",
+		}
+
+		serve("/templates/banana-template/diff?from=1&to=3")
+
+		Expect(differ.DiffArgumentID).To(Equal("banana-template"))
+		Expect(differ.DiffArgumentFrom).To(Equal(1))
+		Expect(differ.DiffArgumentTo).To(Equal(3))
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body).To(MatchJSON(`{
+			"from": 1,
+			"to": 3,
+			"name": "",
+			"subject": "",
+			"text": "-green\n+ripe",
+			"html": "",
+			"metadata": ""
+		}`))
+	})
+
+	It("writes a validation error when the versions are missing or not numbers", func() {
+		serve("/templates/banana-template/diff?from=latest")
+
+		Expect(errorWriter.Error).To(Equal(params.ValidationError([]string{
+			`"from" must be a version number`,
+			`"to" must be a version number`,
+		})))
+		Expect(differ.DiffArgumentID).To(BeEmpty())
+	})
+
+	It("writes errors from the differ to the error writer", func() {
+		differ.DiffError = models.NewRecordNotFoundError("Version %d of template %q could not be found", 7, "banana-template")
+
+		serve("/templates/banana-template/diff?from=1&to=7")
+
+		Expect(errorWriter.Error).To(Equal(differ.DiffError))
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/cloudfoundry-incubator/notifications/mail"
 	"github.com/cloudfoundry-incubator/notifications/models"
 	"github.com/cloudfoundry-incubator/notifications/postal"
 	"github.com/cloudfoundry-incubator/notifications/postal/strategies"
@@ -36,6 +37,8 @@ func (writer ErrorWriter) Write(w http.ResponseWriter, err error) {
 		writer.write(w, http.StatusBadGateway, []string{err.Error()})
 	case postal.TemplateLoadError:
 		writer.write(w, http.StatusInternalServerError, []string{err.Error()})
+	case postal.TemplateRenderError:
+		writer.write(w, 422, []string{err.Error()})
 	case params.TemplateCreateError:
 		writer.write(w, http.StatusInternalServerError, []string{err.Error()})
 	case models.TemplateFindError:
@@ -60,8 +63,12 @@ func (writer ErrorWriter) Write(w http.ResponseWriter, err error) {
 		writer.write(w, http.StatusNotAcceptable, []string{err.Error()})
 	case services.TemplateAssignmentError:
 		writer.write(w, 422, []string{err.Error()})
+	case services.MessageNotCancelableError:
+		writer.write(w, http.StatusConflict, []string{err.Error()})
 	case MissingUserTokenError:
 		writer.write(w, 422, []string{err.Error()})
+	case mail.DSNParseError:
+		writer.write(w, 422, []string{err.Error()})
 	default:
 		panic(err) // This panic will trigger the Stack recovery handler
 	}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 
+	"github.com/cloudfoundry-incubator/notifications/mail"
 	"github.com/cloudfoundry-incubator/notifications/models"
 	"github.com/cloudfoundry-incubator/notifications/postal"
 	"github.com/cloudfoundry-incubator/notifications/postal/strategies"
@@ -98,6 +99,20 @@ var _ = Describe("ErrorWriter", func() {
 		Expect(body["errors"]).To(ContainElement("Your template doesn't exist!!!"))
 	})
 
+	It("returns a 422 and writes the error message when a template fails to render", func() {
+		writer.Write(recorder, postal.TemplateRenderError(`template: compileTemplate:1:2: executing "compileTemplate" at <.Nope>: can't evaluate field Nope`))
+
+		Expect(recorder.Code).To(Equal(422))
+
+		body := make(map[string]interface{})
+		err := json.Unmarshal(recorder.Body.Bytes(), &body)
+		if err != nil {
+			panic(err)
+		}
+
+		Expect(body["errors"]).To(ContainElement(`template: compileTemplate:1:2: executing "compileTemplate" at <.Nope>: can't evaluate field Nope`))
+	})
+
 	It("returns a 500 when there is a template create error", func() {
 		writer.Write(recorder, params.TemplateCreateError{})
 
@@ -251,6 +266,19 @@ var _ = Describe("ErrorWriter", func() {
 		Expect(body["errors"]).To(ContainElement("The template could not be assigned"))
 	})
 
+	It("returns a 409 when a message can no longer be canceled", func() {
+		writer.Write(recorder, services.MessageNotCancelableError("The message can no longer be canceled"))
+		Expect(recorder.Code).To(Equal(409))
+
+		body := make(map[string]interface{})
+		err := json.Unmarshal(recorder.Body.Bytes(), &body)
+		if err != nil {
+			panic(err)
+		}
+
+		Expect(body["errors"]).To(ContainElement("The message can no longer be canceled"))
+	})
+
 	It("returns a 422 when a user token was expected but is not present", func() {
 		writer.Write(recorder, handlers.MissingUserTokenError("Missing user_id from token claims."))
 		Expect(recorder.Code).To(Equal(422))
@@ -264,6 +292,19 @@ var _ = Describe("ErrorWriter", func() {
 		Expect(body["errors"]).To(ContainElement("Missing user_id from token claims."))
 	})
 
+	It("returns a 422 when a bounce message cannot be parsed", func() {
+		writer.Write(recorder, mail.DSNParseError("message has no delivery status"))
+		Expect(recorder.Code).To(Equal(422))
+
+		body := make(map[string]interface{})
+		err := json.Unmarshal(recorder.Body.Bytes(), &body)
+		if err != nil {
+			panic(err)
+		}
+
+		Expect(body["errors"]).To(ContainElement("Delivery status notification could not be parsed: message has no delivery status"))
+	})
+
 	It("panics for unknown errors", func() {
 		Expect(func() {
 			writer.Write(recorder, errors.New("BOOM!"))
@@ -17,9 +17,10 @@ type Client struct {
 }
 
 type Notification struct {
-	Description string `json:"description"`
-	Template    string `json:"template"`
-	Critical    bool   `json:"critical"`
+	Description string              `json:"description"`
+	Template    string              `json:"template"`
+	Critical    bool                `json:"critical"`
+	RetryPolicy *models.RetryPolicy `json:"retry_policy,omitempty"`
 }
 
 type GetAllNotifications struct {
@@ -58,11 +59,16 @@ func (handler GetAllNotifications) constructNotifications(clients []models.Clien
 		clientNotifications := make(map[string]Notification)
 		for _, notification := range notifications {
 			if notification.ClientID == client.ID {
-				clientNotifications[notification.ID] = Notification{
+				clientNotification := Notification{
 					Description: notification.Description,
 					Template:    notification.TemplateToUse(),
 					Critical:    notification.Critical,
 				}
+				if notification.RetryPolicy.IsSet() {
+					policy := notification.RetryPolicy
+					clientNotification.RetryPolicy = &policy
+				}
+				clientNotifications[notification.ID] = clientNotification
 			}
 		}
 
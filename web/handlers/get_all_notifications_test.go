@@ -114,6 +114,51 @@ var _ = Describe("GetAllNotifications", func() {
 			}`))
 		})
 
+		It("includes the retry policy of notifications that have one", func() {
+			notificationsFinder.Clients = map[string]models.Client{
+				"client-123": {
+					ID:          "client-123",
+					Description: "Jurassic Park",
+				},
+			}
+
+			notificationsFinder.Kinds = map[string]models.Kind{
+				"perimeter-breach": {
+					ID:          "perimeter-breach",
+					Description: "very bad",
+					Critical:    true,
+					ClientID:    "client-123",
+					RetryPolicy: models.RetryPolicy{
+						MaxAttempts:      3,
+						BaseDelaySeconds: 30,
+						Jitter:           0.5,
+					},
+				},
+			}
+
+			handler.ServeHTTP(writer, request, nil)
+
+			Expect(writer.Code).To(Equal(http.StatusOK))
+			Expect(writer.Body.Bytes()).To(MatchJSON(`{
+				"client-123": {
+					"name": "Jurassic Park",
+					"template": "default",
+					"notifications": {
+						"perimeter-breach": {
+							"description": "very bad",
+							"template": "default",
+							"critical": true,
+							"retry_policy": {
+								"max_attempts": 3,
+								"base_delay_seconds": 30,
+								"jitter": 0.5
+							}
+						}
+					}
+				}
+			}`))
+		})
+
 		Context("when the notifications finder errors", func() {
 			It("delegates to the error writer", func() {
 				notificationsFinder.AllClientsAndNotificationsError = errors.New("BANANA!!!")
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+	"github.com/ryanmoran/stack"
+)
+
+type GetCampaign struct {
+	finder      services.CampaignFinderInterface
+	errorWriter ErrorWriterInterface
+}
+
+func NewGetCampaign(finder services.CampaignFinderInterface, errorWriter ErrorWriterInterface) GetCampaign {
+	return GetCampaign{
+		finder:      finder,
+		errorWriter: errorWriter,
+	}
+}
+
+func (handler GetCampaign) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	campaignID := strings.Split(req.URL.Path, "/campaigns/")[1]
+
+	campaign, err := handler.finder.Find(campaignID)
+	if err != nil {
+		handler.errorWriter.Write(w, err)
+		return
+	}
+
+	var document struct {
+		ID        string `json:"id"`
+		Status    string `json:"status"`
+		Expanded  int    `json:"expanded"`
+		Queued    int    `json:"queued"`
+		Delivered int    `json:"delivered"`
+	}
+	document.ID = campaign.ID
+	document.Status = campaign.Status
+	document.Expanded = campaign.Expanded
+	document.Queued = campaign.Queued
+	document.Delivered = campaign.Delivered
+
+	writeJSON(w, http.StatusOK, document)
+}
@@ -0,0 +1,68 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/web/handlers"
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetCampaign", func() {
+	var handler handlers.GetCampaign
+	var errorWriter *fakes.ErrorWriter
+	var writer *httptest.ResponseRecorder
+	var request *http.Request
+	var campaignFinder *fakes.CampaignFinder
+
+	BeforeEach(func() {
+		var err error
+
+		errorWriter = fakes.NewErrorWriter()
+		campaignFinder = fakes.NewCampaignFinder()
+		handler = handlers.NewGetCampaign(campaignFinder, errorWriter)
+		writer = httptest.NewRecorder()
+
+		request, err = http.NewRequest("GET", "/campaigns/campaign-123", nil)
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	Describe("ServeHTTP", func() {
+		It("returns the progress of the campaign", func() {
+			campaignFinder.Campaigns["campaign-123"] = services.Campaign{
+				ID:        "campaign-123",
+				Status:    "expanding",
+				Expanded:  250,
+				Queued:    100,
+				Delivered: 42,
+			}
+
+			handler.ServeHTTP(writer, request, nil)
+
+			Expect(writer.Code).To(Equal(http.StatusOK))
+			Expect(writer.Body.Bytes()).To(MatchJSON(`{
+				"id": "campaign-123",
+				"status": "expanding",
+				"expanded": 250,
+				"queued": 100,
+				"delivered": 42
+			}`))
+		})
+
+		Context("when the finder errors", func() {
+			It("delegates to the error writer", func() {
+				campaignFinder.FindError = errors.New("something bad happened")
+
+				handler.ServeHTTP(writer, request, nil)
+
+				Expect(errorWriter.Error).To(Equal(campaignFinder.FindError))
+			})
+		})
+	})
+})
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+	"github.com/ryanmoran/stack"
+)
+
+type GetDeadJob struct {
+	finder      services.DeadJobsFinderInterface
+	errorWriter ErrorWriterInterface
+}
+
+func NewGetDeadJob(finder services.DeadJobsFinderInterface, errorWriter ErrorWriterInterface) GetDeadJob {
+	return GetDeadJob{
+		finder:      finder,
+		errorWriter: errorWriter,
+	}
+}
+
+func (handler GetDeadJob) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	deadJobID, err := deadJobIDFromPath(req.URL.Path)
+	if err != nil {
+		handler.errorWriter.Write(w, err)
+		return
+	}
+
+	deadJob, err := handler.finder.Find(deadJobID)
+	if err != nil {
+		handler.errorWriter.Write(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, deadJob)
+}
@@ -0,0 +1,83 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/web/handlers"
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetDeadJob", func() {
+	var handler handlers.GetDeadJob
+	var finder *fakes.DeadJobsFinder
+	var errorWriter *fakes.ErrorWriter
+	var writer *httptest.ResponseRecorder
+	var context stack.Context
+
+	newRequest := func(path string) *http.Request {
+		request, err := http.NewRequest("GET", path, nil)
+		if err != nil {
+			panic(err)
+		}
+		return request
+	}
+
+	BeforeEach(func() {
+		finder = fakes.NewDeadJobsFinder()
+		finder.DeadJobs = []services.DeadJob{
+			{
+				ID:            3,
+				Payload:       `{"message_id":"message-123"}`,
+				RetryCount:    10,
+				LastError:     "connection refused",
+				LastAttemptAt: time.Date(2015, time.January, 20, 10, 0, 0, 0, time.UTC),
+				CreatedAt:     time.Date(2015, time.January, 20, 10, 1, 0, 0, time.UTC),
+			},
+		}
+		errorWriter = fakes.NewErrorWriter()
+		handler = handlers.NewGetDeadJob(finder, errorWriter)
+		writer = httptest.NewRecorder()
+	})
+
+	It("writes out the dead job with the given id", func() {
+		handler.ServeHTTP(writer, newRequest("/dead_jobs/3"), context)
+
+		Expect(finder.FindArgument).To(Equal(3))
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.Bytes()).To(MatchJSON(`{
+			"id": 3,
+			"payload": "{\"message_id\":\"message-123\"}",
+			"retry_count": 10,
+			"last_error": "connection refused",
+			"last_attempt_at": "2015-01-20T10:00:00Z",
+			"created_at": "2015-01-20T10:01:00Z"
+		}`))
+	})
+
+	Context("when the id is not a number", func() {
+		It("writes a RecordNotFoundError to the error writer", func() {
+			handler.ServeHTTP(writer, newRequest("/dead_jobs/banana"), context)
+
+			Expect(errorWriter.Error).To(BeAssignableToTypeOf(models.RecordNotFoundError("")))
+		})
+	})
+
+	Context("when the finder errors", func() {
+		It("delegates to the error writer", func() {
+			finder.FindError = errors.New("BOOM!")
+
+			handler.ServeHTTP(writer, newRequest("/dead_jobs/3"), context)
+
+			Expect(errorWriter.Error).To(Equal(finder.FindError))
+		})
+	})
+})
@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 
+	"github.com/cloudfoundry-incubator/notifications/postal"
 	"github.com/ryanmoran/stack"
 )
 
@@ -13,6 +14,10 @@ func NewGetInfo() GetInfo {
 }
 
 func (handler GetInfo) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte("{}"))
+	var document struct {
+		TemplateHelpersVersion int `json:"template_helpers_version"`
+	}
+	document.TemplateHelpersVersion = postal.TemplateHelpersVersion
+
+	writeJSON(w, http.StatusOK, document)
 }
@@ -18,7 +18,7 @@ var _ = Describe("GetInfo", func() {
 			handler = handlers.NewGetInfo()
 		})
 
-		It("returns a 200 response code and an empty JSON body", func() {
+		It("returns a 200 response code and the template helpers version", func() {
 			writer := httptest.NewRecorder()
 			request, err := http.NewRequest("GET", "/info", nil)
 			if err != nil {
@@ -28,7 +28,7 @@ var _ = Describe("GetInfo", func() {
 			handler.ServeHTTP(writer, request, nil)
 
 			Expect(writer.Code).To(Equal(http.StatusOK))
-			Expect(writer.Body.String()).To(Equal("{}"))
+			Expect(writer.Body.String()).To(MatchJSON(`{"template_helpers_version": 1}`))
 		})
 	})
 })
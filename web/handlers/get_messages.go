@@ -34,9 +34,15 @@ func (handler GetMessages) ServeHTTP(w http.ResponseWriter, req *http.Request, c
 	}
 
 	var document struct {
-		Status string `json:"status"`
+		Status          string `json:"status"`
+		Relay           string `json:"relay,omitempty"`
+		TemplateID      string `json:"template_id,omitempty"`
+		TemplateVersion int    `json:"template_version,omitempty"`
 	}
 	document.Status = message.Status
+	document.Relay = message.Relay
+	document.TemplateID = message.TemplateID
+	document.TemplateVersion = message.TemplateVersion
 
 	writeJSON(w, http.StatusOK, document)
 }
@@ -49,6 +49,38 @@ var _ = Describe("GetMessages", func() {
 			}`))
 		})
 
+		It("includes the relay that accepted the message", func() {
+			messageFinder.Messages[messageID] = services.Message{
+				Status: "delivered",
+				Relay:  "smtp.example.com:587",
+			}
+
+			handler.ServeHTTP(writer, request, nil)
+
+			Expect(writer.Code).To(Equal(http.StatusOK))
+			Expect(writer.Body.Bytes()).To(MatchJSON(`{
+				"status": "delivered",
+				"relay": "smtp.example.com:587"
+			}`))
+		})
+
+		It("includes the template version the message was rendered from", func() {
+			messageFinder.Messages[messageID] = services.Message{
+				Status:          "delivered",
+				TemplateID:      "some-template",
+				TemplateVersion: 3,
+			}
+
+			handler.ServeHTTP(writer, request, nil)
+
+			Expect(writer.Code).To(Equal(http.StatusOK))
+			Expect(writer.Body.Bytes()).To(MatchJSON(`{
+				"status": "delivered",
+				"template_id": "some-template",
+				"template_version": 3
+			}`))
+		})
+
 		Context("When the finder errors", func() {
 			It("Delegates to the error writer", func() {
 				findError := errors.New("The finder returns a generic error")
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+	"github.com/ryanmoran/stack"
+)
+
+type ListDeadJobs struct {
+	finder      services.DeadJobsFinderInterface
+	errorWriter ErrorWriterInterface
+}
+
+func NewListDeadJobs(finder services.DeadJobsFinderInterface, errorWriter ErrorWriterInterface) ListDeadJobs {
+	return ListDeadJobs{
+		finder:      finder,
+		errorWriter: errorWriter,
+	}
+}
+
+func (handler ListDeadJobs) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	deadJobs, err := handler.finder.List()
+	if err != nil {
+		handler.errorWriter.Write(w, err)
+		return
+	}
+
+	var document struct {
+		DeadJobs []services.DeadJob `json:"dead_jobs"`
+	}
+	document.DeadJobs = deadJobs
+
+	writeJSON(w, http.StatusOK, document)
+}
@@ -0,0 +1,77 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/web/handlers"
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ListDeadJobs", func() {
+	var handler handlers.ListDeadJobs
+	var finder *fakes.DeadJobsFinder
+	var errorWriter *fakes.ErrorWriter
+	var writer *httptest.ResponseRecorder
+	var request *http.Request
+	var context stack.Context
+
+	BeforeEach(func() {
+		finder = fakes.NewDeadJobsFinder()
+		finder.DeadJobs = []services.DeadJob{
+			{
+				ID:            3,
+				Payload:       `{"message_id":"message-123"}`,
+				RetryCount:    10,
+				LastError:     "connection refused",
+				LastAttemptAt: time.Date(2015, time.January, 20, 10, 0, 0, 0, time.UTC),
+				CreatedAt:     time.Date(2015, time.January, 20, 10, 1, 0, 0, time.UTC),
+			},
+		}
+		errorWriter = fakes.NewErrorWriter()
+		handler = handlers.NewListDeadJobs(finder, errorWriter)
+		writer = httptest.NewRecorder()
+
+		var err error
+		request, err = http.NewRequest("GET", "/dead_jobs", nil)
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	It("writes out the dead jobs from the finder", func() {
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(finder.ListWasCalled).To(BeTrue())
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.Bytes()).To(MatchJSON(`{
+			"dead_jobs": [
+				{
+					"id": 3,
+					"payload": "{\"message_id\":\"message-123\"}",
+					"retry_count": 10,
+					"last_error": "connection refused",
+					"last_attempt_at": "2015-01-20T10:00:00Z",
+					"created_at": "2015-01-20T10:01:00Z"
+				}
+			]
+		}`))
+	})
+
+	Context("when the finder errors", func() {
+		It("delegates to the error writer", func() {
+			finder.ListError = errors.New("BOOM!")
+
+			handler.ServeHTTP(writer, request, context)
+
+			Expect(errorWriter.Error).To(Equal(finder.ListError))
+		})
+	})
+})
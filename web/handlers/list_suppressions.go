@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+	"github.com/ryanmoran/stack"
+)
+
+type ListSuppressions struct {
+	finder      services.SuppressionsFinderInterface
+	errorWriter ErrorWriterInterface
+}
+
+func NewListSuppressions(finder services.SuppressionsFinderInterface, errorWriter ErrorWriterInterface) ListSuppressions {
+	return ListSuppressions{
+		finder:      finder,
+		errorWriter: errorWriter,
+	}
+}
+
+func (handler ListSuppressions) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	suppressions, err := handler.finder.List()
+	if err != nil {
+		handler.errorWriter.Write(w, err)
+		return
+	}
+
+	var document struct {
+		Suppressions []services.Suppression `json:"suppressions"`
+	}
+	document.Suppressions = suppressions
+
+	writeJSON(w, http.StatusOK, document)
+}
@@ -0,0 +1,70 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/web/handlers"
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ListSuppressions", func() {
+	var handler handlers.ListSuppressions
+	var finder *fakes.SuppressionsFinder
+	var errorWriter *fakes.ErrorWriter
+	var writer *httptest.ResponseRecorder
+	var request *http.Request
+	var context stack.Context
+
+	BeforeEach(func() {
+		finder = fakes.NewSuppressionsFinder()
+		finder.Suppressions = []services.Suppression{
+			{
+				Email:     "bounced@example.com",
+				Reason:    "RCPT failed with 550 no such user",
+				CreatedAt: time.Date(2015, time.January, 20, 10, 0, 0, 0, time.UTC),
+			},
+		}
+		errorWriter = fakes.NewErrorWriter()
+		handler = handlers.NewListSuppressions(finder, errorWriter)
+		writer = httptest.NewRecorder()
+
+		var err error
+		request, err = http.NewRequest("GET", "/suppressions", nil)
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	It("writes out the suppressions from the finder", func() {
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.Bytes()).To(MatchJSON(`{
+			"suppressions": [
+				{
+					"email": "bounced@example.com",
+					"reason": "RCPT failed with 550 no such user",
+					"created_at": "2015-01-20T10:00:00Z"
+				}
+			]
+		}`))
+	})
+
+	Context("when the finder errors", func() {
+		It("delegates to the error writer", func() {
+			finder.ListError = errors.New("BOOM!")
+
+			handler.ServeHTTP(writer, request, context)
+
+			Expect(errorWriter.Error).To(Equal(finder.ListError))
+		})
+	})
+})
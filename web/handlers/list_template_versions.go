@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+	"github.com/ryanmoran/stack"
+)
+
+type ListTemplateVersions struct {
+	lister      services.TemplateVersionListerInterface
+	errorWriter ErrorWriterInterface
+}
+
+type TemplateVersionOutput struct {
+	Version   int                    `json:"version"`
+	Author    string                 `json:"author"`
+	CreatedAt time.Time              `json:"created_at"`
+	Name      string                 `json:"name"`
+	Subject   string                 `json:"subject"`
+	Text      string                 `json:"text"`
+	HTML      string                 `json:"html"`
+	Metadata  map[string]interface{} `json:"metadata"`
+}
+
+func NewListTemplateVersions(lister services.TemplateVersionListerInterface, errorWriter ErrorWriterInterface) ListTemplateVersions {
+	return ListTemplateVersions{
+		lister:      lister,
+		errorWriter: errorWriter,
+	}
+}
+
+func (handler ListTemplateVersions) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	templateID := regexp.MustCompile(`\/templates\/(.*)\/versions`).FindStringSubmatch(req.URL.Path)[1]
+
+	versions, err := handler.lister.List(templateID)
+	if err != nil {
+		handler.errorWriter.Write(w, err)
+		return
+	}
+
+	document, err := handler.mapToJSON(versions)
+	if err != nil {
+		handler.errorWriter.Write(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, document)
+}
+
+func (handler ListTemplateVersions) mapToJSON(versions []models.TemplateVersion) (map[string][]TemplateVersionOutput, error) {
+	structure := map[string][]TemplateVersionOutput{
+		"versions": []TemplateVersionOutput{},
+	}
+
+	for _, version := range versions {
+		metadata := map[string]interface{}{}
+		if version.Metadata != "" {
+			err := json.Unmarshal([]byte(version.Metadata), &metadata)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		structure["versions"] = append(structure["versions"], TemplateVersionOutput{
+			Version:   version.Version,
+			Author:    version.Author,
+			CreatedAt: version.CreatedAt,
+			Name:      version.Name,
+			Subject:   version.Subject,
+			Text:      version.Text,
+			HTML:      version.HTML,
+			Metadata:  metadata,
+		})
+	}
+
+	return structure, nil
+}
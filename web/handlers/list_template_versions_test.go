@@ -0,0 +1,109 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/web/handlers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ListTemplateVersions", func() {
+	var handler handlers.ListTemplateVersions
+	var writer *httptest.ResponseRecorder
+	var request *http.Request
+	var lister *fakes.TemplateVersionLister
+	var errorWriter *fakes.ErrorWriter
+
+	BeforeEach(func() {
+		var err error
+
+		lister = fakes.NewTemplateVersionLister()
+		lister.Versions = []models.TemplateVersion{
+			{
+				TemplateID: "banana-template",
+				Version:    2,
+				Name:       "Banana",
+				Subject:    "Ripe {{.Subject}}",
+				Text:       "ripe",
+				HTML:       "<p>ripe</p>",
+				Metadata:   `{"color":"yellow"}`,
+				Author:     "some-user",
+				CreatedAt:  time.Date(2015, time.March, 2, 10, 0, 0, 0, time.UTC),
+			},
+			{
+				TemplateID: "banana-template",
+				Version:    1,
+				Name:       "Banana",
+				Subject:    "{{.Subject}}",
+				Text:       "green",
+				HTML:       "<p>green</p>",
+				Metadata:   "{}",
+				Author:     "some-client",
+				CreatedAt:  time.Date(2015, time.March, 1, 10, 0, 0, 0, time.UTC),
+			},
+		}
+
+		errorWriter = fakes.NewErrorWriter()
+		handler = handlers.NewListTemplateVersions(lister, errorWriter)
+
+		writer = httptest.NewRecorder()
+		request, err = http.NewRequest("GET", "/templates/banana-template/versions", nil)
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	It("returns the versions of the template, newest first", func() {
+		handler.ServeHTTP(writer, request, nil)
+
+		Expect(lister.ListArgument).To(Equal("banana-template"))
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body).To(MatchJSON(`{
+			"versions": [
+				{
+					"version": 2,
+					"author": "some-user",
+					"created_at": "2015-03-02T10:00:00Z",
+					"name": "Banana",
+					"subject": "Ripe {{.Subject}}",
+					"text": "ripe",
+					"html": "<p>ripe</p>",
+					"metadata": {"color": "yellow"}
+				},
+				{
+					"version": 1,
+					"author": "some-client",
+					"created_at": "2015-03-01T10:00:00Z",
+					"name": "Banana",
+					"subject": "{{.Subject}}",
+					"text": "green",
+					"html": "<p>green</p>",
+					"metadata": {}
+				}
+			]
+		}`))
+	})
+
+	It("writes errors from the lister to the error writer", func() {
+		lister.ListError = models.NewRecordNotFoundError("Template %q could not be found", "banana-template")
+
+		handler.ServeHTTP(writer, request, nil)
+
+		Expect(errorWriter.Error).To(Equal(lister.ListError))
+	})
+
+	It("writes other errors to the error writer", func() {
+		lister.ListError = errors.New("boom")
+
+		handler.ServeHTTP(writer, request, nil)
+
+		Expect(errorWriter.Error).To(Equal(errors.New("boom")))
+	})
+})
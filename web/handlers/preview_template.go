@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/cloudfoundry-incubator/notifications/postal"
+	"github.com/cloudfoundry-incubator/notifications/web/params"
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+	"github.com/ryanmoran/stack"
+)
+
+var previewTemplatePath = regexp.MustCompile(`\/templates\/(.*)\/preview`)
+
+type PreviewTemplate struct {
+	finder      services.TemplateFinderInterface
+	previewer   postal.TemplatePreviewerInterface
+	errorWriter ErrorWriterInterface
+}
+
+type TemplatePreview struct {
+	TemplateID      string `json:"template_id,omitempty"`
+	TemplateVersion int    `json:"template_version,omitempty"`
+	Subject         string `json:"subject"`
+	Text            string `json:"text"`
+	HTML            string `json:"html"`
+	Source          string `json:"source"`
+}
+
+func NewPreviewTemplate(finder services.TemplateFinderInterface, previewer postal.TemplatePreviewerInterface, errorWriter ErrorWriterInterface) PreviewTemplate {
+	return PreviewTemplate{
+		finder:      finder,
+		previewer:   previewer,
+		errorWriter: errorWriter,
+	}
+}
+
+func (handler PreviewTemplate) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	preview, err := params.NewPreview(req.Body)
+	if err != nil {
+		handler.errorWriter.Write(w, err)
+		return
+	}
+
+	templates, err := handler.templates(req.URL.Path, preview)
+	if err != nil {
+		handler.errorWriter.Write(w, err)
+		return
+	}
+
+	message, err := handler.previewer.Preview(preview.ToDelivery(), templates)
+	if err != nil {
+		handler.errorWriter.Write(w, err)
+		return
+	}
+
+	document := TemplatePreview{
+		TemplateID:      templates.ID,
+		TemplateVersion: templates.Version,
+		Subject:         message.Subject,
+		Source:          message.Data(),
+	}
+	for _, part := range message.Body {
+		switch part.ContentType {
+		case "text/plain":
+			document.Text = part.Content
+		case "text/html":
+			document.HTML = part.Content
+		}
+	}
+
+	writeJSON(w, http.StatusOK, document)
+}
+
+// templates returns the stored template for /templates/{template_id}/preview,
+// and the template sent in the request for /templates/preview.
+func (handler PreviewTemplate) templates(path string, preview params.Preview) (postal.Templates, error) {
+	matches := previewTemplatePath.FindStringSubmatch(path)
+	if matches == nil {
+		if preview.Template == nil {
+			return postal.Templates{}, params.ValidationError([]string{`"template" is a required field`})
+		}
+
+		return postal.Templates{
+			Subject: preview.Template.Subject,
+			Text:    preview.Template.Text,
+			HTML:    preview.Template.HTML,
+		}, nil
+	}
+
+	template, err := handler.finder.FindByID(matches[1])
+	if err != nil {
+		return postal.Templates{}, err
+	}
+
+	return postal.Templates{
+		ID:      template.ID,
+		Version: template.Version,
+		Name:    template.Name,
+		Subject: template.Subject,
+		Text:    template.Text,
+		HTML:    template.HTML,
+	}, nil
+}
@@ -0,0 +1,164 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/cf"
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/postal"
+	"github.com/cloudfoundry-incubator/notifications/web/handlers"
+	"github.com/cloudfoundry-incubator/notifications/web/params"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PreviewTemplate", func() {
+	var handler handlers.PreviewTemplate
+	var writer *httptest.ResponseRecorder
+	var finder *fakes.TemplateFinder
+	var previewer *fakes.TemplatePreviewer
+	var errorWriter *fakes.ErrorWriter
+	var message mail.Message
+
+	newRequest := func(path, body string) *http.Request {
+		request, err := http.NewRequest("POST", path, bytes.NewBufferString(body))
+		if err != nil {
+			panic(err)
+		}
+
+		return request
+	}
+
+	BeforeEach(func() {
+		finder = fakes.NewTemplateFinder()
+		finder.Templates["banana-template"] = models.Template{
+			ID:      "banana-template",
+			Version: 3,
+			Name:    "Banana Template",
+			Subject: "Bananas: {{.Subject}}",
+			Text:    "{{.Text}}",
+			HTML:    "<p>{{.HTML}}</p>",
+		}
+
+		message = mail.Message{
+			From:    "no-reply@example.com",
+			To:      "user@example.com",
+			Subject: "Bananas: Ripe",
+			Body: []mail.Part{
+				{ContentType: "text/plain", Content: "They are ripe"},
+				{ContentType: "text/html", Content: "<p><b>They are ripe</b></p>"},
+			},
+		}
+		previewer = fakes.NewTemplatePreviewer()
+		previewer.Message = message
+
+		errorWriter = fakes.NewErrorWriter()
+		writer = httptest.NewRecorder()
+		handler = handlers.NewPreviewTemplate(finder, previewer, errorWriter)
+	})
+
+	It("renders a stored template for the sample notification", func() {
+		request := newRequest("/templates/banana-template/preview", `{
+			"to": "user@example.com",
+			"user_guid": "user-123",
+			"client_id": "banana-client",
+			"subject": "Ripe",
+			"text": "They are ripe",
+			"html": "<b>They are ripe</b>",
+			"space": {"guid": "space-123", "name": "development"},
+			"organization": {"guid": "org-123", "name": "banana-republic"},
+			"endorsement": "You belong to the {{.Space}} space."
+		}`)
+
+		handler.ServeHTTP(writer, request, nil)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(finder.TemplateID).To(Equal("banana-template"))
+		Expect(previewer.Templates).To(Equal(postal.Templates{
+			ID:      "banana-template",
+			Version: 3,
+			Name:    "Banana Template",
+			Subject: "Bananas: {{.Subject}}",
+			Text:    "{{.Text}}",
+			HTML:    "<p>{{.HTML}}</p>",
+		}))
+
+		delivery := previewer.Delivery
+		Expect(delivery.Email).To(Equal("user@example.com"))
+		Expect(delivery.UserGUID).To(Equal("user-123"))
+		Expect(delivery.ClientID).To(Equal("banana-client"))
+		Expect(delivery.Space).To(Equal(cf.CloudControllerSpace{GUID: "space-123", Name: "development", OrganizationGUID: "org-123"}))
+		Expect(delivery.Organization).To(Equal(cf.CloudControllerOrganization{GUID: "org-123", Name: "banana-republic"}))
+		Expect(delivery.Options.Subject).To(Equal("Ripe"))
+		Expect(delivery.Options.Endorsement).To(Equal("You belong to the {{.Space}} space."))
+
+		var document handlers.TemplatePreview
+		err := json.Unmarshal(writer.Body.Bytes(), &document)
+		if err != nil {
+			panic(err)
+		}
+
+		Expect(document.TemplateID).To(Equal("banana-template"))
+		Expect(document.TemplateVersion).To(Equal(3))
+		Expect(document.Subject).To(Equal("Bananas: Ripe"))
+		Expect(document.Text).To(Equal("They are ripe"))
+		Expect(document.HTML).To(Equal("<p><b>They are ripe</b></p>"))
+		Expect(document.Source).To(ContainSubstring("Subject: Bananas: Ripe\r\n"))
+		Expect(document.Source).To(ContainSubstring("Content-Type: multipart/alternative;"))
+	})
+
+	It("renders the template sent with the request", func() {
+		request := newRequest("/templates/preview", `{
+			"text": "They are ripe",
+			"template": {"subject": "Preview: {{.Subject}}", "text": "Preview {{.Text}}", "html": "<p>{{.HTML}}</p>"}
+		}`)
+
+		handler.ServeHTTP(writer, request, nil)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(finder.TemplateID).To(BeEmpty())
+		Expect(previewer.Templates).To(Equal(postal.Templates{
+			Subject: "Preview: {{.Subject}}",
+			Text:    "Preview {{.Text}}",
+			HTML:    "<p>{{.HTML}}</p>",
+		}))
+		Expect(writer.Body.String()).NotTo(ContainSubstring("template_id"))
+	})
+
+	Context("when an error occurs", func() {
+		It("requires a template when previewing an unsaved template", func() {
+			handler.ServeHTTP(writer, newRequest("/templates/preview", `{"text": "They are ripe"}`), nil)
+
+			Expect(errorWriter.Error).To(Equal(params.ValidationError([]string{`"template" is a required field`})))
+		})
+
+		It("writes parse errors to the error writer", func() {
+			handler.ServeHTTP(writer, newRequest("/templates/banana-template/preview", `{"text": `), nil)
+
+			Expect(errorWriter.Error).To(BeAssignableToTypeOf(params.ParseError{}))
+		})
+
+		It("writes errors finding the template to the error writer", func() {
+			finder.FindError = errors.New("no such template")
+
+			handler.ServeHTTP(writer, newRequest("/templates/banana-template/preview", `{"text": "They are ripe"}`), nil)
+
+			Expect(errorWriter.Error).To(MatchError("no such template"))
+		})
+
+		It("writes rendering errors to the error writer", func() {
+			previewer.PreviewError = postal.TemplateRenderError("template: compileTemplate:1:2: can't evaluate field Nope")
+
+			handler.ServeHTTP(writer, newRequest("/templates/banana-template/preview", `{"text": "They are ripe"}`), nil)
+
+			Expect(errorWriter.Error).To(Equal(postal.TemplateRenderError("template: compileTemplate:1:2: can't evaluate field Nope")))
+		})
+	})
+})
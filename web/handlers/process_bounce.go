@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+	"github.com/ryanmoran/stack"
+)
+
+type ProcessBounce struct {
+	processor   services.BounceProcessorInterface
+	errorWriter ErrorWriterInterface
+}
+
+func NewProcessBounce(processor services.BounceProcessorInterface, errorWriter ErrorWriterInterface) ProcessBounce {
+	return ProcessBounce{
+		processor:   processor,
+		errorWriter: errorWriter,
+	}
+}
+
+func (handler ProcessBounce) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	dsn, err := mail.ParseDSN(req.Body)
+	if err != nil {
+		handler.errorWriter.Write(w, err)
+		return
+	}
+
+	err = handler.processor.Process(dsn)
+	if err != nil {
+		handler.errorWriter.Write(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,97 @@
+package handlers_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/cloudfoundry-incubator/notifications/web/handlers"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProcessBounce", func() {
+	var handler handlers.ProcessBounce
+	var processor *fakes.BounceProcessor
+	var errorWriter *fakes.ErrorWriter
+	var writer *httptest.ResponseRecorder
+	var request *http.Request
+	var context stack.Context
+
+	BeforeEach(func() {
+		var err error
+
+		processor = fakes.NewBounceProcessor()
+		errorWriter = fakes.NewErrorWriter()
+		handler = handlers.NewProcessBounce(processor, errorWriter)
+		writer = httptest.NewRecorder()
+
+		body := strings.Join([]string{
+			`Content-Type: multipart/report; report-type=delivery-status; boundary="XYZ"`,
+			``,
+			`--XYZ`,
+			`Content-Type: message/delivery-status`,
+			``,
+			`Reporting-MTA: dns; relay.example.com`,
+			``,
+			`Final-Recipient: rfc822; gone@example.com`,
+			`Action: failed`,
+			`Status: 5.1.1`,
+			``,
+			`--XYZ`,
+			`Content-Type: text/rfc822-headers`,
+			``,
+			`X-CF-Notification-ID: message-123`,
+			``,
+			`--XYZ--`,
+			``,
+		}, "\r\n")
+
+		request, err = http.NewRequest("POST", "/bounces", strings.NewReader(body))
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	It("processes the parsed bounce", func() {
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusNoContent))
+		Expect(processor.ProcessArgument).To(Equal(mail.DeliveryStatusNotification{
+			NotificationID: "message-123",
+			Recipients: []mail.DeliveryStatus{
+				{
+					FinalRecipient: "gone@example.com",
+					Action:         "failed",
+					Status:         "5.1.1",
+				},
+			},
+		}))
+	})
+
+	Context("when the bounce cannot be parsed", func() {
+		It("delegates to the error writer", func() {
+			request.Body = ioutil.NopCloser(strings.NewReader("Subject: hello\r\n\r\nnot a bounce"))
+
+			handler.ServeHTTP(writer, request, context)
+
+			Expect(errorWriter.Error).To(BeAssignableToTypeOf(mail.DSNParseError("")))
+		})
+	})
+
+	Context("when the processor errors", func() {
+		It("delegates to the error writer", func() {
+			processor.ProcessError = errors.New("BOOM!")
+
+			handler.ServeHTTP(writer, request, context)
+
+			Expect(errorWriter.Error).To(Equal(processor.ProcessError))
+		})
+	})
+})
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+	"github.com/ryanmoran/stack"
+)
+
+type PurgeDeadJobs struct {
+	purger      services.DeadJobPurgerInterface
+	errorWriter ErrorWriterInterface
+}
+
+func NewPurgeDeadJobs(purger services.DeadJobPurgerInterface, errorWriter ErrorWriterInterface) PurgeDeadJobs {
+	return PurgeDeadJobs{
+		purger:      purger,
+		errorWriter: errorWriter,
+	}
+}
+
+func (handler PurgeDeadJobs) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	_, err := handler.purger.PurgeAll()
+	if err != nil {
+		handler.errorWriter.Write(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
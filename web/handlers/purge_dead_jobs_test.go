@@ -0,0 +1,53 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/web/handlers"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PurgeDeadJobs", func() {
+	var handler handlers.PurgeDeadJobs
+	var purger *fakes.DeadJobPurger
+	var errorWriter *fakes.ErrorWriter
+	var writer *httptest.ResponseRecorder
+	var request *http.Request
+	var context stack.Context
+
+	BeforeEach(func() {
+		purger = fakes.NewDeadJobPurger()
+		errorWriter = fakes.NewErrorWriter()
+		handler = handlers.NewPurgeDeadJobs(purger, errorWriter)
+		writer = httptest.NewRecorder()
+
+		var err error
+		request, err = http.NewRequest("DELETE", "/dead_jobs", nil)
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	It("purges all of the dead jobs", func() {
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(purger.PurgeAllWasCalled).To(BeTrue())
+		Expect(writer.Code).To(Equal(http.StatusNoContent))
+	})
+
+	Context("when the purger errors", func() {
+		It("delegates to the error writer", func() {
+			purger.PurgeAllError = errors.New("BOOM!")
+
+			handler.ServeHTTP(writer, request, context)
+
+			Expect(errorWriter.Error).To(Equal(purger.PurgeAllError))
+		})
+	})
+})
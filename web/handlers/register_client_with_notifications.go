@@ -46,12 +46,16 @@ func (handler RegisterClientWithNotifications) Execute(w http.ResponseWriter, re
 
 	generatedKinds := []models.Kind{}
 	for _, notification := range parameters.Notifications {
-		generatedKinds = append(generatedKinds, models.Kind{
+		kind := models.Kind{
 			ID:          notification.ID,
 			Description: notification.Description,
 			Critical:    notification.Critical,
 			TemplateID:  models.DoNotSetTemplateID,
-		})
+		}
+		if notification.RetryPolicy != nil {
+			kind.RetryPolicy = *notification.RetryPolicy
+		}
+		generatedKinds = append(generatedKinds, kind)
 	}
 
 	token := context.Get("token").(*jwt.Token)
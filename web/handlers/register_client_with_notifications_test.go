@@ -122,6 +122,39 @@ var _ = Describe("RegisterClientWithNotifications", func() {
 			Expect(conn.RollbackWasCalled).To(BeFalse())
 		})
 
+		It("passes the retry policy of each notification to Register", func() {
+			requestBody, err := json.Marshal(map[string]interface{}{
+				"source_name": "Raptor Containment Unit",
+				"notifications": map[string]interface{}{
+					"feeding_time": map[string]interface{}{
+						"description": "Feeding Time",
+						"retry_policy": map[string]interface{}{
+							"max_attempts":       3,
+							"base_delay_seconds": 30,
+						},
+					},
+				},
+			})
+			if err != nil {
+				panic(err)
+			}
+			request.Body = ioutil.NopCloser(bytes.NewBuffer(requestBody))
+
+			handler.Execute(writer, request, conn, context)
+
+			Expect(registrar.RegisterArguments[2]).To(ConsistOf([]models.Kind{
+				{
+					ID:          "feeding_time",
+					Description: "Feeding Time",
+					ClientID:    client.ID,
+					RetryPolicy: models.RetryPolicy{
+						MaxAttempts:      3,
+						BaseDelaySeconds: 30,
+					},
+				},
+			}))
+		})
+
 		It("does not prune kinds if they are not in the request", func() {
 			requestBody, err := json.Marshal(map[string]interface{}{
 				"source_name": "Raptor Containment Unit",
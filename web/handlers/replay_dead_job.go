@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+	"github.com/ryanmoran/stack"
+)
+
+type ReplayDeadJob struct {
+	replayer    services.DeadJobReplayerInterface
+	errorWriter ErrorWriterInterface
+}
+
+func NewReplayDeadJob(replayer services.DeadJobReplayerInterface, errorWriter ErrorWriterInterface) ReplayDeadJob {
+	return ReplayDeadJob{
+		replayer:    replayer,
+		errorWriter: errorWriter,
+	}
+}
+
+func (handler ReplayDeadJob) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	deadJobID, err := deadJobIDFromPath(req.URL.Path)
+	if err != nil {
+		handler.errorWriter.Write(w, err)
+		return
+	}
+
+	err = handler.replayer.Replay(deadJobID)
+	if err != nil {
+		handler.errorWriter.Write(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,63 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/web/handlers"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReplayDeadJob", func() {
+	var handler handlers.ReplayDeadJob
+	var replayer *fakes.DeadJobReplayer
+	var errorWriter *fakes.ErrorWriter
+	var writer *httptest.ResponseRecorder
+	var context stack.Context
+
+	newRequest := func(path string) *http.Request {
+		request, err := http.NewRequest("POST", path, nil)
+		if err != nil {
+			panic(err)
+		}
+		return request
+	}
+
+	BeforeEach(func() {
+		replayer = fakes.NewDeadJobReplayer()
+		errorWriter = fakes.NewErrorWriter()
+		handler = handlers.NewReplayDeadJob(replayer, errorWriter)
+		writer = httptest.NewRecorder()
+	})
+
+	It("replays the dead job with the given id", func() {
+		handler.ServeHTTP(writer, newRequest("/dead_jobs/3/replay"), context)
+
+		Expect(replayer.ReplayArgument).To(Equal(3))
+		Expect(writer.Code).To(Equal(http.StatusNoContent))
+	})
+
+	Context("when the id is not a number", func() {
+		It("writes a RecordNotFoundError to the error writer", func() {
+			handler.ServeHTTP(writer, newRequest("/dead_jobs/banana/replay"), context)
+
+			Expect(errorWriter.Error).To(BeAssignableToTypeOf(models.RecordNotFoundError("")))
+		})
+	})
+
+	Context("when the replayer errors", func() {
+		It("delegates to the error writer", func() {
+			replayer.ReplayError = errors.New("BOOM!")
+
+			handler.ServeHTTP(writer, newRequest("/dead_jobs/3/replay"), context)
+
+			Expect(errorWriter.Error).To(Equal(replayer.ReplayError))
+		})
+	})
+})
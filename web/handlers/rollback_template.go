@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+	"github.com/ryanmoran/stack"
+)
+
+type RollbackTemplate struct {
+	rollbacker  services.TemplateRollbackerInterface
+	errorWriter ErrorWriterInterface
+}
+
+func NewRollbackTemplate(rollbacker services.TemplateRollbackerInterface, errorWriter ErrorWriterInterface) RollbackTemplate {
+	return RollbackTemplate{
+		rollbacker:  rollbacker,
+		errorWriter: errorWriter,
+	}
+}
+
+func (handler RollbackTemplate) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	matches := regexp.MustCompile(`\/templates\/(.*)\/versions\/(.*)\/rollback`).FindStringSubmatch(req.URL.Path)
+	templateID := matches[1]
+
+	version, err := strconv.Atoi(matches[2])
+	if err != nil {
+		handler.errorWriter.Write(w, models.NewRecordNotFoundError("Version %q of template %q could not be found", matches[2], templateID))
+		return
+	}
+
+	err = handler.rollbacker.Rollback(templateID, version, templateAuthor(context))
+	if err != nil {
+		handler.errorWriter.Write(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,69 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/web/handlers"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RollbackTemplate", func() {
+	var handler handlers.RollbackTemplate
+	var writer *httptest.ResponseRecorder
+	var context stack.Context
+	var rollbacker *fakes.TemplateRollbacker
+	var errorWriter *fakes.ErrorWriter
+
+	serve := func(path string) {
+		request, err := http.NewRequest("POST", path, nil)
+		if err != nil {
+			panic(err)
+		}
+
+		handler.ServeHTTP(writer, request, context)
+	}
+
+	BeforeEach(func() {
+		rollbacker = fakes.NewTemplateRollbacker()
+		errorWriter = fakes.NewErrorWriter()
+		handler = handlers.NewRollbackTemplate(rollbacker, errorWriter)
+		writer = httptest.NewRecorder()
+
+		context = stack.NewContext()
+		context.Set("token", &jwt.Token{Claims: map[string]interface{}{
+			"client_id": "banana-client",
+			"user_id":   "banana-user",
+		}})
+	})
+
+	It("rolls the template back to the version on behalf of the token's user", func() {
+		serve("/templates/banana-template/versions/2/rollback")
+
+		Expect(writer.Code).To(Equal(http.StatusNoContent))
+		Expect(rollbacker.RollbackArgumentID).To(Equal("banana-template"))
+		Expect(rollbacker.RollbackArgumentVersion).To(Equal(2))
+		Expect(rollbacker.RollbackAuthor).To(Equal("banana-user"))
+	})
+
+	It("writes a not found error when the version is not a number", func() {
+		serve("/templates/banana-template/versions/latest/rollback")
+
+		Expect(errorWriter.Error).To(BeAssignableToTypeOf(models.RecordNotFoundError("")))
+		Expect(rollbacker.RollbackArgumentID).To(BeEmpty())
+	})
+
+	It("writes errors from the rollbacker to the error writer", func() {
+		rollbacker.RollbackError = models.NewRecordNotFoundError("Version %d of template %q could not be found", 9, "banana-template")
+
+		serve("/templates/banana-template/versions/9/rollback")
+
+		Expect(errorWriter.Error).To(Equal(rollbacker.RollbackError))
+	})
+})
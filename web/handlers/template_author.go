@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"github.com/dgrijalva/jwt-go"
+	"github.com/ryanmoran/stack"
+)
+
+// templateAuthor names who made a change to a template: the user on whose
+// behalf the token was issued, or the client when there is no user.
+func templateAuthor(context stack.Context) string {
+	if context == nil {
+		return ""
+	}
+
+	token, ok := context.Get("token").(*jwt.Token)
+	if !ok || token == nil {
+		return ""
+	}
+
+	if userID, ok := token.Claims["user_id"].(string); ok && userID != "" {
+		return userID
+	}
+
+	clientID, _ := token.Claims["client_id"].(string)
+	return clientID
+}
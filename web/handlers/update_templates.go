@@ -30,7 +30,7 @@ func (handler UpdateTemplates) ServeHTTP(w http.ResponseWriter, req *http.Reques
 		return
 	}
 
-	err = handler.updater.Update(templateID, templateParams.ToModel())
+	err = handler.updater.Update(templateID, templateParams.ToModel(), templateAuthor(context))
 	if err != nil {
 		handler.ErrorWriter.Write(w, err)
 		return
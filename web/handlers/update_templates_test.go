@@ -9,6 +9,7 @@ import (
 	"github.com/cloudfoundry-incubator/notifications/models"
 	"github.com/cloudfoundry-incubator/notifications/web/handlers"
 	"github.com/cloudfoundry-incubator/notifications/web/params"
+	"github.com/dgrijalva/jwt-go"
 	"github.com/ryanmoran/stack"
 
 	. "github.com/onsi/ginkgo"
@@ -50,6 +51,16 @@ var _ = Describe("UpdateTemplates", func() {
 			}))
 		})
 
+		It("attributes the new version to the client that made the change", func() {
+			context = stack.NewContext()
+			context.Set("token", &jwt.Token{Claims: map[string]interface{}{
+				"client_id": "gobble-client",
+			}})
+
+			handler.ServeHTTP(writer, request, context)
+			Expect(updater.UpdateAuthor).To(Equal("gobble-client"))
+		})
+
 		It("can update a template without a subject field", func() {
 			body := []byte(`{"name": "my template name", "html": "<p>gobble</p>", "text": "my awesome text"}`)
 			request, err = http.NewRequest("PUT", "/templates/a-template-id.", bytes.NewBuffer(body))
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+
+	"github.com/cloudfoundry-incubator/notifications/models"
 )
 
 type ClientRegistration struct {
@@ -14,8 +16,9 @@ type ClientRegistration struct {
 
 type NotificationStruct struct {
 	ID          string
-	Description string `json:"description"`
-	Critical    bool   `json:"critical"`
+	Description string              `json:"description"`
+	Critical    bool                `json:"critical"`
+	RetryPolicy *models.RetryPolicy `json:"retry_policy"`
 }
 
 func NewClientRegistration(body io.Reader) (ClientRegistration, error) {
@@ -65,6 +68,11 @@ func strictValidateJSON(bytes []byte) error {
 				for propertyName, _ := range notificationMap {
 					if propertyName == "description" || propertyName == "critical" {
 						continue
+					} else if propertyName == "retry_policy" {
+						err := validateRetryPolicyJSON(notificationMap[propertyName])
+						if err != nil {
+							return err
+						}
 					} else {
 						return SchemaError(fmt.Sprintf(`"%+v" is not a valid property`, propertyName))
 					}
@@ -77,6 +85,27 @@ func strictValidateJSON(bytes []byte) error {
 	return nil
 }
 
+func validateRetryPolicyJSON(data interface{}) error {
+	if data == nil {
+		return nil
+	}
+
+	policyMap, ok := data.(map[string]interface{})
+	if !ok {
+		return SchemaError(`"retry_policy" must be an object`)
+	}
+
+	for propertyName, _ := range policyMap {
+		switch propertyName {
+		case "max_attempts", "base_delay_seconds", "max_delay_seconds", "jitter", "give_up_after_seconds":
+			continue
+		default:
+			return SchemaError(fmt.Sprintf(`"%+v" is not a valid retry policy property`, propertyName))
+		}
+	}
+	return nil
+}
+
 func (clientRegistration ClientRegistration) Validate() error {
 	errors := ValidationError{}
 	if clientRegistration.SourceName == "" {
@@ -93,6 +122,9 @@ func (clientRegistration ClientRegistration) Validate() error {
 		if value.Description == "" {
 			errors = append(errors, fmt.Sprintf(`notification "%+v" is missing required field "Description"`, id))
 		}
+		if value.RetryPolicy != nil {
+			errors = append(errors, validateRetryPolicy(id, *value.RetryPolicy)...)
+		}
 	}
 
 	if len(errors) > 0 {
@@ -100,3 +132,28 @@ func (clientRegistration ClientRegistration) Validate() error {
 	}
 	return nil
 }
+
+func validateRetryPolicy(id string, policy models.RetryPolicy) []string {
+	var errors []string
+
+	if policy.MaxAttempts < 0 {
+		errors = append(errors, fmt.Sprintf(`notification "%+v" retry policy "max_attempts" must not be negative`, id))
+	}
+	if policy.BaseDelaySeconds < 0 {
+		errors = append(errors, fmt.Sprintf(`notification "%+v" retry policy "base_delay_seconds" must not be negative`, id))
+	}
+	if policy.MaxDelaySeconds < 0 {
+		errors = append(errors, fmt.Sprintf(`notification "%+v" retry policy "max_delay_seconds" must not be negative`, id))
+	}
+	if policy.GiveUpAfterSeconds < 0 {
+		errors = append(errors, fmt.Sprintf(`notification "%+v" retry policy "give_up_after_seconds" must not be negative`, id))
+	}
+	if policy.Jitter < 0 || policy.Jitter > 1 {
+		errors = append(errors, fmt.Sprintf(`notification "%+v" retry policy "jitter" must be between 0 and 1`, id))
+	}
+	if policy.BaseDelaySeconds > 0 && policy.MaxDelaySeconds > 0 && policy.MaxDelaySeconds < policy.BaseDelaySeconds {
+		errors = append(errors, fmt.Sprintf(`notification "%+v" retry policy "max_delay_seconds" must not be less than "base_delay_seconds"`, id))
+	}
+
+	return errors
+}
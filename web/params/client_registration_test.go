@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"strings"
 
+	"github.com/cloudfoundry-incubator/notifications/models"
 	"github.com/cloudfoundry-incubator/notifications/web/params"
 
 	. "github.com/onsi/ginkgo"
@@ -49,6 +50,32 @@ var _ = Describe("ClientRegistration", func() {
 			}))
 		})
 
+		It("constructs the retry policy of a notification", func() {
+			someJson := `{ "source_name" : "Raptor", "notifications": { "perimeter_breach": {
+				"description": "Perimeter Breach",
+				"retry_policy": {
+					"max_attempts": 5,
+					"base_delay_seconds": 30,
+					"max_delay_seconds": 600,
+					"jitter": 0.25,
+					"give_up_after_seconds": 3600
+				}
+			} } }`
+
+			parameters, err := params.NewClientRegistration(strings.NewReader(someJson))
+			if err != nil {
+				panic(err)
+			}
+
+			Expect(parameters.Notifications["perimeter_breach"].RetryPolicy).To(Equal(&models.RetryPolicy{
+				MaxAttempts:        5,
+				BaseDelaySeconds:   30,
+				MaxDelaySeconds:    600,
+				Jitter:             0.25,
+				GiveUpAfterSeconds: 3600,
+			}))
+		})
+
 		Context("error cases", func() {
 			It("returns an error when the parameters are invalid JSON", func() {
 				_, err := params.NewClientRegistration(strings.NewReader("this is not valid JSON"))
@@ -72,6 +99,12 @@ var _ = Describe("ClientRegistration", func() {
 					_, err := params.NewClientRegistration(strings.NewReader(someJson))
 					Expect(err).To(BeAssignableToTypeOf(params.NewSchemaError("")))
 				})
+
+				It("returns an error for invalid retry policy keys", func() {
+					someJson := `{ "source_name" : "Raptor", "notifications": { "some_id": {"description" : "ok", "retry_policy" : { "forever": true } } } }`
+					_, err := params.NewClientRegistration(strings.NewReader(someJson))
+					Expect(err).To(BeAssignableToTypeOf(params.NewSchemaError("")))
+				})
 			})
 
 			Context("when the JSON contains null values", func() {
@@ -122,5 +155,31 @@ var _ = Describe("ClientRegistration", func() {
 			Expect(err).To(ContainElement(`notification "perimeter_breach" is missing required field "Description"`))
 		})
 
+		It("returns an error if the retry policy of a notification is out of range", func() {
+			cr := params.ClientRegistration{
+				SourceName: "jurassic_park",
+				Notifications: map[string](*params.NotificationStruct){
+					"perimeter_breach": {
+						ID:          "perimeter_breach",
+						Description: "Perimeter Breach",
+						RetryPolicy: &models.RetryPolicy{
+							MaxAttempts:        -1,
+							BaseDelaySeconds:   60,
+							MaxDelaySeconds:    30,
+							Jitter:             1.5,
+							GiveUpAfterSeconds: -10,
+						},
+					},
+				},
+			}
+			err := cr.Validate()
+
+			Expect(err).To(BeAssignableToTypeOf(params.ValidationError{}))
+			Expect(err).To(ContainElement(`notification "perimeter_breach" retry policy "max_attempts" must not be negative`))
+			Expect(err).To(ContainElement(`notification "perimeter_breach" retry policy "give_up_after_seconds" must not be negative`))
+			Expect(err).To(ContainElement(`notification "perimeter_breach" retry policy "jitter" must be between 0 and 1`))
+			Expect(err).To(ContainElement(`notification "perimeter_breach" retry policy "max_delay_seconds" must not be less than "base_delay_seconds"`))
+		})
+
 	})
 })
@@ -2,18 +2,27 @@ package params
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"io"
+	"mime"
+	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/cloudfoundry-incubator/notifications/mail"
 	"github.com/cloudfoundry-incubator/notifications/models"
 	"github.com/cloudfoundry-incubator/notifications/postal"
 )
 
 const InvalidEmail = "<>InvalidEmail<>"
 
+// MaxAttachmentsSize is the most attachment content, once decoded, that a
+// single notification may carry.
+const MaxAttachmentsSize = 10 * 1024 * 1024
+
 var validOrganizationRoles = []string{"OrgManager", "OrgAuditor", "BillingManager"}
 
 type Notify struct {
@@ -26,8 +35,19 @@ type Notify struct {
 	KindDescription   string
 	SourceDescription string
 	Errors            []string
-	To                string `json:"to"`
-	Role              string `json:"role"`
+	To                string                 `json:"to"`
+	Role              string                 `json:"role"`
+	Priority          int                    `json:"priority"`
+	SendAt            string                 `json:"send_at"`
+	Attachments       []Attachment           `json:"attachments"`
+	Data              map[string]interface{} `json:"data"`
+}
+
+type Attachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Content     string `json:"content"`
+	ContentID   string `json:"content_id"`
 }
 
 func NewNotify(body io.Reader) (Notify, error) {
@@ -89,7 +109,49 @@ func (notify *Notify) ToOptions(client models.Client, kind models.Kind) postal.O
 		KindID:            notify.KindID,
 		To:                notify.To,
 		Role:              notify.Role,
+		Critical:          kind.Critical,
+		RetryPolicy:       kind.RetryPolicy,
+		Priority:          notify.Priority,
+		SendAt:            notify.sendAt(),
+		Attachments:       notify.attachments(),
+		Data:              notify.Data,
+	}
+}
+
+func (notify *Notify) attachments() []mail.Attachment {
+	var attachments []mail.Attachment
+	for _, attachment := range notify.Attachments {
+		content, err := base64.StdEncoding.DecodeString(attachment.Content)
+		if err != nil {
+			continue
+		}
+
+		contentType := attachment.ContentType
+		if contentType == "" {
+			contentType = mime.TypeByExtension(filepath.Ext(attachment.Filename))
+		}
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		attachments = append(attachments, mail.Attachment{
+			Filename:    attachment.Filename,
+			ContentType: contentType,
+			Content:     content,
+			ContentID:   attachment.ContentID,
+		})
 	}
+
+	return attachments
+}
+
+func (notify *Notify) sendAt() time.Time {
+	sendAt, err := time.Parse(time.RFC3339, notify.SendAt)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return sendAt
 }
 
 func (notify *Notify) extractHTML() error {
@@ -3,7 +3,9 @@ package params_test
 import (
 	"io"
 	"strings"
+	"time"
 
+	"github.com/cloudfoundry-incubator/notifications/mail"
 	"github.com/cloudfoundry-incubator/notifications/models"
 	"github.com/cloudfoundry-incubator/notifications/postal"
 	"github.com/cloudfoundry-incubator/notifications/web/params"
@@ -351,7 +353,10 @@ var _ = Describe("Notify", func() {
                 "subject": "Summary of contents",
                 "text": "Contents of the email message",
                 "html": "<div>Some HTML</div>",
-                "role": "OrgManager"
+                "role": "OrgManager",
+                "priority": 7,
+                "send_at": "2015-02-03T10:30:00Z",
+                "data": {"AppName": "my-app", "Crashes": 3}
             }`)
 
 			parameters, err := params.NewNotify(body)
@@ -367,6 +372,8 @@ var _ = Describe("Notify", func() {
 				ID:          "test_email",
 				ClientID:    "client-id",
 				Description: "Descriptive Kind Name",
+				Critical:    true,
+				RetryPolicy: models.RetryPolicy{MaxAttempts: 3},
 			}
 			options := parameters.ToOptions(client, kind)
 			Expect(options).To(Equal(postal.Options{
@@ -378,6 +385,48 @@ var _ = Describe("Notify", func() {
 				Text:              "Contents of the email message",
 				HTML:              postal.HTML{BodyAttributes: "", BodyContent: "<div>Some HTML</div>"},
 				Role:              "OrgManager",
+				Critical:          true,
+				Priority:          7,
+				SendAt:            time.Date(2015, time.February, 3, 10, 30, 0, 0, time.UTC),
+				RetryPolicy:       models.RetryPolicy{MaxAttempts: 3},
+				Data:              map[string]interface{}{"AppName": "my-app", "Crashes": float64(3)},
+			}))
+		})
+
+		It("decodes the attachments", func() {
+			body := strings.NewReader(`{
+                "kind_id": "test_email",
+                "text": "Contents of the email message",
+                "attachments": [
+                    {"filename": "invoice.pdf", "content_type": "application/pdf", "content": "JVBERi0xLjQ="},
+                    {"filename": "logo.png", "content": "UE5H", "content_id": "logo"},
+                    {"filename": "notes", "content": "bm90ZXM="}
+                ]
+            }`)
+
+			parameters, err := params.NewNotify(body)
+			if err != nil {
+				panic(err)
+			}
+
+			options := parameters.ToOptions(models.Client{}, models.Kind{})
+			Expect(options.Attachments).To(Equal([]mail.Attachment{
+				{
+					Filename:    "invoice.pdf",
+					ContentType: "application/pdf",
+					Content:     []byte("%PDF-1.4"),
+				},
+				{
+					Filename:    "logo.png",
+					ContentType: "image/png",
+					Content:     []byte("PNG"),
+					ContentID:   "logo",
+				},
+				{
+					Filename:    "notes",
+					ContentType: "application/octet-stream",
+					Content:     []byte("notes"),
+				},
 			}))
 		})
 	})
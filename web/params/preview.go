@@ -0,0 +1,87 @@
+package params
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/cloudfoundry-incubator/notifications/cf"
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/postal"
+)
+
+// Preview is a sample notification, along with the recipient, space,
+// organization and scope it is rendered for. Template is only used when
+// previewing a template that has not been saved.
+type Preview struct {
+	Notify
+	UserGUID     string        `json:"user_guid"`
+	ClientID     string        `json:"client_id"`
+	Space        PreviewEntity `json:"space"`
+	Organization PreviewEntity `json:"organization"`
+	Scope        string        `json:"scope"`
+	Endorsement  string        `json:"endorsement"`
+	Template     *Template     `json:"template"`
+}
+
+type PreviewEntity struct {
+	GUID string `json:"guid"`
+	Name string `json:"name"`
+}
+
+func NewPreview(body io.Reader) (Preview, error) {
+	buffer := bytes.NewBuffer([]byte{})
+	buffer.ReadFrom(body)
+
+	notify, err := NewNotify(bytes.NewReader(buffer.Bytes()))
+	if err != nil {
+		return Preview{}, err
+	}
+
+	var preview Preview
+	if buffer.Len() > 0 {
+		err = json.Unmarshal(buffer.Bytes(), &preview)
+		if err != nil {
+			return Preview{}, ParseError{}
+		}
+	}
+	preview.Notify = notify
+
+	errors := attachmentErrors(&preview.Notify)
+	if len(errors) > 0 {
+		return Preview{}, ValidationError(errors)
+	}
+
+	if preview.Template != nil {
+		err = preview.Template.validateSyntax()
+		if err != nil {
+			return Preview{}, err
+		}
+
+		preview.Template.setDefaults()
+	}
+
+	return preview, nil
+}
+
+func (preview Preview) ToDelivery() postal.Delivery {
+	options := preview.ToOptions(models.Client{}, models.Kind{})
+	options.Endorsement = preview.Endorsement
+
+	return postal.Delivery{
+		Options:  options,
+		UserGUID: preview.UserGUID,
+		Email:    preview.To,
+		Space: cf.CloudControllerSpace{
+			GUID:             preview.Space.GUID,
+			Name:             preview.Space.Name,
+			OrganizationGUID: preview.Organization.GUID,
+		},
+		Organization: cf.CloudControllerOrganization{
+			GUID: preview.Organization.GUID,
+			Name: preview.Organization.Name,
+		},
+		ClientID: preview.ClientID,
+		Scope:    preview.Scope,
+	}
+}
@@ -0,0 +1,72 @@
+package params_test
+
+import (
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/cf"
+	"github.com/cloudfoundry-incubator/notifications/web/params"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Preview", func() {
+	Describe("NewPreview", func() {
+		It("parses the sample notification and its context", func() {
+			body := strings.NewReader(`{
+				"to": "Banana Man <banana@example.com>",
+				"subject": "Ripe",
+				"html": "<p>They are ripe</p>",
+				"data": {"Count": 3},
+				"user_guid": "user-123",
+				"client_id": "banana-client",
+				"space": {"guid": "space-123", "name": "development"},
+				"organization": {"guid": "org-123", "name": "banana-republic"},
+				"scope": "bananas.eat",
+				"endorsement": "You have the {{.Scope}} scope."
+			}`)
+
+			preview, err := params.NewPreview(body)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(preview.To).To(Equal("banana@example.com"))
+			Expect(preview.ParsedHTML.BodyContent).To(Equal("<p>They are ripe</p>"))
+			Expect(preview.Template).To(BeNil())
+
+			delivery := preview.ToDelivery()
+			Expect(delivery.Email).To(Equal("banana@example.com"))
+			Expect(delivery.UserGUID).To(Equal("user-123"))
+			Expect(delivery.ClientID).To(Equal("banana-client"))
+			Expect(delivery.Scope).To(Equal("bananas.eat"))
+			Expect(delivery.Space).To(Equal(cf.CloudControllerSpace{GUID: "space-123", Name: "development", OrganizationGUID: "org-123"}))
+			Expect(delivery.Organization).To(Equal(cf.CloudControllerOrganization{GUID: "org-123", Name: "banana-republic"}))
+			Expect(delivery.Options.Subject).To(Equal("Ripe"))
+			Expect(delivery.Options.Endorsement).To(Equal("You have the {{.Scope}} scope."))
+			Expect(delivery.Options.Data).To(Equal(map[string]interface{}{"Count": float64(3)}))
+		})
+
+		It("parses an unsaved template, defaulting its subject", func() {
+			preview, err := params.NewPreview(strings.NewReader(`{"template": {"text": "{{.Text}}", "html": "<p>{{.HTML}}</p>"}}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(preview.Template.Subject).To(Equal("{{.Subject}}"))
+			Expect(preview.Template.Text).To(Equal("{{.Text}}"))
+			Expect(preview.Template.HTML).To(Equal("<p>{{.HTML}}</p>"))
+		})
+
+		It("returns a validation error when the unsaved template has invalid syntax", func() {
+			_, err := params.NewPreview(strings.NewReader(`{"template": {"html": "{{.bad}"}}`))
+			Expect(err).To(BeAssignableToTypeOf(params.ValidationError([]string{})))
+		})
+
+		It("returns a validation error when the attachments are invalid", func() {
+			_, err := params.NewPreview(strings.NewReader(`{"attachments": [{"filename": "logo.png", "content": "not base64!"}]}`))
+			Expect(err).To(Equal(params.ValidationError([]string{`"attachments" content must be base64 encoded`})))
+		})
+
+		It("returns a parse error when the body is not JSON", func() {
+			_, err := params.NewPreview(strings.NewReader(`{"text": `))
+			Expect(err).To(BeAssignableToTypeOf(params.ParseError{}))
+		})
+	})
+})
@@ -0,0 +1,42 @@
+package params
+
+import (
+	"io"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/valiant"
+)
+
+type SuppressionParams struct {
+	Email  string `json:"email"  validate-required:"true"`
+	Reason string `json:"reason"`
+}
+
+func NewSuppressionParams(body io.Reader) (SuppressionParams, error) {
+	var params SuppressionParams
+
+	validator := valiant.NewValidator(body)
+	err := validator.Validate(&params)
+	if err != nil {
+		switch err.(type) {
+		case valiant.RequiredFieldError:
+			return params, ValidationError([]string{err.Error()})
+		default:
+			return params, ParseError{}
+		}
+	}
+
+	if !strings.Contains(params.Email, "@") {
+		return params, ValidationError([]string{`"email" must be a valid email address`})
+	}
+
+	return params, nil
+}
+
+func (params SuppressionParams) ToModel() models.Suppression {
+	return models.Suppression{
+		Email:  params.Email,
+		Reason: params.Reason,
+	}
+}
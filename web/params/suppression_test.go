@@ -0,0 +1,54 @@
+package params_test
+
+import (
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/web/params"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Suppression", func() {
+	Describe("NewSuppressionParams", func() {
+		It("returns SuppressionParams", func() {
+			body := strings.NewReader(`{"email":"bounced@example.com", "reason":"user asked us to stop"}`)
+			suppressionParams, err := params.NewSuppressionParams(body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(suppressionParams).To(Equal(params.SuppressionParams{
+				Email:  "bounced@example.com",
+				Reason: "user asked us to stop",
+			}))
+		})
+
+		It("returns a validation error when the email is missing", func() {
+			_, err := params.NewSuppressionParams(strings.NewReader(`{"reason":"because"}`))
+			Expect(err).To(BeAssignableToTypeOf(params.ValidationError{}))
+		})
+
+		It("returns a validation error when the email is invalid", func() {
+			_, err := params.NewSuppressionParams(strings.NewReader(`{"email":"nope"}`))
+			Expect(err).To(BeAssignableToTypeOf(params.ValidationError{}))
+		})
+
+		It("returns a parse error when the json is malformed", func() {
+			_, err := params.NewSuppressionParams(strings.NewReader(`{"email":`))
+			Expect(err).To(BeAssignableToTypeOf(params.ParseError{}))
+		})
+	})
+
+	Describe("ToModel", func() {
+		It("returns a models.Suppression", func() {
+			suppressionParams := params.SuppressionParams{
+				Email:  "bounced@example.com",
+				Reason: "because",
+			}
+
+			Expect(suppressionParams.ToModel()).To(Equal(models.Suppression{
+				Email:  "bounced@example.com",
+				Reason: "because",
+			}))
+		})
+	})
+})
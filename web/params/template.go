@@ -6,6 +6,7 @@ import (
 	"text/template"
 
 	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/postal"
 	"github.com/cloudfoundry-incubator/notifications/valiant"
 )
 
@@ -51,20 +52,27 @@ func NewTemplate(body io.Reader) (Template, error) {
 	return template, nil
 }
 
+// validateSyntax parses each template the way it is rendered: the subject
+// and text as text/template, and the HTML as html/template, which must also
+// be able to escape it.
 func (t Template) validateSyntax() error {
 	toValidate := map[string]string{
 		"Subject": t.Subject,
 		"Text":    t.Text,
-		"HTML":    t.HTML,
 	}
 
 	for field, contents := range toValidate {
-		_, err := template.New("test").Parse(contents)
+		_, err := template.New("test").Funcs(postal.TemplateHelpers()).Parse(contents)
 		if err != nil {
 			return ValidationError([]string{field + " syntax is malformed please check your braces"})
 		}
 	}
 
+	err := postal.ValidateHTMLTemplate(t.HTML)
+	if err != nil {
+		return ValidationError([]string{"HTML syntax is malformed: " + err.Error()})
+	}
+
 	return nil
 }
 
@@ -66,6 +66,17 @@ var _ = Describe("Template", func() {
 				Expect(parameters.Metadata).To(Equal(json.RawMessage("{}")))
 			})
 
+			It("accepts templates that use the template helpers", func() {
+				body := buildTemplateRequestBody(params.Template{
+					Name:    "Template name",
+					Text:    `{{truncate 80 .Text}}`,
+					HTML:    `<a href="{{url "https://example.com/spaces" .SpaceGUID}}">{{.Space | default "your space"}}</a>`,
+					Subject: `{{upper .Subject}}`,
+				})
+				_, err := params.NewTemplate(body)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
 			Context("when the template has invalid syntax", func() {
 				Context("when subject template has invalid syntax", func() {
 					It("returns a validation error", func() {
@@ -95,6 +106,20 @@ var _ = Describe("Template", func() {
 					})
 				})
 
+				Context("when a template calls an unknown function", func() {
+					It("returns a validation error", func() {
+						body := buildTemplateRequestBody(params.Template{
+							Name:    "Template name",
+							Text:    "Textual template",
+							HTML:    "{{shout .Subject}}",
+							Subject: "Great Subject",
+						})
+						_, err := params.NewTemplate(body)
+						Expect(err).To(HaveOccurred())
+						Expect(err).To(BeAssignableToTypeOf(params.ValidationError([]string{})))
+					})
+				})
+
 				Context("when html template has invalid syntax", func() {
 					It("returns a validation error", func() {
 						body := buildTemplateRequestBody(params.Template{
@@ -108,6 +133,33 @@ var _ = Describe("Template", func() {
 						Expect(err).To(BeAssignableToTypeOf(params.ValidationError([]string{})))
 					})
 				})
+
+				Context("when html template cannot be escaped", func() {
+					It("returns a validation error", func() {
+						body := buildTemplateRequestBody(params.Template{
+							Name:    "Template name",
+							Text:    "Textual template",
+							HTML:    `{{if .Space}}<a href="{{end}}home">`,
+							Subject: "Great Subject",
+						})
+						_, err := params.NewTemplate(body)
+						Expect(err).To(BeAssignableToTypeOf(params.ValidationError([]string{})))
+						Expect(err.Error()).To(ContainSubstring("HTML syntax is malformed"))
+					})
+				})
+
+				Context("when html template only fails for the values it is given", func() {
+					It("accepts the template", func() {
+						body := buildTemplateRequestBody(params.Template{
+							Name:    "Template name",
+							Text:    "Textual template",
+							HTML:    `<p>{{index .Data "count"}} {{formatDate "2006-01-02" .Data.sent_at}}</p>`,
+							Subject: "Great Subject",
+						})
+						_, err := params.NewTemplate(body)
+						Expect(err).NotTo(HaveOccurred())
+					})
+				})
 			})
 		})
 	})
@@ -1,5 +1,12 @@
 package params
 
+import (
+	"encoding/base64"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/postal"
+)
+
 type EmailValidator struct{}
 
 func (validator EmailValidator) Validate(notify *Notify) bool {
@@ -17,6 +24,16 @@ func (validator EmailValidator) Validate(notify *Notify) bool {
 		notify.Errors = append(notify.Errors, `"text" or "html" fields must be supplied`)
 	}
 
+	if invalidPriority(notify) {
+		notify.Errors = append(notify.Errors, `"priority" must be between 0 and 9`)
+	}
+
+	if invalidSendAt(notify) {
+		notify.Errors = append(notify.Errors, `"send_at" must be a timestamp in RFC 3339 format`)
+	}
+
+	notify.Errors = append(notify.Errors, attachmentErrors(notify)...)
+
 	return len(notify.Errors) == 0
 }
 
@@ -35,6 +52,16 @@ func (validator GUIDValidator) Validate(notify *Notify) bool {
 		notify.Errors = append(notify.Errors, `"role" must be "OrgManager", "OrgAuditor", "BillingManager" or unset`)
 	}
 
+	if invalidPriority(notify) {
+		notify.Errors = append(notify.Errors, `"priority" must be between 0 and 9`)
+	}
+
+	if invalidSendAt(notify) {
+		notify.Errors = append(notify.Errors, `"send_at" must be a timestamp in RFC 3339 format`)
+	}
+
+	notify.Errors = append(notify.Errors, attachmentErrors(notify)...)
+
 	return len(notify.Errors) == 0
 }
 
@@ -42,6 +69,53 @@ func missingTextOrHTMLFields(notify *Notify) bool {
 	return notify.Text == "" && notify.ParsedHTML.BodyContent == ""
 }
 
+func invalidSendAt(notify *Notify) bool {
+	if notify.SendAt == "" {
+		return false
+	}
+
+	_, err := time.Parse(time.RFC3339, notify.SendAt)
+	return err != nil
+}
+
+func attachmentErrors(notify *Notify) []string {
+	var errors []string
+	var missingFields, notEncoded bool
+	size := 0
+	for _, attachment := range notify.Attachments {
+		if attachment.Filename == "" || attachment.Content == "" {
+			missingFields = true
+		}
+
+		content, err := base64.StdEncoding.DecodeString(attachment.Content)
+		if err != nil {
+			notEncoded = true
+		}
+		size += len(content)
+	}
+
+	if missingFields {
+		errors = append(errors, `"attachments" must each have a "filename" and "content"`)
+	}
+
+	if notEncoded {
+		errors = append(errors, `"attachments" content must be base64 encoded`)
+	}
+
+	if size > MaxAttachmentsSize {
+		errors = append(errors, `"attachments" must not be larger than 10MB in total`)
+	}
+
+	return errors
+}
+
+// invalidPriority reports whether a caller asked for a priority outside of
+// the range they may request. The critical priority is reserved for
+// critical kinds.
+func invalidPriority(notify *Notify) bool {
+	return notify.Priority < postal.PriorityNormal || notify.Priority >= postal.PriorityCritical
+}
+
 func (validator GUIDValidator) invalidRoleField(roleName string) bool {
 	if roleName == "" {
 		return false
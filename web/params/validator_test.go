@@ -1,6 +1,8 @@
 package params_test
 
 import (
+	"encoding/base64"
+
 	"github.com/cloudfoundry-incubator/notifications/postal"
 	"github.com/cloudfoundry-incubator/notifications/web/params"
 
@@ -54,6 +56,63 @@ var _ = Describe("Validator", func() {
 					Expect(notify.Errors).To(ContainElement(`"to" is improperly formatted`))
 				})
 			})
+
+			It("validates that the priority is between 0 and 9", func() {
+				for _, priority := range []int{0, 5, 9} {
+					notify.Priority = priority
+					Expect(validator.Validate(notify)).To(BeTrue())
+					Expect(len(notify.Errors)).To(Equal(0))
+				}
+
+				for _, priority := range []int{-1, 10, 11} {
+					notify.Priority = priority
+					Expect(validator.Validate(notify)).To(BeFalse())
+					Expect(len(notify.Errors)).To(Equal(1))
+					Expect(notify.Errors).To(ContainElement(`"priority" must be between 0 and 9`))
+				}
+			})
+
+			It("validates that send_at is an RFC 3339 timestamp", func() {
+				for _, sendAt := range []string{"", "2015-02-03T10:30:00Z", "2015-02-03T10:30:00-08:00"} {
+					notify.SendAt = sendAt
+					Expect(validator.Validate(notify)).To(BeTrue())
+					Expect(len(notify.Errors)).To(Equal(0))
+				}
+
+				notify.SendAt = "next tuesday"
+				Expect(validator.Validate(notify)).To(BeFalse())
+				Expect(len(notify.Errors)).To(Equal(1))
+				Expect(notify.Errors).To(ContainElement(`"send_at" must be a timestamp in RFC 3339 format`))
+			})
+
+			It("validates the attachments", func() {
+				notify.Attachments = []params.Attachment{
+					{Filename: "invoice.pdf", Content: base64.StdEncoding.EncodeToString([]byte("%PDF-1.4"))},
+				}
+				Expect(validator.Validate(notify)).To(BeTrue())
+				Expect(len(notify.Errors)).To(Equal(0))
+
+				notify.Attachments = append(notify.Attachments, params.Attachment{Content: "bm8gbmFtZQ=="})
+				Expect(validator.Validate(notify)).To(BeFalse())
+				Expect(notify.Errors).To(ConsistOf(`"attachments" must each have a "filename" and "content"`))
+
+				notify.Attachments[1] = params.Attachment{Filename: "logo.png", Content: "not base64!"}
+				Expect(validator.Validate(notify)).To(BeFalse())
+				Expect(notify.Errors).To(ConsistOf(`"attachments" content must be base64 encoded`))
+			})
+
+			It("validates that the attachments are not larger than 10MB in total", func() {
+				content := base64.StdEncoding.EncodeToString(make([]byte, params.MaxAttachmentsSize/2))
+				notify.Attachments = []params.Attachment{
+					{Filename: "one.bin", Content: content},
+					{Filename: "two.bin", Content: content},
+				}
+				Expect(validator.Validate(notify)).To(BeTrue())
+
+				notify.Attachments = append(notify.Attachments, params.Attachment{Filename: "three.bin", Content: "AA=="})
+				Expect(validator.Validate(notify)).To(BeFalse())
+				Expect(notify.Errors).To(ConsistOf(`"attachments" must not be larger than 10MB in total`))
+			})
 		})
 	})
 
@@ -119,6 +178,63 @@ var _ = Describe("Validator", func() {
 				Expect(len(notify.Errors)).To(Equal(1))
 				Expect(notify.Errors).To(ContainElement(`"role" must be "OrgManager", "OrgAuditor", "BillingManager" or unset`))
 			})
+
+			It("validates that the priority is between 0 and 9", func() {
+				for _, priority := range []int{0, 5, 9} {
+					notify.Priority = priority
+					Expect(validator.Validate(notify)).To(BeTrue())
+					Expect(len(notify.Errors)).To(Equal(0))
+				}
+
+				for _, priority := range []int{-1, 10, 11} {
+					notify.Priority = priority
+					Expect(validator.Validate(notify)).To(BeFalse())
+					Expect(len(notify.Errors)).To(Equal(1))
+					Expect(notify.Errors).To(ContainElement(`"priority" must be between 0 and 9`))
+				}
+			})
+
+			It("validates that send_at is an RFC 3339 timestamp", func() {
+				for _, sendAt := range []string{"", "2015-02-03T10:30:00Z", "2015-02-03T10:30:00-08:00"} {
+					notify.SendAt = sendAt
+					Expect(validator.Validate(notify)).To(BeTrue())
+					Expect(len(notify.Errors)).To(Equal(0))
+				}
+
+				notify.SendAt = "next tuesday"
+				Expect(validator.Validate(notify)).To(BeFalse())
+				Expect(len(notify.Errors)).To(Equal(1))
+				Expect(notify.Errors).To(ContainElement(`"send_at" must be a timestamp in RFC 3339 format`))
+			})
+
+			It("validates the attachments", func() {
+				notify.Attachments = []params.Attachment{
+					{Filename: "invoice.pdf", Content: base64.StdEncoding.EncodeToString([]byte("%PDF-1.4"))},
+				}
+				Expect(validator.Validate(notify)).To(BeTrue())
+				Expect(len(notify.Errors)).To(Equal(0))
+
+				notify.Attachments = append(notify.Attachments, params.Attachment{Content: "bm8gbmFtZQ=="})
+				Expect(validator.Validate(notify)).To(BeFalse())
+				Expect(notify.Errors).To(ConsistOf(`"attachments" must each have a "filename" and "content"`))
+
+				notify.Attachments[1] = params.Attachment{Filename: "logo.png", Content: "not base64!"}
+				Expect(validator.Validate(notify)).To(BeFalse())
+				Expect(notify.Errors).To(ConsistOf(`"attachments" content must be base64 encoded`))
+			})
+
+			It("validates that the attachments are not larger than 10MB in total", func() {
+				content := base64.StdEncoding.EncodeToString(make([]byte, params.MaxAttachmentsSize/2))
+				notify.Attachments = []params.Attachment{
+					{Filename: "one.bin", Content: content},
+					{Filename: "two.bin", Content: content},
+				}
+				Expect(validator.Validate(notify)).To(BeTrue())
+
+				notify.Attachments = append(notify.Attachments, params.Attachment{Filename: "three.bin", Content: "AA=="})
+				Expect(validator.Validate(notify)).To(BeFalse())
+				Expect(notify.Errors).To(ConsistOf(`"attachments" must not be larger than 10MB in total`))
+			})
 		})
 	})
 })
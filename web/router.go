@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/postal"
 	"github.com/cloudfoundry-incubator/notifications/postal/strategies"
 	"github.com/cloudfoundry-incubator/notifications/web/handlers"
 	"github.com/cloudfoundry-incubator/notifications/web/middleware"
@@ -25,7 +26,14 @@ type MotherInterface interface {
 	PreferencesFinder() *services.PreferencesFinder
 	PreferenceUpdater() services.PreferenceUpdater
 	MessageFinder() services.MessageFinder
+	MessageCanceler() services.MessageCanceler
+	CampaignFinder() services.CampaignFinder
+	DeadJobServiceObjects() (services.DeadJobsFinder, services.DeadJobReplayer, services.DeadJobPurger)
+	SuppressionServiceObjects() (services.SuppressionsFinder, services.SuppressionsUpdater)
+	BounceProcessor() services.BounceProcessor
 	TemplateServiceObjects() (services.TemplateCreator, services.TemplateFinder, services.TemplateUpdater, services.TemplateDeleter, services.TemplateLister, services.TemplateAssigner, services.TemplateAssociationLister)
+	TemplatePreviewer() postal.TemplatePreviewer
+	TemplateVersionServiceObjects() (services.TemplateVersionLister, services.TemplateVersionDiffer, services.TemplateRollbacker)
 	Database() models.DatabaseInterface
 	Logging() stack.Middleware
 	ErrorWriter() handlers.ErrorWriter
@@ -51,8 +59,15 @@ func NewRouter(mother MotherInterface) Router {
 	preferencesFinder := mother.PreferencesFinder()
 	preferenceUpdater := mother.PreferenceUpdater()
 	templateCreator, templateFinder, templateUpdater, templateDeleter, templateLister, templateAssigner, templateAssociationLister := mother.TemplateServiceObjects()
+	templatePreviewer := mother.TemplatePreviewer()
+	templateVersionLister, templateVersionDiffer, templateRollbacker := mother.TemplateVersionServiceObjects()
 	notificationsUpdater := mother.NotificationsUpdater()
 	messageFinder := mother.MessageFinder()
+	messageCanceler := mother.MessageCanceler()
+	campaignFinder := mother.CampaignFinder()
+	deadJobsFinder, deadJobReplayer, deadJobPurger := mother.DeadJobServiceObjects()
+	suppressionsFinder, suppressionsUpdater := mother.SuppressionServiceObjects()
+	bounceProcessor := mother.BounceProcessor()
 	logging := mother.Logging()
 	errorWriter := mother.ErrorWriter()
 	notificationsWriteAuthenticator := mother.Authenticator("notifications.write")
@@ -99,7 +114,23 @@ func NewRouter(mother MotherInterface) Router {
 			"PUT /clients/{client_id}/template":                                 stack.NewStack(handlers.NewAssignClientTemplate(templateAssigner, errorWriter)).Use(logging, requestCounter, notificationsManageAuthenticator),
 			"PUT /clients/{client_id}/notifications/{notification_id}/template": stack.NewStack(handlers.NewAssignNotificationTemplate(templateAssigner, errorWriter)).Use(logging, requestCounter, notificationsManageAuthenticator),
 			"GET /templates/{template_id}/associations":                         stack.NewStack(handlers.NewListTemplateAssociations(templateAssociationLister, errorWriter)).Use(logging, requestCounter, notificationsManageAuthenticator),
+			"POST /templates/preview":                                           stack.NewStack(handlers.NewPreviewTemplate(templateFinder, templatePreviewer, errorWriter)).Use(logging, requestCounter, notificationsTemplateReadAuthenticator),
+			"POST /templates/{template_id}/preview":                             stack.NewStack(handlers.NewPreviewTemplate(templateFinder, templatePreviewer, errorWriter)).Use(logging, requestCounter, notificationsTemplateReadAuthenticator),
+			"GET /templates/{template_id}/versions":                             stack.NewStack(handlers.NewListTemplateVersions(templateVersionLister, errorWriter)).Use(logging, requestCounter, notificationsTemplateReadAuthenticator),
+			"GET /templates/{template_id}/diff":                                 stack.NewStack(handlers.NewDiffTemplateVersions(templateVersionDiffer, errorWriter)).Use(logging, requestCounter, notificationsTemplateReadAuthenticator),
+			"POST /templates/{template_id}/versions/{version}/rollback":         stack.NewStack(handlers.NewRollbackTemplate(templateRollbacker, errorWriter)).Use(logging, requestCounter, notificationsTemplateWriteAuthenticator),
 			"GET /messages/{message_id}":                                        stack.NewStack(handlers.NewGetMessages(messageFinder, errorWriter)).Use(logging, requestCounter, notificationsWriteOrEmailsWriteAuthenticator),
+			"DELETE /messages/{message_id}":                                     stack.NewStack(handlers.NewCancelMessage(messageCanceler, errorWriter)).Use(logging, requestCounter, notificationsWriteOrEmailsWriteAuthenticator),
+			"GET /campaigns/{campaign_id}":                                      stack.NewStack(handlers.NewGetCampaign(campaignFinder, errorWriter)).Use(logging, requestCounter, notificationsWriteAuthenticator),
+			"GET /dead_jobs":                                                    stack.NewStack(handlers.NewListDeadJobs(deadJobsFinder, errorWriter)).Use(logging, requestCounter, notificationsManageAuthenticator),
+			"DELETE /dead_jobs":                                                 stack.NewStack(handlers.NewPurgeDeadJobs(deadJobPurger, errorWriter)).Use(logging, requestCounter, notificationsManageAuthenticator),
+			"GET /dead_jobs/{dead_job_id}":                                      stack.NewStack(handlers.NewGetDeadJob(deadJobsFinder, errorWriter)).Use(logging, requestCounter, notificationsManageAuthenticator),
+			"DELETE /dead_jobs/{dead_job_id}":                                   stack.NewStack(handlers.NewDeleteDeadJob(deadJobPurger, errorWriter)).Use(logging, requestCounter, notificationsManageAuthenticator),
+			"POST /dead_jobs/{dead_job_id}/replay":                              stack.NewStack(handlers.NewReplayDeadJob(deadJobReplayer, errorWriter)).Use(logging, requestCounter, notificationsManageAuthenticator),
+			"GET /suppressions":                                                 stack.NewStack(handlers.NewListSuppressions(suppressionsFinder, errorWriter)).Use(logging, requestCounter, notificationsManageAuthenticator),
+			"POST /suppressions":                                                stack.NewStack(handlers.NewCreateSuppression(suppressionsUpdater, errorWriter)).Use(logging, requestCounter, notificationsManageAuthenticator),
+			"DELETE /suppressions/{email}":                                      stack.NewStack(handlers.NewDeleteSuppression(suppressionsUpdater, errorWriter)).Use(logging, requestCounter, notificationsManageAuthenticator),
+			"POST /bounces":                                                     stack.NewStack(handlers.NewProcessBounce(bounceProcessor, errorWriter)).Use(logging, requestCounter, notificationsManageAuthenticator),
 		},
 	}
 }
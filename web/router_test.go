@@ -256,6 +256,61 @@ var _ = Describe("Router", func() {
 		Expect(authenticator.Scopes).To(Equal([]string{"notification_templates.read"}))
 	})
 
+	It("routes POST /templates/preview", func() {
+		s := router.Routes().Get("POST /templates/preview").GetHandler().(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(handlers.PreviewTemplate{}))
+		Expect(s.Middleware[0]).To(BeAssignableToTypeOf(stack.Logging{}))
+		Expect(s.Middleware[1]).To(BeAssignableToTypeOf(middleware.RequestCounter{}))
+		Expect(s.Middleware[2]).To(BeAssignableToTypeOf(middleware.Authenticator{}))
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(Equal([]string{"notification_templates.read"}))
+	})
+
+	It("routes POST /templates/{template_id}/preview", func() {
+		s := router.Routes().Get("POST /templates/{template_id}/preview").GetHandler().(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(handlers.PreviewTemplate{}))
+		Expect(s.Middleware[0]).To(BeAssignableToTypeOf(stack.Logging{}))
+		Expect(s.Middleware[1]).To(BeAssignableToTypeOf(middleware.RequestCounter{}))
+		Expect(s.Middleware[2]).To(BeAssignableToTypeOf(middleware.Authenticator{}))
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(Equal([]string{"notification_templates.read"}))
+	})
+
+	It("routes GET /templates/{template_id}/versions", func() {
+		s := router.Routes().Get("GET /templates/{template_id}/versions").GetHandler().(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(handlers.ListTemplateVersions{}))
+		Expect(s.Middleware[0]).To(BeAssignableToTypeOf(stack.Logging{}))
+		Expect(s.Middleware[1]).To(BeAssignableToTypeOf(middleware.RequestCounter{}))
+		Expect(s.Middleware[2]).To(BeAssignableToTypeOf(middleware.Authenticator{}))
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(Equal([]string{"notification_templates.read"}))
+	})
+
+	It("routes GET /templates/{template_id}/diff", func() {
+		s := router.Routes().Get("GET /templates/{template_id}/diff").GetHandler().(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(handlers.DiffTemplateVersions{}))
+		Expect(s.Middleware[0]).To(BeAssignableToTypeOf(stack.Logging{}))
+		Expect(s.Middleware[1]).To(BeAssignableToTypeOf(middleware.RequestCounter{}))
+		Expect(s.Middleware[2]).To(BeAssignableToTypeOf(middleware.Authenticator{}))
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(Equal([]string{"notification_templates.read"}))
+	})
+
+	It("routes POST /templates/{template_id}/versions/{version}/rollback", func() {
+		s := router.Routes().Get("POST /templates/{template_id}/versions/{version}/rollback").GetHandler().(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(handlers.RollbackTemplate{}))
+		Expect(s.Middleware[0]).To(BeAssignableToTypeOf(stack.Logging{}))
+		Expect(s.Middleware[1]).To(BeAssignableToTypeOf(middleware.RequestCounter{}))
+		Expect(s.Middleware[2]).To(BeAssignableToTypeOf(middleware.Authenticator{}))
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(Equal([]string{"notification_templates.write"}))
+	})
+
 	It("routes PUT /clients/{client_id}/template", func() {
 		s := router.Routes().Get("PUT /clients/{client_id}/template").GetHandler().(stack.Stack)
 		Expect(s.Handler).To(BeAssignableToTypeOf(handlers.AssignClientTemplate{}))
@@ -321,4 +376,125 @@ var _ = Describe("Router", func() {
 		authenticator := s.Middleware[2].(middleware.Authenticator)
 		Expect(authenticator.Scopes).To(ConsistOf([]string{"notifications.write", "emails.write"}))
 	})
+
+	It("routes DELETE /messages/{message_id}", func() {
+		s := router.Routes().Get("DELETE /messages/{message_id}").GetHandler().(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(handlers.CancelMessage{}))
+		Expect(s.Middleware[0]).To(BeAssignableToTypeOf(stack.Logging{}))
+		Expect(s.Middleware[1]).To(BeAssignableToTypeOf(middleware.RequestCounter{}))
+		Expect(s.Middleware[2]).To(BeAssignableToTypeOf(middleware.Authenticator{}))
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(ConsistOf([]string{"notifications.write", "emails.write"}))
+	})
+
+	It("routes GET /campaigns/{campaign_id}", func() {
+		s := router.Routes().Get("GET /campaigns/{campaign_id}").GetHandler().(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(handlers.GetCampaign{}))
+		Expect(s.Middleware[0]).To(BeAssignableToTypeOf(stack.Logging{}))
+		Expect(s.Middleware[1]).To(BeAssignableToTypeOf(middleware.RequestCounter{}))
+		Expect(s.Middleware[2]).To(BeAssignableToTypeOf(middleware.Authenticator{}))
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(Equal([]string{"notifications.write"}))
+	})
+
+	It("routes GET /dead_jobs", func() {
+		s := router.Routes().Get("GET /dead_jobs").GetHandler().(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(handlers.ListDeadJobs{}))
+		Expect(s.Middleware[0]).To(BeAssignableToTypeOf(stack.Logging{}))
+		Expect(s.Middleware[1]).To(BeAssignableToTypeOf(middleware.RequestCounter{}))
+		Expect(s.Middleware[2]).To(BeAssignableToTypeOf(middleware.Authenticator{}))
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(Equal([]string{"notifications.manage"}))
+	})
+
+	It("routes DELETE /dead_jobs", func() {
+		s := router.Routes().Get("DELETE /dead_jobs").GetHandler().(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(handlers.PurgeDeadJobs{}))
+		Expect(s.Middleware[0]).To(BeAssignableToTypeOf(stack.Logging{}))
+		Expect(s.Middleware[1]).To(BeAssignableToTypeOf(middleware.RequestCounter{}))
+		Expect(s.Middleware[2]).To(BeAssignableToTypeOf(middleware.Authenticator{}))
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(Equal([]string{"notifications.manage"}))
+	})
+
+	It("routes GET /dead_jobs/{dead_job_id}", func() {
+		s := router.Routes().Get("GET /dead_jobs/{dead_job_id}").GetHandler().(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(handlers.GetDeadJob{}))
+		Expect(s.Middleware[0]).To(BeAssignableToTypeOf(stack.Logging{}))
+		Expect(s.Middleware[1]).To(BeAssignableToTypeOf(middleware.RequestCounter{}))
+		Expect(s.Middleware[2]).To(BeAssignableToTypeOf(middleware.Authenticator{}))
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(Equal([]string{"notifications.manage"}))
+	})
+
+	It("routes DELETE /dead_jobs/{dead_job_id}", func() {
+		s := router.Routes().Get("DELETE /dead_jobs/{dead_job_id}").GetHandler().(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(handlers.DeleteDeadJob{}))
+		Expect(s.Middleware[0]).To(BeAssignableToTypeOf(stack.Logging{}))
+		Expect(s.Middleware[1]).To(BeAssignableToTypeOf(middleware.RequestCounter{}))
+		Expect(s.Middleware[2]).To(BeAssignableToTypeOf(middleware.Authenticator{}))
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(Equal([]string{"notifications.manage"}))
+	})
+
+	It("routes POST /dead_jobs/{dead_job_id}/replay", func() {
+		s := router.Routes().Get("POST /dead_jobs/{dead_job_id}/replay").GetHandler().(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(handlers.ReplayDeadJob{}))
+		Expect(s.Middleware[0]).To(BeAssignableToTypeOf(stack.Logging{}))
+		Expect(s.Middleware[1]).To(BeAssignableToTypeOf(middleware.RequestCounter{}))
+		Expect(s.Middleware[2]).To(BeAssignableToTypeOf(middleware.Authenticator{}))
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(Equal([]string{"notifications.manage"}))
+	})
+
+	It("routes GET /suppressions", func() {
+		s := router.Routes().Get("GET /suppressions").GetHandler().(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(handlers.ListSuppressions{}))
+		Expect(s.Middleware[0]).To(BeAssignableToTypeOf(stack.Logging{}))
+		Expect(s.Middleware[1]).To(BeAssignableToTypeOf(middleware.RequestCounter{}))
+		Expect(s.Middleware[2]).To(BeAssignableToTypeOf(middleware.Authenticator{}))
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(Equal([]string{"notifications.manage"}))
+	})
+
+	It("routes POST /suppressions", func() {
+		s := router.Routes().Get("POST /suppressions").GetHandler().(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(handlers.CreateSuppression{}))
+		Expect(s.Middleware[0]).To(BeAssignableToTypeOf(stack.Logging{}))
+		Expect(s.Middleware[1]).To(BeAssignableToTypeOf(middleware.RequestCounter{}))
+		Expect(s.Middleware[2]).To(BeAssignableToTypeOf(middleware.Authenticator{}))
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(Equal([]string{"notifications.manage"}))
+	})
+
+	It("routes DELETE /suppressions/{email}", func() {
+		s := router.Routes().Get("DELETE /suppressions/{email}").GetHandler().(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(handlers.DeleteSuppression{}))
+		Expect(s.Middleware[0]).To(BeAssignableToTypeOf(stack.Logging{}))
+		Expect(s.Middleware[1]).To(BeAssignableToTypeOf(middleware.RequestCounter{}))
+		Expect(s.Middleware[2]).To(BeAssignableToTypeOf(middleware.Authenticator{}))
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(Equal([]string{"notifications.manage"}))
+	})
+
+	It("routes POST /bounces", func() {
+		s := router.Routes().Get("POST /bounces").GetHandler().(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(handlers.ProcessBounce{}))
+		Expect(s.Middleware[0]).To(BeAssignableToTypeOf(stack.Logging{}))
+		Expect(s.Middleware[1]).To(BeAssignableToTypeOf(middleware.RequestCounter{}))
+		Expect(s.Middleware[2]).To(BeAssignableToTypeOf(middleware.Authenticator{}))
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(Equal([]string{"notifications.manage"}))
+	})
 })
@@ -2,19 +2,59 @@ package web
 
 import (
 	"log"
+	"net"
 	"net/http"
+	"sync"
 )
 
 type Server struct {
+	mutex    *sync.Mutex
+	listener net.Listener
+	server   *http.Server
+	stopped  bool
 }
 
-func NewServer() Server {
-	return Server{}
+func NewServer() *Server {
+	return &Server{
+		mutex: &sync.Mutex{},
+	}
 }
 
-func (s Server) Run(port string, mother MotherInterface) {
+func (s *Server) Run(port string, mother MotherInterface) {
 	router := NewRouter(mother)
+
+	listener, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		panic(err)
+	}
+
+	s.mutex.Lock()
+	if s.stopped {
+		s.mutex.Unlock()
+		listener.Close()
+		return
+	}
+	s.listener = listener
+	s.server = &http.Server{Handler: router.Routes()}
+	s.mutex.Unlock()
+
 	log.Printf("Listening on localhost:%s\n", port)
 
-	http.ListenAndServe(":"+port, router.Routes())
+	s.server.Serve(listener)
+}
+
+// Shutdown stops the server from accepting new connections. Requests that
+// are already being served are allowed to finish, but their connections
+// will not be kept alive afterwards.
+func (s *Server) Shutdown() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.stopped = true
+	if s.listener == nil {
+		return
+	}
+
+	s.server.SetKeepAlivesEnabled(false)
+	s.listener.Close()
 }
@@ -0,0 +1,62 @@
+package web_test
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/web"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Server", func() {
+	var server *web.Server
+	var port string
+
+	BeforeEach(func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			panic(err)
+		}
+		port = strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+		listener.Close()
+
+		server = web.NewServer()
+	})
+
+	Describe("Shutdown", func() {
+		It("stops accepting requests and returns from Run", func() {
+			done := make(chan bool)
+			go func() {
+				server.Run(port, fakes.NewMother())
+				close(done)
+			}()
+
+			Eventually(func() error {
+				response, err := http.Get("http://127.0.0.1:" + port + "/info")
+				if err == nil {
+					response.Body.Close()
+				}
+				return err
+			}).ShouldNot(HaveOccurred())
+
+			server.Shutdown()
+
+			Eventually(done).Should(BeClosed())
+
+			_, err := http.Get("http://127.0.0.1:" + port + "/info")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("prevents a server that has not started yet from listening", func() {
+			server.Shutdown()
+			server.Run(port, fakes.NewMother())
+
+			_, err := http.Get("http://127.0.0.1:" + port + "/info")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/cloudfoundry-incubator/notifications/models"
+)
+
+type BounceProcessorInterface interface {
+	Process(mail.DeliveryStatusNotification) error
+}
+
+type BounceProcessor struct {
+	messagesRepo     MessagesRepoInterface
+	suppressionsRepo models.SuppressionsRepoInterface
+	database         models.DatabaseInterface
+}
+
+func NewBounceProcessor(messagesRepo MessagesRepoInterface, suppressionsRepo models.SuppressionsRepoInterface, database models.DatabaseInterface) BounceProcessor {
+	return BounceProcessor{
+		messagesRepo:     messagesRepo,
+		suppressionsRepo: suppressionsRepo,
+		database:         database,
+	}
+}
+
+// Process suppresses every recipient that hard bounced and marks the original
+// message as bounced. Reports that only contain delays or transient failures
+// are ignored, as the relay will keep trying to deliver those on its own.
+func (processor BounceProcessor) Process(dsn mail.DeliveryStatusNotification) error {
+	conn := processor.database.Connection()
+
+	bounced := false
+	for _, recipient := range dsn.Recipients {
+		if !recipient.HardBounce() || recipient.FinalRecipient == "" {
+			continue
+		}
+
+		_, err := processor.suppressionsRepo.Upsert(conn, models.Suppression{
+			Email:  recipient.FinalRecipient,
+			Reason: strings.TrimSpace(fmt.Sprintf("Bounced with %s %s", recipient.Status, recipient.DiagnosticCode)),
+		})
+		if err != nil {
+			return err
+		}
+
+		bounced = true
+	}
+
+	if !bounced || dsn.NotificationID == "" {
+		return nil
+	}
+
+	message, err := processor.messagesRepo.FindByID(conn, dsn.NotificationID)
+	if err != nil {
+		if _, ok := err.(models.RecordNotFoundError); ok {
+			return nil
+		}
+		return err
+	}
+
+	message.Status = models.StatusBounced
+	_, err = processor.messagesRepo.Upsert(conn, message)
+	return err
+}
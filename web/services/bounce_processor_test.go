@@ -0,0 +1,101 @@
+package services_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BounceProcessor", func() {
+	var processor services.BounceProcessor
+	var messagesRepo *fakes.MessagesRepo
+	var suppressionsRepo *fakes.SuppressionsRepo
+	var dsn mail.DeliveryStatusNotification
+
+	BeforeEach(func() {
+		messagesRepo = fakes.NewMessagesRepo()
+		messagesRepo.Messages["message-123"] = models.Message{
+			ID:     "message-123",
+			Status: models.StatusDelivered,
+		}
+		suppressionsRepo = fakes.NewSuppressionsRepo()
+		processor = services.NewBounceProcessor(messagesRepo, suppressionsRepo, fakes.NewDatabase())
+
+		dsn = mail.DeliveryStatusNotification{
+			NotificationID: "message-123",
+			Recipients: []mail.DeliveryStatus{
+				{
+					FinalRecipient: "gone@example.com",
+					Action:         "failed",
+					Status:         "5.1.1",
+					DiagnosticCode: "550 5.1.1 user unknown",
+				},
+			},
+		}
+	})
+
+	Describe("Process", func() {
+		It("suppresses the hard-bounced recipients", func() {
+			err := processor.Process(dsn)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(suppressionsRepo.Suppressions["gone@example.com"]).To(Equal(models.Suppression{
+				Email:  "gone@example.com",
+				Reason: "Bounced with 5.1.1 550 5.1.1 user unknown",
+			}))
+		})
+
+		It("marks the original message as bounced", func() {
+			err := processor.Process(dsn)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(messagesRepo.Messages["message-123"].Status).To(Equal(models.StatusBounced))
+		})
+
+		It("ignores delays and transient failures", func() {
+			dsn.Recipients = []mail.DeliveryStatus{
+				{
+					FinalRecipient: "busy@example.com",
+					Action:         "delayed",
+					Status:         "4.2.2",
+				},
+			}
+
+			err := processor.Process(dsn)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(suppressionsRepo.Suppressions).To(BeEmpty())
+			Expect(messagesRepo.Messages["message-123"].Status).To(Equal(models.StatusDelivered))
+		})
+
+		It("still suppresses the recipients when the message is no longer known", func() {
+			dsn.NotificationID = "expired-message"
+
+			err := processor.Process(dsn)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(suppressionsRepo.Suppressions).To(HaveKey("gone@example.com"))
+			Expect(messagesRepo.Messages).NotTo(HaveKey("expired-message"))
+		})
+
+		It("returns errors from the suppressions repo", func() {
+			suppressionsRepo.UpsertError = errors.New("BOOM!")
+
+			err := processor.Process(dsn)
+			Expect(err).To(Equal(errors.New("BOOM!")))
+		})
+
+		It("returns errors from the messages repo", func() {
+			messagesRepo.FindByIDError = errors.New("BOOM!")
+
+			err := processor.Process(dsn)
+			Expect(err).To(Equal(errors.New("BOOM!")))
+		})
+	})
+})
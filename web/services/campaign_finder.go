@@ -0,0 +1,46 @@
+package services
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/models"
+)
+
+type Campaign struct {
+	ID        string
+	Status    string
+	Expanded  int
+	Queued    int
+	Delivered int
+}
+
+type CampaignFinderInterface interface {
+	Find(string) (Campaign, error)
+}
+
+type CampaignFinder struct {
+	campaignsRepo models.CampaignsRepoInterface
+	database      models.DatabaseInterface
+}
+
+func NewCampaignFinder(campaignsRepo models.CampaignsRepoInterface, database models.DatabaseInterface) CampaignFinder {
+	return CampaignFinder{
+		campaignsRepo: campaignsRepo,
+		database:      database,
+	}
+}
+
+func (finder CampaignFinder) Find(campaignID string) (Campaign, error) {
+	conn := finder.database.Connection()
+
+	campaign, err := finder.campaignsRepo.Find(conn, campaignID)
+	if err != nil {
+		return Campaign{}, err
+	}
+
+	return Campaign{
+		ID:        campaign.ID,
+		Status:    campaign.Status,
+		Expanded:  campaign.ExpandedCount,
+		Queued:    campaign.QueuedCount,
+		Delivered: campaign.DeliveredCount,
+	}, nil
+}
@@ -0,0 +1,46 @@
+package services_test
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CampaignFinder.Find", func() {
+	var finder services.CampaignFinder
+	var campaignsRepo *fakes.CampaignsRepo
+
+	BeforeEach(func() {
+		campaignsRepo = fakes.NewCampaignsRepo()
+		finder = services.NewCampaignFinder(campaignsRepo, fakes.NewDatabase())
+
+		campaignsRepo.Campaigns["campaign-123"] = models.Campaign{
+			ID:             "campaign-123",
+			Status:         models.CampaignStatusExpanding,
+			ExpandedCount:  3,
+			QueuedCount:    2,
+			DeliveredCount: 1,
+		}
+	})
+
+	It("reports how many recipients have been expanded, queued and delivered", func() {
+		campaign, err := finder.Find("campaign-123")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(campaign).To(Equal(services.Campaign{
+			ID:        "campaign-123",
+			Status:    models.CampaignStatusExpanding,
+			Expanded:  3,
+			Queued:    2,
+			Delivered: 1,
+		}))
+	})
+
+	Context("when the campaign does not exist", func() {
+		It("returns a RecordNotFoundError", func() {
+			_, err := finder.Find("missing-campaign")
+			Expect(err).To(BeAssignableToTypeOf(models.RecordNotFoundError("")))
+		})
+	})
+})
@@ -0,0 +1,31 @@
+package services
+
+import "github.com/cloudfoundry-incubator/notifications/gobble"
+
+type DeadJobPurgerInterface interface {
+	Purge(int) error
+	PurgeAll() (int, error)
+}
+
+type DeadJobPurger struct {
+	queue gobble.QueueInterface
+}
+
+func NewDeadJobPurger(queue gobble.QueueInterface) DeadJobPurger {
+	return DeadJobPurger{
+		queue: queue,
+	}
+}
+
+func (purger DeadJobPurger) Purge(id int) error {
+	err := purger.queue.PurgeDeadJob(id)
+	if err != nil {
+		return deadJobError(err, id)
+	}
+
+	return nil
+}
+
+func (purger DeadJobPurger) PurgeAll() (int, error) {
+	return purger.queue.PurgeDeadJobs()
+}
@@ -0,0 +1,69 @@
+package services_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeadJobPurger", func() {
+	var purger services.DeadJobPurger
+	var queue *fakes.Queue
+
+	BeforeEach(func() {
+		queue = fakes.NewQueue()
+		queue.DeadJobsList = []gobble.DeadJob{
+			{ID: 3, Payload: "first"},
+			{ID: 4, Payload: "second"},
+		}
+
+		purger = services.NewDeadJobPurger(queue)
+	})
+
+	Describe("Purge", func() {
+		It("purges the dead job from the queue", func() {
+			err := purger.Purge(3)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(queue.PurgedDeadJobIDs).To(Equal([]int{3}))
+			Expect(queue.DeadJobsList).To(Equal([]gobble.DeadJob{
+				{ID: 4, Payload: "second"},
+			}))
+		})
+
+		It("returns a RecordNotFoundError when the dead job does not exist", func() {
+			err := purger.Purge(99)
+			Expect(err).To(BeAssignableToTypeOf(models.RecordNotFoundError("")))
+		})
+
+		It("returns an error when the queue errors", func() {
+			queue.DeadJobsError = errors.New("BOOM!")
+
+			err := purger.Purge(3)
+			Expect(err).To(MatchError(queue.DeadJobsError))
+		})
+	})
+
+	Describe("PurgeAll", func() {
+		It("purges every dead job from the queue", func() {
+			count, err := purger.PurgeAll()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(2))
+
+			Expect(queue.DeadJobsList).To(BeEmpty())
+		})
+
+		It("returns an error when the queue errors", func() {
+			queue.DeadJobsError = errors.New("BOOM!")
+
+			_, err := purger.PurgeAll()
+			Expect(err).To(MatchError(queue.DeadJobsError))
+		})
+	})
+})
@@ -0,0 +1,26 @@
+package services
+
+import "github.com/cloudfoundry-incubator/notifications/gobble"
+
+type DeadJobReplayerInterface interface {
+	Replay(int) error
+}
+
+type DeadJobReplayer struct {
+	queue gobble.QueueInterface
+}
+
+func NewDeadJobReplayer(queue gobble.QueueInterface) DeadJobReplayer {
+	return DeadJobReplayer{
+		queue: queue,
+	}
+}
+
+func (replayer DeadJobReplayer) Replay(id int) error {
+	_, err := replayer.queue.ReplayDeadJob(id)
+	if err != nil {
+		return deadJobError(err, id)
+	}
+
+	return nil
+}
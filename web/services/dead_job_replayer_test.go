@@ -0,0 +1,49 @@
+package services_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeadJobReplayer", func() {
+	var replayer services.DeadJobReplayer
+	var queue *fakes.Queue
+
+	BeforeEach(func() {
+		queue = fakes.NewQueue()
+		queue.DeadJobsList = []gobble.DeadJob{
+			{ID: 3, Payload: "the-payload"},
+		}
+
+		replayer = services.NewDeadJobReplayer(queue)
+	})
+
+	Describe("Replay", func() {
+		It("replays the dead job on the queue", func() {
+			err := replayer.Replay(3)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(queue.ReplayedDeadJobIDs).To(Equal([]int{3}))
+			Expect(queue.DeadJobsList).To(BeEmpty())
+		})
+
+		It("returns a RecordNotFoundError when the dead job does not exist", func() {
+			err := replayer.Replay(99)
+			Expect(err).To(BeAssignableToTypeOf(models.RecordNotFoundError("")))
+		})
+
+		It("returns an error when the queue errors", func() {
+			queue.DeadJobsError = errors.New("BOOM!")
+
+			err := replayer.Replay(3)
+			Expect(err).To(MatchError(queue.DeadJobsError))
+		})
+	})
+})
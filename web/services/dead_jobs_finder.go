@@ -0,0 +1,75 @@
+package services
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/models"
+)
+
+type DeadJob struct {
+	ID            int       `json:"id"`
+	Payload       string    `json:"payload"`
+	RetryCount    int       `json:"retry_count"`
+	LastError     string    `json:"last_error"`
+	LastAttemptAt time.Time `json:"last_attempt_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func NewDeadJob(deadJob gobble.DeadJob) DeadJob {
+	return DeadJob{
+		ID:            deadJob.ID,
+		Payload:       deadJob.Payload,
+		RetryCount:    deadJob.RetryCount,
+		LastError:     deadJob.LastError,
+		LastAttemptAt: deadJob.LastAttemptAt,
+		CreatedAt:     deadJob.CreatedAt,
+	}
+}
+
+type DeadJobsFinderInterface interface {
+	List() ([]DeadJob, error)
+	Find(int) (DeadJob, error)
+}
+
+type DeadJobsFinder struct {
+	queue gobble.QueueInterface
+}
+
+func NewDeadJobsFinder(queue gobble.QueueInterface) DeadJobsFinder {
+	return DeadJobsFinder{
+		queue: queue,
+	}
+}
+
+func (finder DeadJobsFinder) List() ([]DeadJob, error) {
+	deadJobs, err := finder.queue.DeadJobs()
+	if err != nil {
+		return []DeadJob{}, err
+	}
+
+	results := []DeadJob{}
+	for _, deadJob := range deadJobs {
+		results = append(results, NewDeadJob(deadJob))
+	}
+
+	return results, nil
+}
+
+func (finder DeadJobsFinder) Find(id int) (DeadJob, error) {
+	deadJob, err := finder.queue.FindDeadJob(id)
+	if err != nil {
+		return DeadJob{}, deadJobError(err, id)
+	}
+
+	return NewDeadJob(deadJob), nil
+}
+
+func deadJobError(err error, id int) error {
+	if err == sql.ErrNoRows {
+		return models.NewRecordNotFoundError("Dead job with ID %d could not be found", id)
+	}
+
+	return err
+}
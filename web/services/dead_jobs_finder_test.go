@@ -0,0 +1,108 @@
+package services_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeadJobsFinder", func() {
+	var finder services.DeadJobsFinder
+	var queue *fakes.Queue
+	var createdAt, lastAttemptAt time.Time
+
+	BeforeEach(func() {
+		createdAt = time.Now().Truncate(1 * time.Second)
+		lastAttemptAt = createdAt.Add(-1 * time.Minute)
+
+		queue = fakes.NewQueue()
+		queue.DeadJobsList = []gobble.DeadJob{
+			{
+				ID:            3,
+				JobID:         42,
+				Payload:       `{"message_id":"message-123"}`,
+				RetryCount:    10,
+				LastError:     "connection refused",
+				LastAttemptAt: lastAttemptAt,
+				CreatedAt:     createdAt,
+			},
+			{
+				ID:      4,
+				JobID:   43,
+				Payload: `{"message_id":"message-456"}`,
+			},
+		}
+
+		finder = services.NewDeadJobsFinder(queue)
+	})
+
+	Describe("List", func() {
+		It("returns all of the dead jobs in the queue", func() {
+			deadJobs, err := finder.List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deadJobs).To(Equal([]services.DeadJob{
+				{
+					ID:            3,
+					Payload:       `{"message_id":"message-123"}`,
+					RetryCount:    10,
+					LastError:     "connection refused",
+					LastAttemptAt: lastAttemptAt,
+					CreatedAt:     createdAt,
+				},
+				{
+					ID:      4,
+					Payload: `{"message_id":"message-456"}`,
+				},
+			}))
+		})
+
+		It("returns an empty list when there are no dead jobs", func() {
+			queue.DeadJobsList = []gobble.DeadJob{}
+
+			deadJobs, err := finder.List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deadJobs).To(BeEmpty())
+		})
+
+		It("returns an error when the queue errors", func() {
+			queue.DeadJobsError = errors.New("BOOM!")
+
+			_, err := finder.List()
+			Expect(err).To(MatchError(queue.DeadJobsError))
+		})
+	})
+
+	Describe("Find", func() {
+		It("returns the dead job with the given id", func() {
+			deadJob, err := finder.Find(3)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deadJob).To(Equal(services.DeadJob{
+				ID:            3,
+				Payload:       `{"message_id":"message-123"}`,
+				RetryCount:    10,
+				LastError:     "connection refused",
+				LastAttemptAt: lastAttemptAt,
+				CreatedAt:     createdAt,
+			}))
+		})
+
+		It("returns a RecordNotFoundError when the dead job does not exist", func() {
+			_, err := finder.Find(99)
+			Expect(err).To(BeAssignableToTypeOf(models.RecordNotFoundError("")))
+		})
+
+		It("returns an error when the queue errors", func() {
+			queue.DeadJobsError = errors.New("BOOM!")
+
+			_, err := finder.Find(3)
+			Expect(err).To(MatchError(queue.DeadJobsError))
+		})
+	})
+})
@@ -29,3 +29,9 @@ type TemplateAssignmentError string
 func (err TemplateAssignmentError) Error() string {
 	return string(err)
 }
+
+type MessageNotCancelableError string
+
+func (err MessageNotCancelableError) Error() string {
+	return string(err)
+}
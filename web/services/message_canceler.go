@@ -0,0 +1,56 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/models"
+)
+
+type MessageCancelerInterface interface {
+	Cancel(string) error
+}
+
+type MessageCanceler struct {
+	repo     MessagesRepoInterface
+	queue    gobble.QueueInterface
+	database models.DatabaseInterface
+}
+
+func NewMessageCanceler(repo MessagesRepoInterface, queue gobble.QueueInterface, database models.DatabaseInterface) MessageCanceler {
+	return MessageCanceler{
+		repo:     repo,
+		queue:    queue,
+		database: database,
+	}
+}
+
+func (canceler MessageCanceler) Cancel(messageID string) error {
+	conn := canceler.database.Connection()
+
+	message, err := canceler.repo.FindByID(conn, messageID)
+	if err != nil {
+		return err
+	}
+
+	if message.Status != models.StatusQueued && message.Status != models.StatusScheduled {
+		return MessageNotCancelableError(fmt.Sprintf("Message with ID %q is %s and can no longer be canceled", messageID, message.Status))
+	}
+
+	err = canceler.queue.Cancel(messageID)
+	if err != nil {
+		if err == sql.ErrNoRows || err == gobble.ErrJobReserved {
+			return MessageNotCancelableError(fmt.Sprintf("Message with ID %q is already being delivered and can no longer be canceled", messageID))
+		}
+		return err
+	}
+
+	message.Status = models.StatusCanceled
+	_, err = canceler.repo.Upsert(conn, message)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
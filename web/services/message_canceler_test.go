@@ -0,0 +1,112 @@
+package services_test
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/postal"
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MessageCanceler", func() {
+	var canceler services.MessageCanceler
+	var messagesRepo *fakes.MessagesRepo
+	var queue *fakes.Queue
+
+	BeforeEach(func() {
+		messagesRepo = fakes.NewMessagesRepo()
+		messagesRepo.Messages["scheduled-message"] = models.Message{
+			ID:     "scheduled-message",
+			Status: postal.StatusScheduled,
+		}
+		messagesRepo.Messages["queued-message"] = models.Message{
+			ID:     "queued-message",
+			Status: postal.StatusQueued,
+		}
+		messagesRepo.Messages["failed-message"] = models.Message{
+			ID:     "failed-message",
+			Status: postal.StatusFailed,
+		}
+		messagesRepo.Messages["delivered-message"] = models.Message{
+			ID:     "delivered-message",
+			Status: postal.StatusDelivered,
+		}
+		queue = fakes.NewQueue()
+
+		canceler = services.NewMessageCanceler(messagesRepo, queue, fakes.NewDatabase())
+	})
+
+	Describe("Cancel", func() {
+		It("removes the job from the queue and marks the message as canceled", func() {
+			err := canceler.Cancel("scheduled-message")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(queue.CanceledReferences).To(Equal([]string{"scheduled-message"}))
+			Expect(messagesRepo.Messages["scheduled-message"].Status).To(Equal(postal.StatusCanceled))
+		})
+
+		It("cancels messages that are queued", func() {
+			err := canceler.Cancel("queued-message")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(queue.CanceledReferences).To(Equal([]string{"queued-message"}))
+			Expect(messagesRepo.Messages["queued-message"].Status).To(Equal(postal.StatusCanceled))
+		})
+
+		It("returns a RecordNotFoundError when the message does not exist", func() {
+			err := canceler.Cancel("missing-message")
+			Expect(err).To(BeAssignableToTypeOf(models.RecordNotFoundError("")))
+		})
+
+		It("returns a MessageNotCancelableError when delivery of the message has finished", func() {
+			err := canceler.Cancel("delivered-message")
+			Expect(err).To(BeAssignableToTypeOf(services.MessageNotCancelableError("")))
+
+			Expect(queue.CanceledReferences).To(BeEmpty())
+			Expect(messagesRepo.Messages["delivered-message"].Status).To(Equal(postal.StatusDelivered))
+		})
+
+		It("returns a MessageNotCancelableError when delivery of the message has been attempted", func() {
+			err := canceler.Cancel("failed-message")
+			Expect(err).To(BeAssignableToTypeOf(services.MessageNotCancelableError("")))
+
+			Expect(queue.CanceledReferences).To(BeEmpty())
+			Expect(messagesRepo.Messages["failed-message"].Status).To(Equal(postal.StatusFailed))
+		})
+
+		It("returns a MessageNotCancelableError when the job has already been reserved", func() {
+			queue.CancelError = gobble.ErrJobReserved
+
+			err := canceler.Cancel("scheduled-message")
+			Expect(err).To(BeAssignableToTypeOf(services.MessageNotCancelableError("")))
+			Expect(messagesRepo.Messages["scheduled-message"].Status).To(Equal(postal.StatusScheduled))
+		})
+
+		It("returns a MessageNotCancelableError when the job has already left the queue", func() {
+			queue.CancelError = sql.ErrNoRows
+
+			err := canceler.Cancel("scheduled-message")
+			Expect(err).To(BeAssignableToTypeOf(services.MessageNotCancelableError("")))
+		})
+
+		It("returns an error when the queue errors", func() {
+			queue.CancelError = errors.New("BOOM!")
+
+			err := canceler.Cancel("scheduled-message")
+			Expect(err).To(MatchError(queue.CancelError))
+		})
+
+		It("returns an error when the message cannot be updated", func() {
+			messagesRepo.UpsertError = errors.New("BOOM!")
+
+			err := canceler.Cancel("scheduled-message")
+			Expect(err).To(MatchError(messagesRepo.UpsertError))
+		})
+	})
+})
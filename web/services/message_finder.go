@@ -3,11 +3,15 @@ package services
 import "github.com/cloudfoundry-incubator/notifications/models"
 
 type Message struct {
-	Status string
+	Status          string
+	Relay           string
+	TemplateID      string
+	TemplateVersion int
 }
 
 type MessagesRepoInterface interface {
 	FindByID(models.ConnectionInterface, string) (models.Message, error)
+	Upsert(models.ConnectionInterface, models.Message) (models.Message, error)
 }
 
 type MessageFinder struct {
@@ -28,5 +32,10 @@ func (finder MessageFinder) Find(messageID string) (Message, error) {
 		return Message{}, err
 	}
 
-	return Message{Status: message.Status}, nil
+	return Message{
+		Status:          message.Status,
+		Relay:           message.Relay,
+		TemplateID:      message.TemplateID,
+		TemplateVersion: message.TemplateVersion,
+	}, nil
 }
@@ -26,12 +26,20 @@ var _ = Describe("MessageFinder.Find", func() {
 	Context("when a message exists with the given id", func() {
 		It("Returns the right Message struct", func() {
 
-			messagesRepo.Messages[messageID] = models.Message{Status: postal.StatusDelivered}
+			messagesRepo.Messages[messageID] = models.Message{
+				Status:          postal.StatusDelivered,
+				Relay:           "smtp.example.com:587",
+				TemplateID:      "some-template",
+				TemplateVersion: 2,
+			}
 
 			message, err := finder.Find(messageID)
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(message.Status).To(Equal(postal.StatusDelivered))
+			Expect(message.Relay).To(Equal("smtp.example.com:587"))
+			Expect(message.TemplateID).To(Equal("some-template"))
+			Expect(message.TemplateVersion).To(Equal(2))
 		})
 	})
 
@@ -15,7 +15,14 @@ func NewNotificationsUpdater(kindsRepo models.KindsRepoInterface, database model
 }
 
 func (updater NotificationsUpdater) Update(notification models.Kind) error {
-	_, err := updater.kindsRepo.Update(updater.database.Connection(), notification)
+	conn := updater.database.Connection()
+
+	existingKind, err := updater.kindsRepo.Find(conn, notification.ID, notification.ClientID)
+	if err == nil {
+		notification.RetryPolicy = existingKind.RetryPolicy
+	}
+
+	_, err = updater.kindsRepo.Update(conn, notification)
 	if err != nil {
 		return err
 	}
@@ -52,6 +52,25 @@ var _ = Describe("NotificationUpdater", func() {
 			Expect(updatedKind.ClientID).To(Equal(clientID))
 		})
 
+		It("keeps the retry policy of the existing notification", func() {
+			policy := models.RetryPolicy{MaxAttempts: 3, BaseDelaySeconds: 30}
+			kindsRepo.Kinds[notificationID+clientID] = models.Kind{
+				ID:          notificationID,
+				ClientID:    clientID,
+				Description: "What a beautiful description",
+				RetryPolicy: policy,
+			}
+
+			err := notificationsUpdater.Update(models.Kind{
+				Description: "some-description",
+				ID:          notificationID,
+				ClientID:    clientID,
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(kindsRepo.Kinds[notificationID+clientID].RetryPolicy).To(Equal(policy))
+		})
+
 		It("propagates errors returned by the repo", func() {
 			boomError := errors.New("Boom")
 			kindsRepo.UpdateError = boomError
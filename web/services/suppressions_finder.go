@@ -0,0 +1,51 @@
+package services
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/models"
+)
+
+type Suppression struct {
+	Email     string    `json:"email"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewSuppression(suppression models.Suppression) Suppression {
+	return Suppression{
+		Email:     suppression.Email,
+		Reason:    suppression.Reason,
+		CreatedAt: suppression.CreatedAt,
+	}
+}
+
+type SuppressionsFinderInterface interface {
+	List() ([]Suppression, error)
+}
+
+type SuppressionsFinder struct {
+	suppressionsRepo models.SuppressionsRepoInterface
+	database         models.DatabaseInterface
+}
+
+func NewSuppressionsFinder(suppressionsRepo models.SuppressionsRepoInterface, database models.DatabaseInterface) SuppressionsFinder {
+	return SuppressionsFinder{
+		suppressionsRepo: suppressionsRepo,
+		database:         database,
+	}
+}
+
+func (finder SuppressionsFinder) List() ([]Suppression, error) {
+	suppressions, err := finder.suppressionsRepo.FindAll(finder.database.Connection())
+	if err != nil {
+		return []Suppression{}, err
+	}
+
+	results := []Suppression{}
+	for _, suppression := range suppressions {
+		results = append(results, NewSuppression(suppression))
+	}
+
+	return results, nil
+}
@@ -0,0 +1,60 @@
+package services_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SuppressionsFinder", func() {
+	var finder services.SuppressionsFinder
+	var suppressionsRepo *fakes.SuppressionsRepo
+	var createdAt time.Time
+
+	BeforeEach(func() {
+		createdAt = time.Now().Truncate(1 * time.Second)
+		suppressionsRepo = fakes.NewSuppressionsRepo()
+		suppressionsRepo.Suppressions["zed@example.com"] = models.Suppression{
+			Primary:   2,
+			Email:     "zed@example.com",
+			Reason:    "RCPT failed with 550 no such user",
+			CreatedAt: createdAt,
+		}
+		suppressionsRepo.Suppressions["amy@example.com"] = models.Suppression{
+			Primary: 1,
+			Email:   "amy@example.com",
+		}
+
+		finder = services.NewSuppressionsFinder(suppressionsRepo, fakes.NewDatabase())
+	})
+
+	Describe("List", func() {
+		It("returns every suppressed address", func() {
+			suppressions, err := finder.List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suppressions).To(Equal([]services.Suppression{
+				{
+					Email: "amy@example.com",
+				},
+				{
+					Email:     "zed@example.com",
+					Reason:    "RCPT failed with 550 no such user",
+					CreatedAt: createdAt,
+				},
+			}))
+		})
+
+		It("returns errors from the repo", func() {
+			suppressionsRepo.FindAllError = errors.New("BOOM!")
+
+			_, err := finder.List()
+			Expect(err).To(Equal(errors.New("BOOM!")))
+		})
+	})
+})
@@ -0,0 +1,33 @@
+package services
+
+import "github.com/cloudfoundry-incubator/notifications/models"
+
+type SuppressionsUpdaterInterface interface {
+	Add(models.Suppression) (Suppression, error)
+	Remove(string) error
+}
+
+type SuppressionsUpdater struct {
+	suppressionsRepo models.SuppressionsRepoInterface
+	database         models.DatabaseInterface
+}
+
+func NewSuppressionsUpdater(suppressionsRepo models.SuppressionsRepoInterface, database models.DatabaseInterface) SuppressionsUpdater {
+	return SuppressionsUpdater{
+		suppressionsRepo: suppressionsRepo,
+		database:         database,
+	}
+}
+
+func (updater SuppressionsUpdater) Add(suppression models.Suppression) (Suppression, error) {
+	suppression, err := updater.suppressionsRepo.Upsert(updater.database.Connection(), suppression)
+	if err != nil {
+		return Suppression{}, err
+	}
+
+	return NewSuppression(suppression), nil
+}
+
+func (updater SuppressionsUpdater) Remove(email string) error {
+	return updater.suppressionsRepo.Delete(updater.database.Connection(), email)
+}
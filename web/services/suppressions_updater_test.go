@@ -0,0 +1,60 @@
+package services_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SuppressionsUpdater", func() {
+	var updater services.SuppressionsUpdater
+	var suppressionsRepo *fakes.SuppressionsRepo
+
+	BeforeEach(func() {
+		suppressionsRepo = fakes.NewSuppressionsRepo()
+		updater = services.NewSuppressionsUpdater(suppressionsRepo, fakes.NewDatabase())
+	})
+
+	Describe("Add", func() {
+		It("suppresses the address", func() {
+			suppression, err := updater.Add(models.Suppression{
+				Email:  "bounced@example.com",
+				Reason: "because",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suppression).To(Equal(services.Suppression{
+				Email:  "bounced@example.com",
+				Reason: "because",
+			}))
+
+			Expect(suppressionsRepo.Suppressions).To(HaveKey("bounced@example.com"))
+		})
+
+		It("returns errors from the repo", func() {
+			suppressionsRepo.UpsertError = errors.New("BOOM!")
+
+			_, err := updater.Add(models.Suppression{Email: "bounced@example.com"})
+			Expect(err).To(Equal(errors.New("BOOM!")))
+		})
+	})
+
+	Describe("Remove", func() {
+		It("removes the suppression", func() {
+			suppressionsRepo.Suppressions["bounced@example.com"] = models.Suppression{Email: "bounced@example.com"}
+
+			err := updater.Remove("bounced@example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suppressionsRepo.Suppressions).To(BeEmpty())
+		})
+
+		It("returns a RecordNotFoundError when the address is not suppressed", func() {
+			err := updater.Remove("nobody@example.com")
+			Expect(err).To(BeAssignableToTypeOf(models.RecordNotFoundError("")))
+		})
+	})
+})
@@ -13,11 +13,13 @@ import (
 var _ = Describe("Creator", func() {
 	Describe("Create", func() {
 		var templatesRepo *fakes.TemplatesRepo
+		var versionsRepo *fakes.TemplateVersionsRepo
 		var template models.Template
 		var creator services.TemplateCreator
 
 		BeforeEach(func() {
 			templatesRepo = fakes.NewTemplatesRepo()
+			versionsRepo = fakes.NewTemplateVersionsRepo()
 			template = models.Template{
 				Name:    "Big Hero 6 Template",
 				Text:    "Adorable robot.",
@@ -25,12 +27,12 @@ var _ = Describe("Creator", func() {
 				Subject: "Robots and Heroes",
 			}
 
-			creator = services.NewTemplateCreator(templatesRepo, fakes.NewDatabase())
+			creator = services.NewTemplateCreator(templatesRepo, versionsRepo, fakes.NewDatabase())
 		})
 
 		It("Creates a new template via the templates repo", func() {
 			Expect(templatesRepo.Templates).ToNot(ContainElement(template))
-			_, err := creator.Create(template)
+			_, err := creator.Create(template, "some-client")
 			if err != nil {
 				panic(err)
 			}
@@ -39,11 +41,29 @@ var _ = Describe("Creator", func() {
 			Expect(templatesRepo.Templates).To(ContainElement(template))
 		})
 
+		It("records the template as its first version", func() {
+			_, err := creator.Create(template, "some-client")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(versionsRepo.Versions).To(Equal([]models.TemplateVersion{
+				models.NewTemplateVersion(template, "some-client"),
+			}))
+		})
+
 		It("propagates errors from repo", func() {
 			expectedErr := errors.New("Boom!")
 
 			templatesRepo.CreateError = expectedErr
-			_, err := creator.Create(template)
+			_, err := creator.Create(template, "some-client")
+
+			Expect(err).To(Equal(expectedErr))
+		})
+
+		It("propagates errors from the versions repo", func() {
+			expectedErr := errors.New("Boom!")
+
+			versionsRepo.CreateError = expectedErr
+			_, err := creator.Create(template, "some-client")
 
 			Expect(err).To(Equal(expectedErr))
 		})
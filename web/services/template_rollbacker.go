@@ -0,0 +1,38 @@
+package services
+
+import "github.com/cloudfoundry-incubator/notifications/models"
+
+type TemplateRollbackerInterface interface {
+	Rollback(string, int, string) error
+}
+
+type TemplateRollbacker struct {
+	versionsRepo models.TemplateVersionsRepoInterface
+	updater      TemplateUpdaterInterface
+	database     models.DatabaseInterface
+}
+
+func NewTemplateRollbacker(versionsRepo models.TemplateVersionsRepoInterface, updater TemplateUpdaterInterface, database models.DatabaseInterface) TemplateRollbacker {
+	return TemplateRollbacker{
+		versionsRepo: versionsRepo,
+		updater:      updater,
+		database:     database,
+	}
+}
+
+// Rollback restores the content of an earlier version. The history is left
+// intact: the restored content is saved as a new version.
+func (rollbacker TemplateRollbacker) Rollback(templateID string, number int, author string) error {
+	version, err := rollbacker.versionsRepo.Find(rollbacker.database.Connection(), templateID, number)
+	if err != nil {
+		return err
+	}
+
+	return rollbacker.updater.Update(templateID, models.Template{
+		Name:     version.Name,
+		Subject:  version.Subject,
+		Text:     version.Text,
+		HTML:     version.HTML,
+		Metadata: version.Metadata,
+	}, author)
+}
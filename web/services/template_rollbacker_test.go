@@ -0,0 +1,67 @@
+package services_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TemplateRollbacker", func() {
+	var rollbacker services.TemplateRollbacker
+	var versionsRepo *fakes.TemplateVersionsRepo
+	var updater *fakes.TemplateUpdater
+
+	BeforeEach(func() {
+		versionsRepo = fakes.NewTemplateVersionsRepo()
+		versionsRepo.Versions = []models.TemplateVersion{
+			{
+				TemplateID: "banana-template",
+				Version:    1,
+				Name:       "Banana",
+				Subject:    "{{.Subject}}",
+				Text:       "green",
+				HTML:       "<p>green</p>",
+				Metadata:   `{"color":"green"}`,
+				Author:     "some-client",
+			},
+		}
+		updater = fakes.NewTemplateUpdater()
+
+		rollbacker = services.NewTemplateRollbacker(versionsRepo, updater, fakes.NewDatabase())
+	})
+
+	Describe("Rollback", func() {
+		It("saves the content of the version as the template's next version", func() {
+			err := rollbacker.Rollback("banana-template", 1, "some-user")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(updater.UpdateArgumentID).To(Equal("banana-template"))
+			Expect(updater.UpdateArgumentBody).To(Equal(models.Template{
+				Name:     "Banana",
+				Subject:  "{{.Subject}}",
+				Text:     "green",
+				HTML:     "<p>green</p>",
+				Metadata: `{"color":"green"}`,
+			}))
+			Expect(updater.UpdateAuthor).To(Equal("some-user"))
+		})
+
+		It("returns an error when the version does not exist", func() {
+			err := rollbacker.Rollback("banana-template", 4, "some-user")
+			Expect(err).To(BeAssignableToTypeOf(models.RecordNotFoundError("")))
+			Expect(updater.UpdateArgumentID).To(BeEmpty())
+		})
+
+		It("propagates errors from the updater", func() {
+			updater.UpdateError = errors.New("boom")
+
+			err := rollbacker.Rollback("banana-template", 1, "some-user")
+			Expect(err).To(Equal(errors.New("boom")))
+		})
+	})
+})
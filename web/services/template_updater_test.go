@@ -13,32 +13,53 @@ import (
 var _ = Describe("Updater", func() {
 	Describe("#Update", func() {
 		var templatesRepo *fakes.TemplatesRepo
+		var versionsRepo *fakes.TemplateVersionsRepo
 		var template models.Template
 		var updater services.TemplateUpdater
 
 		BeforeEach(func() {
 			templatesRepo = fakes.NewTemplatesRepo()
+			versionsRepo = fakes.NewTemplateVersionsRepo()
 			template = models.Template{
 				Name: "gobble template",
 				Text: "gobble",
 				HTML: "<p>gobble</p>",
 			}
 
-			updater = services.NewTemplateUpdater(templatesRepo, fakes.NewDatabase())
+			updater = services.NewTemplateUpdater(templatesRepo, versionsRepo, fakes.NewDatabase())
 		})
 
 		It("Inserts templates into the templates repo", func() {
 			Expect(templatesRepo.Templates).ToNot(ContainElement(template))
-			err := updater.Update("my-awesome-id", template)
+			err := updater.Update("my-awesome-id", template, "some-user")
 			Expect(err).ToNot(HaveOccurred())
 			Expect(templatesRepo.Templates).To(ContainElement(template))
 		})
 
+		It("records the updated template as a new version", func() {
+			err := updater.Update("my-awesome-id", template, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(versionsRepo.Versions).To(Equal([]models.TemplateVersion{
+				models.NewTemplateVersion(template, "some-user"),
+			}))
+		})
+
 		It("propagates errors from repo", func() {
 			expectedErr := errors.New("Boom!")
 
 			templatesRepo.UpdateError = expectedErr
-			err := updater.Update("unimportant", template)
+			err := updater.Update("unimportant", template, "some-user")
+
+			Expect(err).To(Equal(expectedErr))
+			Expect(versionsRepo.Versions).To(BeEmpty())
+		})
+
+		It("propagates errors from the versions repo", func() {
+			expectedErr := errors.New("Boom!")
+
+			versionsRepo.CreateError = expectedErr
+			err := updater.Update("unimportant", template, "some-user")
 
 			Expect(err).To(Equal(expectedErr))
 		})
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/models"
+)
+
+type TemplateVersionDifferInterface interface {
+	Diff(string, int, int) (TemplateDiff, error)
+}
+
+// TemplateDiff holds a line diff for each field of a template. Removed lines
+// start with "-", added lines with "+" and unchanged lines with " ". Fields
+// that did not change are left empty.
+type TemplateDiff struct {
+	From     int
+	To       int
+	Name     string
+	Subject  string
+	Text     string
+	HTML     string
+	Metadata string
+}
+
+type TemplateVersionDiffer struct {
+	versionsRepo models.TemplateVersionsRepoInterface
+	database     models.DatabaseInterface
+}
+
+func NewTemplateVersionDiffer(versionsRepo models.TemplateVersionsRepoInterface, database models.DatabaseInterface) TemplateVersionDiffer {
+	return TemplateVersionDiffer{
+		versionsRepo: versionsRepo,
+		database:     database,
+	}
+}
+
+func (differ TemplateVersionDiffer) Diff(templateID string, from, to int) (TemplateDiff, error) {
+	conn := differ.database.Connection()
+
+	fromVersion, err := differ.versionsRepo.Find(conn, templateID, from)
+	if err != nil {
+		return TemplateDiff{}, err
+	}
+
+	toVersion, err := differ.versionsRepo.Find(conn, templateID, to)
+	if err != nil {
+		return TemplateDiff{}, err
+	}
+
+	return TemplateDiff{
+		From:     from,
+		To:       to,
+		Name:     diffLines(fromVersion.Name, toVersion.Name),
+		Subject:  diffLines(fromVersion.Subject, toVersion.Subject),
+		Text:     diffLines(fromVersion.Text, toVersion.Text),
+		HTML:     diffLines(fromVersion.HTML, toVersion.HTML),
+		Metadata: diffLines(fromVersion.Metadata, toVersion.Metadata),
+	}, nil
+}
+
+// diffLines compares two texts line by line, keeping the longest common
+// subsequence of lines and marking everything else as removed or added.
+func diffLines(before, after string) string {
+	if before == after {
+		return ""
+	}
+
+	a := strings.Split(before, "\n")
+	b := strings.Split(after, "\n")
+
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	var lines []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, " "+a[i])
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			lines = append(lines, "-"+a[i])
+			i++
+		default:
+			lines = append(lines, "+"+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, "-"+a[i])
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, "+"+b[j])
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,70 @@
+package services_test
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TemplateVersionDiffer", func() {
+	var differ services.TemplateVersionDiffer
+	var versionsRepo *fakes.TemplateVersionsRepo
+
+	BeforeEach(func() {
+		versionsRepo = fakes.NewTemplateVersionsRepo()
+		versionsRepo.Versions = []models.TemplateVersion{
+			{
+				TemplateID: "banana-template",
+				Version:    1,
+				Name:       "Banana",
+				Subject:    "{{.Subject}}",
+				Text:       "Hello,\nyour banana is green.\nBye",
+				HTML:       "<p>green</p>",
+				Metadata:   "{}",
+			},
+			{
+				TemplateID: "banana-template",
+				Version:    2,
+				Name:       "Banana",
+				Subject:    "Ripe: {{.Subject}}",
+				Text:       "Hello,\nyour banana is ripe.\nEat it soon.\nBye",
+				HTML:       "<p>green</p>",
+				Metadata:   "{}",
+			},
+		}
+
+		differ = services.NewTemplateVersionDiffer(versionsRepo, fakes.NewDatabase())
+	})
+
+	Describe("Diff", func() {
+		It("diffs each field of the two versions line by line", func() {
+			diff, err := differ.Diff("banana-template", 1, 2)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(diff).To(Equal(services.TemplateDiff{
+				From:    1,
+				To:      2,
+				Subject: "-{{.Subject}}\n+Ripe: {{.Subject}}",
+				Text:    " Hello,\n-your banana is green.\n+your banana is ripe.\n+Eat it soon.\n Bye",
+			}))
+		})
+
+		It("diffs in either direction", func() {
+			diff, err := differ.Diff("banana-template", 2, 1)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(diff.Text).To(Equal(" Hello,\n-your banana is ripe.\n-Eat it soon.\n+your banana is green.\n Bye"))
+		})
+
+		It("returns an error when either version does not exist", func() {
+			_, err := differ.Diff("banana-template", 1, 3)
+			Expect(err).To(BeAssignableToTypeOf(models.RecordNotFoundError("")))
+
+			_, err = differ.Diff("banana-template", 0, 2)
+			Expect(err).To(BeAssignableToTypeOf(models.RecordNotFoundError("")))
+		})
+	})
+})
@@ -0,0 +1,33 @@
+package services
+
+import "github.com/cloudfoundry-incubator/notifications/models"
+
+type TemplateVersionListerInterface interface {
+	List(string) ([]models.TemplateVersion, error)
+}
+
+type TemplateVersionLister struct {
+	templatesRepo models.TemplatesRepoInterface
+	versionsRepo  models.TemplateVersionsRepoInterface
+	database      models.DatabaseInterface
+}
+
+func NewTemplateVersionLister(templatesRepo models.TemplatesRepoInterface, versionsRepo models.TemplateVersionsRepoInterface, database models.DatabaseInterface) TemplateVersionLister {
+	return TemplateVersionLister{
+		templatesRepo: templatesRepo,
+		versionsRepo:  versionsRepo,
+		database:      database,
+	}
+}
+
+// List returns the versions of a template, newest first.
+func (lister TemplateVersionLister) List(templateID string) ([]models.TemplateVersion, error) {
+	conn := lister.database.Connection()
+
+	_, err := lister.templatesRepo.FindByID(conn, templateID)
+	if err != nil {
+		return []models.TemplateVersion{}, err
+	}
+
+	return lister.versionsRepo.List(conn, templateID)
+}
@@ -0,0 +1,45 @@
+package services_test
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/fakes"
+	"github.com/cloudfoundry-incubator/notifications/models"
+	"github.com/cloudfoundry-incubator/notifications/web/services"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TemplateVersionLister", func() {
+	var lister services.TemplateVersionLister
+	var templatesRepo *fakes.TemplatesRepo
+	var versionsRepo *fakes.TemplateVersionsRepo
+
+	BeforeEach(func() {
+		templatesRepo = fakes.NewTemplatesRepo()
+		versionsRepo = fakes.NewTemplateVersionsRepo()
+		lister = services.NewTemplateVersionLister(templatesRepo, versionsRepo, fakes.NewDatabase())
+	})
+
+	Describe("List", func() {
+		It("returns the versions of the template, newest first", func() {
+			templatesRepo.Templates["banana-template"] = models.Template{ID: "banana-template", Version: 2}
+			versionsRepo.Versions = []models.TemplateVersion{
+				{TemplateID: "banana-template", Version: 1, Text: "green"},
+				{TemplateID: "other-template", Version: 1, Text: "other"},
+				{TemplateID: "banana-template", Version: 2, Text: "ripe"},
+			}
+
+			versions, err := lister.List("banana-template")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions).To(Equal([]models.TemplateVersion{
+				{TemplateID: "banana-template", Version: 2, Text: "ripe"},
+				{TemplateID: "banana-template", Version: 1, Text: "green"},
+			}))
+		})
+
+		It("returns an error when the template does not exist", func() {
+			_, err := lister.List("missing-template")
+			Expect(err).To(BeAssignableToTypeOf(models.RecordNotFoundError("")))
+		})
+	})
+})